@@ -0,0 +1,334 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkPolicy specifies how symbolic links are handled when walking a directory.
+type SymlinkPolicy uint8
+
+const (
+	// SymlinkSkip ignores symbolic links.
+	SymlinkSkip SymlinkPolicy = iota
+	// SymlinkFollow follows symbolic links to files and directories.
+	// Links pointing to a directory that has already been walked are ignored to avoid cycles.
+	SymlinkFollow
+)
+
+// ErrFileTooLarge is reported for files larger than DirConfig.MaxFileSize.
+var ErrFileTooLarge = errors.New("file size exceeds the limit")
+
+// DirConfig enables the directory mode of FileLoader.
+// In directory mode, the source URI can be a directory or a glob pattern such as "./docs/**/*.md",
+// every matched file is parsed by FileLoaderConfig.Parser.
+type DirConfig struct {
+	// Include specifies the glob patterns of files to load, all files are loaded by default.
+	// Patterns containing '/' are matched against the slash separated path relative to the root directory,
+	// and '**' matches zero or more directories. Other patterns are matched against the file name only.
+	// e.g. []string{"*.md", "docs/**/*.txt"}
+	Include []string
+	// Exclude specifies the glob patterns of files and directories to skip, the syntax is the same as Include.
+	// Excluded directories are not walked.
+	Exclude []string
+	// MaxDepth limits how deep the directory is walked, files directly under the root directory are at depth 1.
+	// 0 means no limit.
+	MaxDepth int
+	// SymlinkPolicy specifies how symbolic links are handled. SymlinkSkip by default.
+	SymlinkPolicy SymlinkPolicy
+	// MaxFileSize is the maximum size in bytes of a single file, larger files are reported with ErrFileTooLarge.
+	// 0 means no limit.
+	MaxFileSize int64
+	// ContinueOnError specifies whether to keep loading the remaining files when a file fails.
+	// If true, Load returns the documents of all successfully loaded files, together with an error joining
+	// a *FileError for every failed file.
+	ContinueOnError bool
+}
+
+// FileError is the error of a single file in directory mode.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("load file [%s] fail: %v", e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// globPattern is a compiled glob pattern, anchored patterns are matched against the relative path.
+type globPattern struct {
+	segments []string
+	anchored bool
+}
+
+func compileGlob(pattern string, anchored bool) (globPattern, error) {
+	pattern = strings.Trim(filepath.ToSlash(pattern), "/")
+	if pattern == "" {
+		return globPattern{}, errors.New("glob pattern is empty")
+	}
+	segments := strings.Split(pattern, "/")
+	for _, seg := range segments {
+		if seg == "**" {
+			continue
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return globPattern{}, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+	return globPattern{
+		segments: segments,
+		anchored: anchored || len(segments) > 1,
+	}, nil
+}
+
+func (g globPattern) match(relPath string) bool {
+	if !g.anchored {
+		ok, _ := path.Match(g.segments[0], path.Base(relPath))
+		return ok
+	}
+	return matchSegments(g.segments, strings.Split(relPath, "/"))
+}
+
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range parts {
+				if matchSegments(pattern, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
+
+func hasGlobMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// splitGlobURI splits a glob uri into the root directory to walk and the pattern relative to it.
+func splitGlobURI(uri string) (root string, pattern string) {
+	if !hasGlobMeta(uri) {
+		return uri, ""
+	}
+	parts := strings.Split(filepath.ToSlash(uri), "/")
+	for i, part := range parts {
+		if !hasGlobMeta(part) {
+			continue
+		}
+		root = strings.Join(parts[:i], "/")
+		pattern = strings.Join(parts[i:], "/")
+		break
+	}
+	if root == "" {
+		if strings.HasPrefix(uri, "/") {
+			root = "/"
+		} else {
+			root = "."
+		}
+	}
+	return filepath.Clean(filepath.FromSlash(root)), pattern
+}
+
+type dirWalker struct {
+	conf       *DirConfig
+	include    []globPattern
+	exclude    []globPattern
+	uriPattern *globPattern
+	visited    map[string]bool
+
+	// onFile is called for every matched regular file.
+	onFile func(path, relPath string, info fs.FileInfo) error
+	// onError is called for every per-file error, the walk stops if it returns an error.
+	onError func(path string, err error) error
+}
+
+func newDirWalker(conf *DirConfig, uriPattern string) (*dirWalker, error) {
+	w := &dirWalker{
+		conf:    conf,
+		visited: make(map[string]bool),
+	}
+	for _, p := range conf.Include {
+		g, err := compileGlob(p, false)
+		if err != nil {
+			return nil, err
+		}
+		w.include = append(w.include, g)
+	}
+	for _, p := range conf.Exclude {
+		g, err := compileGlob(p, false)
+		if err != nil {
+			return nil, err
+		}
+		w.exclude = append(w.exclude, g)
+	}
+	if uriPattern != "" {
+		g, err := compileGlob(uriPattern, true)
+		if err != nil {
+			return nil, err
+		}
+		w.uriPattern = &g
+	}
+	return w, nil
+}
+
+func (w *dirWalker) excluded(relPath string) bool {
+	for _, g := range w.exclude {
+		if g.match(relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *dirWalker) included(relPath string) bool {
+	if w.uriPattern != nil && !w.uriPattern.match(relPath) {
+		return false
+	}
+	if len(w.include) == 0 {
+		return true
+	}
+	for _, g := range w.include {
+		if g.match(relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matched reports whether a file at relPath would be loaded by the walker, regardless of whether it exists.
+func (w *dirWalker) matched(relPath string) bool {
+	parts := strings.Split(relPath, "/")
+	if w.conf.MaxDepth > 0 && len(parts) > w.conf.MaxDepth {
+		return false
+	}
+	for i := 1; i < len(parts); i++ {
+		if w.excluded(strings.Join(parts[:i], "/")) {
+			return false
+		}
+	}
+	return !w.excluded(relPath) && w.included(relPath)
+}
+
+func (w *dirWalker) walkRoot(root string) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("read dir from path, error while checking dir stat: %w, path= %s", err, root)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("read dir from path can only accept dir path, actual= %s", root)
+	}
+	if real, err := filepath.EvalSymlinks(root); err == nil {
+		w.visited[real] = true
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("read dir from path failed with err: %w, path= %s", err, root)
+	}
+	return w.walk(root, "", 1, entries)
+}
+
+func (w *dirWalker) walk(dir, relDir string, depth int, entries []fs.DirEntry) error {
+	for _, entry := range entries {
+		fullPath := filepath.Join(dir, entry.Name())
+		relPath := path.Join(relDir, entry.Name())
+
+		var (
+			info fs.FileInfo
+			err  error
+		)
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if w.conf.SymlinkPolicy != SymlinkFollow {
+				continue
+			}
+			info, err = os.Stat(fullPath)
+		} else {
+			info, err = entry.Info()
+		}
+		if err != nil {
+			if err = w.onError(fullPath, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if info.IsDir() {
+			if w.excluded(relPath) || (w.conf.MaxDepth > 0 && depth >= w.conf.MaxDepth) {
+				continue
+			}
+			if err = w.walkDir(fullPath, relPath, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !info.Mode().IsRegular() || w.excluded(relPath) || !w.included(relPath) {
+			continue
+		}
+		if w.conf.MaxFileSize > 0 && info.Size() > w.conf.MaxFileSize {
+			if err = w.onError(fullPath, fmt.Errorf("%w: size= %d, limit= %d", ErrFileTooLarge, info.Size(), w.conf.MaxFileSize)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = w.onFile(fullPath, relPath, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *dirWalker) walkDir(dir, relDir string, depth int) error {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return w.onError(dir, err)
+	}
+	if w.visited[real] {
+		return nil
+	}
+	w.visited[real] = true
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return w.onError(dir, err)
+	}
+	return w.walk(dir, relDir, depth, entries)
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+func docIDs(docs []*schema.Document) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func TestFileLoader_LoadDir(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without dir config", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: "./testdata/dir"})
+		assert.ErrorContains(t, err, "non-dir path")
+	})
+
+	t.Run("walk all", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+			UseNameAsID: true,
+			DirConfig:   &DirConfig{},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: "./testdata/dir"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "b.txt", "drafts/e.md", "sub/c.md", "sub/deep/d.md"}, docIDs(docs))
+		assert.Equal(t, "c.md", docs[3].MetaData[MetaKeyFileName])
+		assert.Equal(t, ".md", docs[3].MetaData[MetaKeyExtension])
+		assert.Equal(t, filepath.Join("testdata", "dir", "sub", "c.md"), docs[3].MetaData[MetaKeySource])
+		assert.Equal(t, "# C\n\ncharlie", docs[3].Content)
+	})
+
+	t.Run("include, exclude and max depth", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+			UseNameAsID: true,
+			DirConfig: &DirConfig{
+				Include:  []string{"*.md"},
+				Exclude:  []string{"drafts"},
+				MaxDepth: 2,
+			},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: "./testdata/dir"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "sub/c.md"}, docIDs(docs))
+	})
+
+	t.Run("glob uri", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+			UseNameAsID: true,
+			DirConfig: &DirConfig{
+				Exclude: []string{"sub/deep/**"},
+			},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: "./testdata/dir/**/*.md"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "drafts/e.md", "sub/c.md"}, docIDs(docs))
+
+		docs, err = loader.Load(ctx, document.Source{URI: "./testdata/dir/*.md"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md"}, docIDs(docs))
+	})
+
+	t.Run("single file in dir mode", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+			UseNameAsID: true,
+			DirConfig:   &DirConfig{},
+		})
+		assert.NoError(t, err)
+
+		docs, err := loader.Load(ctx, document.Source{URI: "./testdata/test.md"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"test.md"}, docIDs(docs))
+	})
+
+	t.Run("max file size", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+			UseNameAsID: true,
+			DirConfig: &DirConfig{
+				MaxFileSize: 9,
+			},
+		})
+		assert.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: "./testdata/dir"})
+		assert.ErrorIs(t, err, ErrFileTooLarge)
+
+		loader.DirConfig.ContinueOnError = true
+		docs, err := loader.Load(ctx, document.Source{URI: "./testdata/dir"})
+		assert.ErrorIs(t, err, ErrFileTooLarge)
+		assert.Equal(t, []string{"b.txt", "drafts/e.md"}, docIDs(docs))
+
+		var fileErr *FileError
+		assert.True(t, errors.As(err, &fileErr))
+		assert.Equal(t, filepath.Join("testdata", "dir", "a.md"), fileErr.Path)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+			DirConfig: &DirConfig{
+				Include: []string{"[a-"},
+			},
+		})
+		assert.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: "./testdata/dir"})
+		assert.ErrorContains(t, err, "invalid glob pattern")
+	})
+}
+
+func TestFileLoader_LoadDirSymlink(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	target := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("a"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(target, "b.txt"), []byte("b"), 0o644))
+	if err := os.Symlink(target, filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlink not supported: %v", err)
+	}
+	assert.NoError(t, os.Symlink(root, filepath.Join(target, "loop")))
+
+	loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+		UseNameAsID: true,
+		DirConfig:   &DirConfig{},
+	})
+	assert.NoError(t, err)
+
+	docs, err := loader.Load(ctx, document.Source{URI: root})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt"}, docIDs(docs))
+
+	loader.DirConfig.SymlinkPolicy = SymlinkFollow
+	docs, err = loader.Load(ctx, document.Source{URI: root})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "linked/b.txt"}, docIDs(docs))
+}
+
+func TestGlobPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		anchored bool
+		path     string
+		want     bool
+	}{
+		{pattern: "*.md", path: "a.md", want: true},
+		{pattern: "*.md", path: "x/y/a.md", want: true},
+		{pattern: "*.md", anchored: true, path: "x/a.md", want: false},
+		{pattern: "x/*.md", path: "x/a.md", want: true},
+		{pattern: "x/*.md", path: "x/y/a.md", want: false},
+		{pattern: "**/*.md", path: "a.md", want: true},
+		{pattern: "**/*.md", path: "x/y/a.md", want: true},
+		{pattern: "x/**", path: "x", want: true},
+		{pattern: "x/**/z/*.go", path: "x/y/z/main.go", want: true},
+		{pattern: "x/**/z/*.go", path: "x/y/main.go", want: false},
+	}
+	for _, tt := range tests {
+		g, err := compileGlob(tt.pattern, tt.anchored)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, g.match(tt.path), "pattern= %s, path= %s", tt.pattern, tt.path)
+	}
+
+	root, pattern := splitGlobURI("./docs/**/*.md")
+	assert.Equal(t, "docs", filepath.ToSlash(root))
+	assert.Equal(t, "**/*.md", pattern)
+
+	root, pattern = splitGlobURI("*.md")
+	assert.Equal(t, ".", root)
+	assert.Equal(t, "*.md", pattern)
+}
@@ -21,14 +21,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
 )
 
 const (
@@ -40,6 +44,15 @@ const (
 type FileLoaderConfig struct {
 	UseNameAsID bool
 	Parser      parser.Parser
+	// DirConfig enables loading a directory or a glob pattern, see DirConfig for details.
+	// If nil, only a single file path is accepted.
+	// In directory mode, UseNameAsID uses the slash separated path relative to the root directory as the name.
+	DirConfig *DirConfig
+	// Tracker enables incremental loading, files are tracked by their absolute paths.
+	// Unchanged files are skipped or marked with tracker.MetaKeyChange, depending on the tracker config.
+	// In directory mode, files under the root directory which are matched before but deleted now are
+	// reported with an empty document marked as tracker.ChangeDeleted, if ReportDeleted is set.
+	Tracker *tracker.Tracker
 }
 
 // FileLoader loads a local file and use its content directly as Document's content.
@@ -80,31 +93,67 @@ func (f *FileLoader) Load(ctx context.Context, src document.Source, opts ...docu
 		}
 	}()
 
-	file, err := openFile(src.URI)
-	if err != nil {
+	if f.Parser == nil {
+		return nil, errors.New("no parser specified")
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	if f.DirConfig != nil {
+		docs, err = f.loadDir(ctx, src.URI, o)
+	} else {
+		docs, err = f.loadFile(ctx, src.URI, filepath.Base(src.URI), o)
+	}
+	if err != nil && len(docs) == 0 {
 		return nil, err
 	}
 
-	defer file.Close()
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
 
-	name := filepath.Base(src.URI)
-	ext := filepath.Ext(src.URI)
+	return docs, err
+}
 
-	meta := map[string]any{
-		MetaKeyExtension: ext,
-		MetaKeyFileName:  name,
-		MetaKeySource:    src.URI,
+// loadFile parses a single file, name is used to generate document IDs if UseNameAsID is set.
+func (f *FileLoader) loadFile(ctx context.Context, path, name string, o *document.LoaderOptions) ([]*schema.Document, error) {
+	var (
+		key    string
+		state  *tracker.State
+		change tracker.ChangeType
+	)
+	if f.Tracker != nil {
+		var err error
+		key, state, change, err = f.detectChange(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if change == tracker.ChangeUnchanged && f.Tracker.SkipUnchanged() {
+			if state == nil {
+				return nil, nil
+			}
+			// the file is touched without content changes, record the new mod time
+			return nil, f.Tracker.Commit(ctx, key, state)
+		}
 	}
 
-	if f.Parser == nil {
-		return nil, errors.New("no parser specified")
+	file, err := openFile(path)
+	if err != nil {
+		return nil, err
 	}
 
-	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	defer file.Close()
+
+	meta := map[string]any{
+		MetaKeyExtension: filepath.Ext(path),
+		MetaKeyFileName:  filepath.Base(path),
+		MetaKeySource:    path,
+	}
 
-	docs, err = f.Parser.Parse(ctx, file, append([]parser.Option{parser.WithURI(src.URI), parser.WithExtraMeta(meta)}, o.ParserOptions...)...)
+	docs, err := f.Parser.Parse(ctx, file, append([]parser.Option{parser.WithURI(path), parser.WithExtraMeta(meta)}, o.ParserOptions...)...)
 	if err != nil {
-		return nil, fmt.Errorf("file parse err of [%s]: %w", src.URI, err)
+		return nil, fmt.Errorf("file parse err of [%s]: %w", path, err)
 	}
 
 	if f.UseNameAsID {
@@ -117,14 +166,191 @@ func (f *FileLoader) Load(ctx context.Context, src document.Source, opts ...docu
 		}
 	}
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
+	if f.Tracker != nil {
+		for _, doc := range docs {
+			tracker.SetChangeType(doc, change)
+		}
+		if state != nil {
+			if err = f.Tracker.Commit(ctx, key, state); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// detectChange compares the mod time and size of the file with the recorded ones first,
+// and compares the content hash only if they differ.
+// The returned state is nil if nothing needs to be recorded.
+func (f *FileLoader) detectChange(ctx context.Context, path string) (key string, state *tracker.State, change tracker.ChangeType, err error) {
+	if err = validateSingleFilePath(path); err != nil {
+		return "", nil, "", err
+	}
+	if key, err = filepath.Abs(path); err != nil {
+		return "", nil, "", fmt.Errorf("get absolute path of [%s] fail: %w", path, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("read single file from path, error while checking file stat: %w, path= %s", err, path)
+	}
+	state = &tracker.State{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+	}
+	change, err = f.Tracker.Detect(ctx, key, state)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if change == tracker.ChangeUnchanged {
+		return key, nil, change, nil
+	}
+
+	file, err := openFile(path)
+	if err != nil {
+		return "", nil, "", err
+	}
+	defer file.Close()
 
+	if state.Hash, err = tracker.Hash(file); err != nil {
+		return "", nil, "", fmt.Errorf("hash file [%s] fail: %w", path, err)
+	}
+	change, err = f.Tracker.Detect(ctx, key, state)
+	if err != nil {
+		return "", nil, "", err
+	}
+	return key, state, change, nil
+}
+
+// loadDir walks the directory or glob pattern of uri and loads every matched file.
+// When ContinueOnError is set, the documents of loaded files are returned together with the joined errors of failed files.
+func (f *FileLoader) loadDir(ctx context.Context, uri string, o *document.LoaderOptions) ([]*schema.Document, error) {
+	if len(uri) == 0 {
+		return nil, errors.New("read dir from path, path is empty")
+	}
+
+	root, pattern := splitGlobURI(uri)
+	if pattern == "" {
+		if info, err := os.Stat(uri); err == nil && !info.IsDir() {
+			return f.loadFile(ctx, uri, filepath.Base(uri), o)
+		}
+	}
+
+	walker, err := newDirWalker(f.DirConfig, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("file loader dir config invalid: %w", err)
+	}
+
+	var (
+		docs []*schema.Document
+		errs []error
+		seen = make(map[string]bool)
+	)
+	walker.onError = func(path string, err error) error {
+		fileErr := &FileError{Path: path, Err: err}
+		if !f.DirConfig.ContinueOnError {
+			return fileErr
+		}
+		// the failed file or directory may still exist, so it must not be reported as deleted
+		if abs, err := filepath.Abs(path); err == nil {
+			seen[abs] = true
+		}
+		errs = append(errs, fileErr)
+		return nil
+	}
+	walker.onFile = func(path, relPath string, _ fs.FileInfo) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			seen[abs] = true
+		}
+		fileDocs, err := f.loadFile(ctx, path, relPath, o)
+		if err != nil {
+			return walker.onError(path, err)
+		}
+		docs = append(docs, fileDocs...)
+		return nil
+	}
+
+	if err = walker.walkRoot(root); err != nil {
+		return nil, err
+	}
+
+	if f.Tracker != nil && f.Tracker.ReportDeleted() {
+		deleted, err := f.detectDeleted(ctx, root, walker, seen)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, deleted...)
+	}
+
+	return docs, errors.Join(errs...)
+}
+
+// detectDeleted returns a document for each tracked file under root, which is matched by the walker but not seen in this walk.
+// Files under a directory that failed to be walked are considered seen.
+func (f *FileLoader) detectDeleted(ctx context.Context, root string, walker *dirWalker, seen map[string]bool) ([]*schema.Document, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("get absolute path of [%s] fail: %w", root, err)
+	}
+	prefix := absRoot
+	if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+		prefix += string(filepath.Separator)
+	}
+
+	keys, err := f.Tracker.Keys(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*schema.Document
+	for _, key := range keys {
+		if seenUnder(seen, absRoot, key) {
+			continue
+		}
+		rel, err := filepath.Rel(absRoot, key)
+		if err != nil {
+			continue
+		}
+		relPath := filepath.ToSlash(rel)
+		if !walker.matched(relPath) {
+			continue
+		}
+
+		path := filepath.Join(root, rel)
+		doc := &schema.Document{
+			MetaData: map[string]any{
+				MetaKeyExtension: filepath.Ext(path),
+				MetaKeyFileName:  filepath.Base(path),
+				MetaKeySource:    path,
+			},
+		}
+		if f.UseNameAsID {
+			doc.ID = relPath
+		}
+		tracker.SetChangeType(doc, tracker.ChangeDeleted)
+		docs = append(docs, doc)
+
+		if err = f.Tracker.Forget(ctx, key); err != nil {
+			return nil, err
+		}
+	}
 	return docs, nil
 }
 
+// seenUnder reports whether path or any of its parent directories below root is seen.
+func seenUnder(seen map[string]bool, root, path string) bool {
+	for ; len(path) > len(root); path = filepath.Dir(path) {
+		if seen[path] {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *FileLoader) GetType() string {
 	return "FileLoader"
 }
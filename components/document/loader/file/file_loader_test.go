@@ -18,11 +18,18 @@ package file
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
 )
 
 func TestFileLoader_Load(t *testing.T) {
@@ -49,3 +56,170 @@ func TestFileLoader_Load(t *testing.T) {
 		assert.Equal(t, "./testdata/test.md", docs[0].MetaData[MetaKeySource])
 	})
 }
+
+func TestFileLoader_LoadWithTracker(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	write := func(name, content string, modTime time.Time) {
+		path := filepath.Join(root, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	changes := func(docs []*schema.Document) map[string]tracker.ChangeType {
+		ret := make(map[string]tracker.ChangeType, len(docs))
+		for _, doc := range docs {
+			change, _ := tracker.GetChangeType(doc)
+			ret[doc.ID] = change
+		}
+		return ret
+	}
+
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	write("a.md", "a", t0)
+	write("b.md", "b", t0)
+	write("sub/c.md", "c", t0)
+
+	tr, err := tracker.NewTracker(ctx, &tracker.Config{
+		Store:         tracker.NewMemoryStore(),
+		SkipUnchanged: true,
+		ReportDeleted: true,
+	})
+	assert.NoError(t, err)
+
+	loader, err := NewFileLoader(ctx, &FileLoaderConfig{
+		UseNameAsID: true,
+		DirConfig:   &DirConfig{},
+		Tracker:     tr,
+	})
+	assert.NoError(t, err)
+
+	docs, err := loader.Load(ctx, document.Source{URI: root})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]tracker.ChangeType{
+		"a.md":     tracker.ChangeAdded,
+		"b.md":     tracker.ChangeAdded,
+		"sub/c.md": tracker.ChangeAdded,
+	}, changes(docs))
+
+	docs, err = loader.Load(ctx, document.Source{URI: root})
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+
+	// touch a.md, modify b.md, delete c.md and add d.md
+	t1 := t0.Add(time.Hour)
+	write("a.md", "a", t1)
+	write("b.md", "bb", t1)
+	write("d.md", "d", t1)
+	assert.NoError(t, os.Remove(filepath.Join(root, "sub", "c.md")))
+
+	docs, err = loader.Load(ctx, document.Source{URI: root})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]tracker.ChangeType{
+		"b.md":     tracker.ChangeModified,
+		"d.md":     tracker.ChangeAdded,
+		"sub/c.md": tracker.ChangeDeleted,
+	}, changes(docs))
+	for _, doc := range docs {
+		if doc.ID == "sub/c.md" {
+			assert.Equal(t, "", doc.Content)
+			assert.Equal(t, filepath.Join(root, "sub", "c.md"), doc.MetaData[MetaKeySource])
+		}
+	}
+
+	docs, err = loader.Load(ctx, document.Source{URI: root})
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+
+	// single file mode, without skipping unchanged files
+	tr, err = tracker.NewTracker(ctx, &tracker.Config{Store: tracker.NewMemoryStore()})
+	assert.NoError(t, err)
+	loader, err = NewFileLoader(ctx, &FileLoaderConfig{UseNameAsID: true, Tracker: tr})
+	assert.NoError(t, err)
+
+	docs, err = loader.Load(ctx, document.Source{URI: filepath.Join(root, "a.md")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]tracker.ChangeType{"a.md": tracker.ChangeAdded}, changes(docs))
+
+	docs, err = loader.Load(ctx, document.Source{URI: filepath.Join(root, "a.md")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]tracker.ChangeType{"a.md": tracker.ChangeUnchanged}, changes(docs))
+	assert.Equal(t, "a", docs[0].Content)
+
+	_, err = loader.Load(ctx, document.Source{URI: filepath.Join(root, "not-exist.md")})
+	assert.Error(t, err)
+}
+
+func TestFileLoader_LoadWithTrackerErrors(t *testing.T) {
+	ctx := context.Background()
+	write := func(path, content string) {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	newLoader := func(conf *DirConfig) *FileLoader {
+		tr, err := tracker.NewTracker(ctx, &tracker.Config{
+			Store:         tracker.NewMemoryStore(),
+			SkipUnchanged: true,
+			ReportDeleted: true,
+		})
+		assert.NoError(t, err)
+		loader, err := NewFileLoader(ctx, &FileLoaderConfig{UseNameAsID: true, DirConfig: conf, Tracker: tr})
+		assert.NoError(t, err)
+		return loader
+	}
+
+	t.Run("file exceeds max size", func(t *testing.T) {
+		root := t.TempDir()
+		write(filepath.Join(root, "a.md"), "a")
+		write(filepath.Join(root, "b.md"), "b")
+		loader := newLoader(&DirConfig{MaxFileSize: 4, ContinueOnError: true})
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+
+		write(filepath.Join(root, "a.md"), "too large")
+		docs, err = loader.Load(ctx, document.Source{URI: root})
+		assert.ErrorIs(t, err, ErrFileTooLarge)
+		assert.Empty(t, docs)
+
+		// a.md is still tracked, and reported as modified once it fits again
+		write(filepath.Join(root, "a.md"), "aa")
+		docs, err = loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md"}, docIDs(docs))
+		change, _ := tracker.GetChangeType(docs[0])
+		assert.Equal(t, tracker.ChangeModified, change)
+	})
+
+	t.Run("unreadable directory", func(t *testing.T) {
+		root := t.TempDir()
+		target := t.TempDir()
+		write(filepath.Join(root, "a.md"), "a")
+		write(filepath.Join(target, "c.md"), "c")
+		if err := os.Symlink(target, filepath.Join(root, "sub")); err != nil {
+			t.Skipf("symlink not supported: %v", err)
+		}
+		loader := newLoader(&DirConfig{SymlinkPolicy: SymlinkFollow, ContinueOnError: true})
+
+		docs, err := loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md", "sub/c.md"}, docIDs(docs))
+
+		// the link target is gone, so the walk of sub fails
+		assert.NoError(t, os.RemoveAll(target))
+		docs, err = loader.Load(ctx, document.Source{URI: root})
+		var fileErr *FileError
+		assert.True(t, errors.As(err, &fileErr))
+		assert.Equal(t, filepath.Join(root, "sub"), fileErr.Path)
+		assert.Empty(t, docs)
+
+		// once sub is removed, its files are reported as deleted
+		assert.NoError(t, os.Remove(filepath.Join(root, "sub")))
+		docs, err = loader.Load(ctx, document.Source{URI: root})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"sub/c.md"}, docIDs(docs))
+		change, _ := tracker.GetChangeType(docs[0])
+		assert.Equal(t, tracker.ChangeDeleted, change)
+	})
+}
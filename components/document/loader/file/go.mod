@@ -2,8 +2,11 @@ module github.com/cloudwego/eino-ext/components/document/loader/file
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/components/document/loader/tracker => ../tracker
+
 require (
 	github.com/cloudwego/eino v0.6.0
+	github.com/cloudwego/eino-ext/components/document/loader/tracker v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.10.0
 )
 
@@ -2,6 +2,8 @@ module github.com/cloudwego/eino-ext/components/document/loader/s3
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/components/document/loader/tracker => ../tracker
+
 require (
 	github.com/aws/aws-sdk-go-v2 v1.32.3
 	github.com/aws/aws-sdk-go-v2/config v1.28.1
@@ -9,6 +11,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.66.2
 	github.com/bytedance/mockey v1.2.13
 	github.com/cloudwego/eino v0.6.0
+	github.com/cloudwego/eino-ext/components/document/loader/tracker v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.10.0
 )
 
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
+)
+
+// ObjectError is the error of a single object in prefix mode.
+type ObjectError struct {
+	Key string
+	Err error
+}
+
+func (e *ObjectError) Error() string {
+	return fmt.Sprintf("load object [%s] fail: %v", e.Key, e.Err)
+}
+
+func (e *ObjectError) Unwrap() error {
+	return e.Err
+}
+
+type objectResult struct {
+	docs []*schema.Document
+	err  error
+}
+
+// loadPrefix lists the objects under prefix page by page, and loads them with at most l.concurrency objects at a time.
+// Documents are returned in the listing order of their objects.
+func (l *loader) loadPrefix(ctx context.Context, bucket, prefix string, o *document.LoaderOptions) ([]*schema.Document, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  []*objectResult
+		firstErr error
+		seen     = make(map[string]bool)
+		sem      = make(chan struct{}, l.concurrency)
+	)
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+	if l.maxKeys > 0 {
+		input.MaxKeys = aws.Int32(l.maxKeys)
+	}
+
+	var listErr error
+	paginator := s3.NewListObjectsV2Paginator(l.client, input)
+list:
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			listErr = fmt.Errorf("s3 loader list objects err: %w", err)
+			break
+		}
+
+		for i := range page.Contents {
+			obj := page.Contents[i]
+			key := aws.ToString(obj.Key)
+			if strings.HasSuffix(key, "/") {
+				// "folder" placeholder created by consoles
+				continue
+			}
+			seen[toURI(bucket, key)] = true
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				break list
+			}
+
+			result := &objectResult{}
+			results = append(results, result)
+
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				docs, err := l.loadObject(ctx, bucket, key, &obj, o)
+				if err != nil {
+					err = &ObjectError{Key: key, Err: err}
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				result.docs, result.err = docs, err
+				if err != nil && !l.continueOnError && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if listErr != nil {
+		return nil, listErr
+	}
+	// the listing stops early on cancellation, so the results and seen are partial here,
+	// and must not be used to detect deleted objects.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		docs []*schema.Document
+		errs []error
+	)
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, result.err)
+			continue
+		}
+		docs = append(docs, result.docs...)
+	}
+
+	if l.tracker != nil && l.tracker.ReportDeleted() {
+		deleted, err := l.detectDeleted(ctx, bucket, prefix, seen)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, deleted...)
+	}
+
+	return docs, errors.Join(errs...)
+}
+
+// detectDeleted reports the tracked objects under prefix which are not listed anymore.
+func (l *loader) detectDeleted(ctx context.Context, bucket, prefix string, seen map[string]bool) ([]*schema.Document, error) {
+	keys, err := l.tracker.Keys(ctx, toURI(bucket, prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*schema.Document
+	for _, uri := range keys {
+		if seen[uri] {
+			continue
+		}
+
+		key := strings.TrimPrefix(uri, toURI(bucket, ""))
+		doc := &schema.Document{
+			MetaData: map[string]any{
+				MetaKeyBucket:    bucket,
+				MetaKeyObjectKey: key,
+			},
+		}
+		if l.useObjectKeyAsID {
+			doc.ID = key
+		}
+		tracker.SetChangeType(doc, tracker.ChangeDeleted)
+		docs = append(docs, doc)
+
+		if err = l.tracker.Forget(ctx, uri); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
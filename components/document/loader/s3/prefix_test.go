@@ -0,0 +1,357 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
+)
+
+type fakeObject struct {
+	body        string
+	contentType string
+	etag        string
+	meta        map[string]string
+}
+
+// fakeS3 serves ListObjectsV2 and GetObject of a single bucket in path style, like MinIO does.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string]*fakeObject
+	gets    int
+}
+
+type listResult struct {
+	XMLName               xml.Name      `xml:"ListBucketResult"`
+	Name                  string        `xml:"Name"`
+	Prefix                string        `xml:"Prefix"`
+	KeyCount              int           `xml:"KeyCount"`
+	MaxKeys               int           `xml:"MaxKeys"`
+	IsTruncated           bool          `xml:"IsTruncated"`
+	NextContinuationToken string        `xml:"NextContinuationToken,omitempty"`
+	Contents              []listContent `xml:"Contents"`
+}
+
+type listContent struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+}
+
+var fakeModTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+f.bucket), "/")
+	if r.URL.Query().Get("list-type") == "2" {
+		f.list(w, r)
+		return
+	}
+
+	obj, ok := f.objects[key]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+		return
+	}
+	f.gets++
+	w.Header().Set("ETag", obj.etag)
+	w.Header().Set("Last-Modified", fakeModTime.Format(http.TimeFormat))
+	w.Header().Set("Content-Length", strconv.Itoa(len(obj.body)))
+	if obj.contentType != "" {
+		w.Header().Set("Content-Type", obj.contentType)
+	}
+	for k, v := range obj.meta {
+		w.Header().Set("x-amz-meta-"+k, v)
+	}
+	_, _ = io.WriteString(w, obj.body)
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	maxKeys := 1000
+	if v := r.URL.Query().Get("max-keys"); v != "" {
+		maxKeys, _ = strconv.Atoi(v)
+	}
+
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) && key > r.URL.Query().Get("continuation-token") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := listResult{Name: f.bucket, Prefix: prefix, MaxKeys: maxKeys}
+	if len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, key := range keys {
+		result.Contents = append(result.Contents, listContent{
+			Key:          key,
+			LastModified: fakeModTime.Format(time.RFC3339),
+			ETag:         f.objects[key].etag,
+			Size:         len(f.objects[key].body),
+		})
+	}
+	result.KeyCount = len(result.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeS3) getCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.gets
+}
+
+type upperParser struct{}
+
+func (upperParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	o := parser.GetCommonOptions(&parser.Options{}, opts...)
+	return []*schema.Document{{Content: strings.ToUpper(string(data)), MetaData: o.ExtraMeta}}, nil
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		bucket: "bucket",
+		objects: map[string]*fakeObject{
+			"docs/":          {etag: `"dir"`},
+			"docs/a.txt":     {body: "alpha", contentType: "text/plain", etag: `"a1"`, meta: map[string]string{"author": "tom"}},
+			"docs/b.html":    {body: "bravo", contentType: "text/html; charset=utf-8", etag: `"b1"`},
+			"docs/c.txt":     {body: "charlie", etag: `"c1"`},
+			"docs/sub/d.txt": {body: "delta", etag: `"d1"`},
+			"other/e.txt":    {body: "echo", etag: `"e1"`},
+		},
+	}
+}
+
+func newTestLoader(t *testing.T, endpoint string, conf *LoaderConfig) document.Loader {
+	conf.Region = aws.String("us-east-1")
+	conf.AWSAccessKey = aws.String("ak")
+	conf.AWSSecretKey = aws.String("sk")
+	conf.Endpoint = aws.String(endpoint)
+	conf.UsePathStyle = true
+	l, err := NewS3Loader(context.Background(), conf)
+	assert.NoError(t, err)
+	return l
+}
+
+func TestLoader_LoadPrefix(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	t.Run("paginate and dispatch by content type", func(t *testing.T) {
+		l := newTestLoader(t, server.URL, &LoaderConfig{
+			UseObjectKeyAsID:   true,
+			ContentTypeParsers: map[string]parser.Parser{"Text/HTML": upperParser{}},
+			Concurrency:        2,
+			MaxKeys:            2,
+		})
+
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"docs/a.txt", "docs/b.html", "docs/c.txt", "docs/sub/d.txt"}, docIDs(docs))
+		assert.Equal(t, []string{"alpha", "BRAVO", "charlie", "delta"}, contents(docs))
+
+		meta := docs[0].MetaData
+		assert.Equal(t, "bucket", meta[MetaKeyBucket])
+		assert.Equal(t, "docs/a.txt", meta[MetaKeyObjectKey])
+		assert.Equal(t, `"a1"`, meta[MetaKeyETag])
+		assert.Equal(t, int64(5), meta[MetaKeySize])
+		assert.Equal(t, "text/plain", meta[MetaKeyContentType])
+		assert.True(t, fakeModTime.Equal(meta[MetaKeyLastModified].(time.Time)))
+		assert.Equal(t, map[string]string{"author": "tom"}, meta[MetaKeyUserMetadata])
+		assert.Equal(t, "s3://bucket/docs/a.txt", meta["_source"])
+	})
+
+	t.Run("whole bucket", func(t *testing.T) {
+		l := newTestLoader(t, server.URL, &LoaderConfig{UseObjectKeyAsID: true})
+
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/"})
+		assert.NoError(t, err)
+		assert.Len(t, docs, 5)
+		assert.Equal(t, "other/e.txt", docs[4].ID)
+	})
+
+	t.Run("single object with custom endpoint", func(t *testing.T) {
+		l := newTestLoader(t, server.URL, &LoaderConfig{})
+
+		docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/c.txt"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"charlie"}, contents(docs))
+	})
+}
+
+func TestLoader_LoadPrefixError(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/b.html") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>denied</Message></Error>`)
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	l := newTestLoader(t, server.URL, &LoaderConfig{UseObjectKeyAsID: true})
+	_, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+	assert.ErrorContains(t, err, "AccessDenied")
+
+	l = newTestLoader(t, server.URL, &LoaderConfig{UseObjectKeyAsID: true, ContinueOnError: true})
+	docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"docs/a.txt", "docs/c.txt", "docs/sub/d.txt"}, docIDs(docs))
+
+	var objErr *ObjectError
+	assert.True(t, errors.As(err, &objErr))
+	assert.Equal(t, "docs/b.html", objErr.Key)
+}
+
+func TestLoader_LoadPrefixWithTracker(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	tr, err := tracker.NewTracker(ctx, &tracker.Config{
+		Store:         tracker.NewMemoryStore(),
+		SkipUnchanged: true,
+		ReportDeleted: true,
+	})
+	assert.NoError(t, err)
+	l := newTestLoader(t, server.URL, &LoaderConfig{UseObjectKeyAsID: true, Tracker: tr})
+
+	src := document.Source{URI: "s3://bucket/docs/"}
+	docs, err := l.Load(ctx, src)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 4)
+	assert.Equal(t, 4, fake.getCount())
+
+	fake.mu.Lock()
+	fake.objects["docs/a.txt"] = &fakeObject{body: "alpha2", etag: `"a2"`}
+	delete(fake.objects, "docs/c.txt")
+	fake.mu.Unlock()
+
+	docs, err = l.Load(ctx, src)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, fake.getCount())
+	assert.Equal(t, []string{"docs/a.txt", "docs/c.txt"}, docIDs(docs))
+
+	change, _ := tracker.GetChangeType(docs[0])
+	assert.Equal(t, tracker.ChangeModified, change)
+	assert.Equal(t, "alpha2", docs[0].Content)
+	change, _ = tracker.GetChangeType(docs[1])
+	assert.Equal(t, tracker.ChangeDeleted, change)
+	assert.Equal(t, "", docs[1].Content)
+
+	docs, err = l.Load(ctx, src)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 0)
+}
+
+func TestLoader_LoadPrefixCanceled(t *testing.T) {
+	fake := newFakeS3()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/a.txt") {
+			// cancel the load while the listing waits for a free slot
+			cancel()
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	tr, err := tracker.NewTracker(context.Background(), &tracker.Config{
+		Store:         tracker.NewMemoryStore(),
+		SkipUnchanged: true,
+		ReportDeleted: true,
+	})
+	assert.NoError(t, err)
+	plain := httptest.NewServer(fake)
+	defer plain.Close()
+	src := document.Source{URI: "s3://bucket/docs/"}
+	docs, err := newTestLoader(t, plain.URL, &LoaderConfig{Tracker: tr}).Load(context.Background(), src)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 4)
+
+	fake.mu.Lock()
+	fake.objects["docs/a.txt"] = &fakeObject{body: "alpha2", etag: `"a2"`}
+	fake.mu.Unlock()
+
+	l := newTestLoader(t, server.URL, &LoaderConfig{UseObjectKeyAsID: true, Tracker: tr, ContinueOnError: true, Concurrency: 1})
+	docs, err = l.Load(ctx, src)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, docs, 0)
+
+	// nothing is forgotten by the canceled load
+	docs, err = l.Load(context.Background(), src)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs/a.txt"}, docIDs(docs))
+	change, _ := tracker.GetChangeType(docs[0])
+	assert.Equal(t, tracker.ChangeModified, change)
+}
+
+func docIDs(docs []*schema.Document) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
+
+func contents(docs []*schema.Document) []string {
+	res := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		res = append(res, doc.Content)
+	}
+	return res
+}
@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mime"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -33,25 +35,68 @@ import (
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
+)
+
+const (
+	MetaKeyBucket       = "_bucket"
+	MetaKeyObjectKey    = "_object_key"
+	MetaKeyETag         = "_etag"
+	MetaKeyLastModified = "_last_modified"
+	MetaKeySize         = "_size"
+	MetaKeyContentType  = "_content_type"
+	MetaKeyUserMetadata = "_user_metadata"
 )
 
+const defaultConcurrency = 4
+
 // LoaderConfig is the configuration for s3 loader.
 type LoaderConfig struct {
 	Region       *string // the region of the AWS bucket
 	AWSAccessKey *string
 	AWSSecretKey *string
 
+	Endpoint     *string // custom endpoint of s3 compatible services, e.g. "http://localhost:9000" for MinIO
+	UsePathStyle bool    // whether to address the bucket in the path instead of the host name, usually required by MinIO
+
 	UseObjectKeyAsID bool // whether to use object key as document ID
 
 	Parser parser.Parser // the parser to parse the s3 object stream into documents, default to parser.TextParser, which directly converts []byte to string
+	// ContentTypeParsers specifies the parser by the media type of the object's Content-Type, e.g. "application/pdf".
+	// Objects whose content type is not matched are parsed by Parser, use parser.NewExtParser to choose the parser by extension.
+	ContentTypeParsers map[string]parser.Parser
+
+	// Concurrency is the max number of objects downloaded and parsed at the same time when loading a prefix, default to 4.
+	// A uri ending with "/" is loaded as a prefix, e.g. "s3://bucket/docs/", and "s3://bucket/" loads the whole bucket.
+	Concurrency int
+	// MaxKeys is the max number of keys listed in a page when loading a prefix, 0 means the s3 default (1000).
+	MaxKeys int32
+	// ContinueOnError specifies whether to keep loading the remaining objects when an object fails in prefix mode.
+	// If true, Load returns the documents of all successfully loaded objects, together with an error joining
+	// an *ObjectError for every failed object.
+	ContinueOnError bool
+
+	// Tracker enables incremental loading, objects are tracked by their s3 uri and compared by ETag.
+	// Unchanged objects are skipped or marked with tracker.MetaKeyChange, depending on the tracker config.
+	// In prefix mode, objects under the prefix which are loaded before but deleted now are reported with
+	// an empty document marked as tracker.ChangeDeleted, if ReportDeleted is set.
+	Tracker *tracker.Tracker
 }
 
 type loader struct {
 	client *s3.Client
 
-	parser parser.Parser
+	parser             parser.Parser
+	contentTypeParsers map[string]parser.Parser
 
 	useObjectKeyAsID bool
+
+	concurrency     int
+	maxKeys         int32
+	continueOnError bool
+
+	tracker *tracker.Tracker
 }
 
 // NewS3Loader creates a new s3 loader.
@@ -88,21 +133,41 @@ func NewS3Loader(ctx context.Context, conf *LoaderConfig) (document.Loader, erro
 		return nil, fmt.Errorf("new s3 loader, load config err: %w", err)
 	}
 
-	client := s3.NewFromConfig(sdkConfig)
+	client := s3.NewFromConfig(sdkConfig, func(o *s3.Options) {
+		if conf.Endpoint != nil {
+			o.BaseEndpoint = conf.Endpoint
+		}
+		o.UsePathStyle = conf.UsePathStyle
+	})
 
 	p := conf.Parser
 	if p == nil {
 		p = &parser.TextParser{}
 	}
 
+	contentTypeParsers := make(map[string]parser.Parser, len(conf.ContentTypeParsers))
+	for contentType, ctp := range conf.ContentTypeParsers {
+		contentTypeParsers[strings.ToLower(contentType)] = ctp
+	}
+
+	concurrency := conf.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
 	return &loader{
-		client:           client,
-		parser:           p,
-		useObjectKeyAsID: conf.UseObjectKeyAsID,
+		client:             client,
+		parser:             p,
+		contentTypeParsers: contentTypeParsers,
+		useObjectKeyAsID:   conf.UseObjectKeyAsID,
+		concurrency:        concurrency,
+		maxKeys:            conf.MaxKeys,
+		continueOnError:    conf.ContinueOnError,
+		tracker:            conf.Tracker,
 	}, nil
 }
 
-// Load loads the s3 object from the given URI.
+// Load loads the s3 object from the given URI, or all objects under the prefix if the URI ends with "/".
 func (l *loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
 	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
 	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
@@ -121,12 +186,59 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 		return nil, err
 	}
 
-	// prefix is not supported now
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
 	if isPrefix {
-		err = errors.New("s3 loader does not support batch load with prefix for now")
+		docs, err = l.loadPrefix(ctx, bucket, key, o)
+	} else {
+		docs, err = l.loadObject(ctx, bucket, key, nil, o)
+	}
+	if err != nil && len(docs) == 0 {
 		return nil, err
 	}
 
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, err
+}
+
+// loadObject downloads and parses a single object, listed is the object returned by ListObjectsV2 in prefix mode.
+func (l *loader) loadObject(ctx context.Context, bucket, key string, listed *types.Object, o *document.LoaderOptions) ([]*schema.Document, error) {
+	uri := toURI(bucket, key)
+
+	var change tracker.ChangeType
+	if l.tracker != nil {
+		var state *tracker.State
+		if listed != nil {
+			state = objectState(listed.ETag, listed.LastModified, listed.Size)
+		} else {
+			head, err := l.client.HeadObject(ctx, &s3.HeadObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				var notFound *types.NotFound
+				if errors.As(err, &notFound) {
+					return nil, fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
+				}
+				return nil, fmt.Errorf("s3 loader head object err: %w", err)
+			}
+			state = objectState(head.ETag, head.LastModified, head.ContentLength)
+		}
+
+		var err error
+		change, err = l.tracker.Detect(ctx, uri, state)
+		if err != nil {
+			return nil, fmt.Errorf("s3 loader detect change err: %w", err)
+		}
+		if change == tracker.ChangeUnchanged && l.tracker.SkipUnchanged() {
+			return nil, nil
+		}
+	}
+
 	// get object from s3
 	resp, err := l.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
@@ -135,21 +247,17 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 	if err != nil {
 		var noKey *types.NoSuchKey
 		if errors.As(err, &noKey) {
-			err = fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
-			return nil, err
+			return nil, fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
 		}
 
-		err = fmt.Errorf("s3 loader get object err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader get object err: %w", err)
 	}
 	defer resp.Body.Close()
 
-	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
-
-	docs, err = l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(src.URI)}, o.ParserOptions...)...)
+	docs, err := l.parserOf(resp.ContentType).Parse(ctx, resp.Body,
+		append([]parser.Option{parser.WithURI(uri), parser.WithExtraMeta(objectMeta(bucket, key, resp))}, o.ParserOptions...)...)
 	if err != nil {
-		err = fmt.Errorf("s3 loader parse err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader parse err: %w", err)
 	}
 
 	if l.useObjectKeyAsID {
@@ -158,14 +266,68 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 		}
 	}
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
+	if l.tracker != nil {
+		for _, doc := range docs {
+			tracker.SetChangeType(doc, change)
+		}
+		// record the state of the downloaded object, in case it is modified after being listed
+		if err = l.tracker.Commit(ctx, uri, objectState(resp.ETag, resp.LastModified, resp.ContentLength)); err != nil {
+			return nil, fmt.Errorf("s3 loader commit state err: %w", err)
+		}
+	}
 
 	return docs, nil
 }
 
+func (l *loader) parserOf(contentType *string) parser.Parser {
+	if len(l.contentTypeParsers) == 0 || contentType == nil {
+		return l.parser
+	}
+	mediaType, _, err := mime.ParseMediaType(*contentType)
+	if err != nil {
+		return l.parser
+	}
+	if p, ok := l.contentTypeParsers[mediaType]; ok {
+		return p
+	}
+	return l.parser
+}
+
+func objectMeta(bucket, key string, resp *s3.GetObjectOutput) map[string]any {
+	meta := map[string]any{
+		MetaKeyBucket:    bucket,
+		MetaKeyObjectKey: key,
+	}
+	if resp.ETag != nil {
+		meta[MetaKeyETag] = *resp.ETag
+	}
+	if resp.LastModified != nil {
+		meta[MetaKeyLastModified] = *resp.LastModified
+	}
+	if resp.ContentLength != nil {
+		meta[MetaKeySize] = *resp.ContentLength
+	}
+	if resp.ContentType != nil {
+		meta[MetaKeyContentType] = *resp.ContentType
+	}
+	if len(resp.Metadata) > 0 {
+		meta[MetaKeyUserMetadata] = resp.Metadata
+	}
+	return meta
+}
+
+func objectState(etag *string, lastModified *time.Time, size *int64) *tracker.State {
+	return &tracker.State{
+		ETag:    aws.ToString(etag),
+		ModTime: aws.ToTime(lastModified),
+		Size:    aws.ToInt64(size),
+	}
+}
+
+func toURI(bucket, key string) string {
+	return "s3://" + bucket + "/" + key
+}
+
 func uriToBucketAndKey(uri string) (bucket string, key string, isPrefix bool, err error) {
 	const (
 		uriPrefix = `s3://`
@@ -189,7 +351,7 @@ func uriToBucketAndKey(uri string) (bucket string, key string, isPrefix bool, er
 	bucket = bucketAndKey[:bucketEnd]
 	key = bucketAndKey[bucketEnd+1:]
 
-	if strings.HasSuffix(key, separator) {
+	if key == "" || strings.HasSuffix(key, separator) {
 		return bucket, key, true, nil
 	}
 
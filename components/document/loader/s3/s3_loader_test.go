@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -29,6 +30,8 @@ import (
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
 )
 
 func TestNewS3Loader(t *testing.T) {
@@ -95,11 +98,15 @@ func TestLoader_Load(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "incomplete")
 
-		_, err = s3Loader.Load(ctx, document.Source{
-			URI: "s3://bucket/prefix/",
+		mockey.PatchConvey("list objects returns error", func() {
+			mockey.Mock((*s3.Client).ListObjectsV2).Return(nil, errors.New("list fail")).Build()
+
+			_, err = s3Loader.Load(ctx, document.Source{
+				URI: "s3://bucket/prefix/",
+			})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "list objects")
 		})
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "does not support batch load with prefix")
 
 		mockey.PatchConvey("get object returns no such key", func() {
 			mockey.Mock((*s3.Client).GetObject).Return(nil, &types.NoSuchKey{}).Build()
@@ -140,3 +147,58 @@ func TestLoader_Load(t *testing.T) {
 		assert.Equal(t, "key.txt", result[0].ID)
 	})
 }
+
+func TestLoader_LoadWithTracker(t *testing.T) {
+	mockey.PatchConvey("TestLoader_LoadWithTracker", t, func() {
+		ctx := context.Background()
+
+		tr, err := tracker.NewTracker(ctx, &tracker.Config{
+			Store:         tracker.NewMemoryStore(),
+			SkipUnchanged: true,
+		})
+		assert.NoError(t, err)
+
+		s3Loader, err := NewS3Loader(ctx, &LoaderConfig{
+			Region:       aws.String("region"),
+			AWSAccessKey: aws.String("ak"),
+			AWSSecretKey: aws.String("sk"),
+			Tracker:      tr,
+		})
+		assert.NoError(t, err)
+
+		etag := aws.String(`"v1"`)
+		mockey.Mock((*s3.Client).HeadObject).To(func(_ *s3.Client, _ context.Context, _ *s3.HeadObjectInput, _ ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+			return &s3.HeadObjectOutput{ETag: etag}, nil
+		}).Build()
+		getter := mockey.Mock((*s3.Client).GetObject).To(func(_ *s3.Client, _ context.Context, _ *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			return &s3.GetObjectOutput{ETag: etag, Body: io.NopCloser(strings.NewReader("hello"))}, nil
+		}).Build()
+
+		src := document.Source{URI: "s3://bucket/key.txt"}
+		docs, err := s3Loader.Load(ctx, src)
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		change, _ := tracker.GetChangeType(docs[0])
+		assert.Equal(t, tracker.ChangeAdded, change)
+
+		docs, err = s3Loader.Load(ctx, src)
+		assert.NoError(t, err)
+		assert.Len(t, docs, 0)
+		assert.Equal(t, 1, getter.Times())
+
+		etag = aws.String(`"v2"`)
+		docs, err = s3Loader.Load(ctx, src)
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		change, _ = tracker.GetChangeType(docs[0])
+		assert.Equal(t, tracker.ChangeModified, change)
+
+		mockey.PatchConvey("head object returns not found", func() {
+			mockey.Mock((*s3.Client).HeadObject).Return(nil, &types.NotFound{}).Build()
+
+			_, err = s3Loader.Load(ctx, src)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found")
+		})
+	})
+}
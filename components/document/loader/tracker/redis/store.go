@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
+)
+
+// Store is a tracker.Store keeping all states in a single redis hash, the field is the source key.
+type Store struct {
+	rdb       redis.UniversalClient
+	key       string
+	scanCount int64
+}
+
+type Option interface {
+	apply(*Store)
+}
+
+type optionFunc func(*Store)
+
+func (f optionFunc) apply(s *Store) {
+	f(s)
+}
+
+// WithKey sets the key of the redis hash, "eino:loader:tracker" by default.
+// Use different keys to track sources of different pipelines separately.
+func WithKey(key string) Option {
+	return optionFunc(func(s *Store) {
+		s.key = key
+	})
+}
+
+// WithScanCount sets the COUNT hint of HSCAN used by Keys, 1000 by default.
+func WithScanCount(count int64) Option {
+	return optionFunc(func(s *Store) {
+		s.scanCount = count
+	})
+}
+
+var _ tracker.Store = (*Store)(nil)
+
+// NewStore creates a new redis Store.
+func NewStore(rdb redis.UniversalClient, opts ...Option) *Store {
+	s := &Store{
+		rdb:       rdb,
+		key:       "eino:loader:tracker",
+		scanCount: 1000,
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
+}
+
+func (s *Store) Get(ctx context.Context, key string) (*tracker.State, bool, error) {
+	data, err := s.rdb.HGet(ctx, s.key, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	state := &tracker.State{}
+	if err = sonic.Unmarshal(data, state); err != nil {
+		return nil, false, err
+	}
+	return state, true, nil
+}
+
+func (s *Store) Set(ctx context.Context, key string, state *tracker.State) error {
+	if state == nil {
+		return errors.New("state is nil")
+	}
+	data, err := sonic.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.rdb.HSet(ctx, s.key, key, data).Err()
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	return s.rdb.HDel(ctx, s.key, key).Err()
+}
+
+func (s *Store) Keys(ctx context.Context, prefix string) ([]string, error) {
+	var (
+		cursor uint64
+		seen   = make(map[string]bool)
+		match  = escapeGlob(prefix) + "*"
+	)
+	for {
+		// HSCAN returns field and value alternately
+		page, next, err := s.rdb.HScan(ctx, s.key, cursor, match, s.scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(page); i += 2 {
+			seen[page[i]] = true
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+var globReplacer = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+func escapeGlob(s string) string {
+	return globReplacer.Replace(s)
+}
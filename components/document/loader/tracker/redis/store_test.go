@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
+)
+
+type mockRedisClient struct {
+	redis.UniversalClient
+	mock.Mock
+}
+
+var _ redis.UniversalClient = (*mockRedisClient)(nil)
+
+func (m *mockRedisClient) HGet(ctx context.Context, key, field string) *redis.StringCmd {
+	args := m.Called(ctx, key, field)
+	cmd := redis.NewStringCmd(ctx)
+	cmd.SetVal(args.String(0))
+	cmd.SetErr(args.Error(1))
+	return cmd
+}
+
+func (m *mockRedisClient) HSet(ctx context.Context, key string, values ...any) *redis.IntCmd {
+	args := m.Called(ctx, key, values)
+	cmd := redis.NewIntCmd(ctx)
+	cmd.SetErr(args.Error(0))
+	return cmd
+}
+
+func (m *mockRedisClient) HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd {
+	args := m.Called(ctx, key, fields)
+	cmd := redis.NewIntCmd(ctx)
+	cmd.SetErr(args.Error(0))
+	return cmd
+}
+
+func (m *mockRedisClient) HScan(ctx context.Context, key string, cursor uint64, match string, count int64) *redis.ScanCmd {
+	args := m.Called(ctx, key, cursor, match, count)
+	cmd := redis.NewScanCmd(ctx, nil)
+	cmd.SetVal(args.Get(0).([]string), uint64(args.Int(1)))
+	cmd.SetErr(args.Error(2))
+	return cmd
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	state := &tracker.State{Hash: "abc", Size: 3}
+	data, err := sonic.Marshal(state)
+	require.NoError(t, err)
+
+	t.Run("get and set", func(t *testing.T) {
+		rdb := new(mockRedisClient)
+		s := NewStore(rdb, WithKey("states"))
+
+		rdb.On("HSet", mock.Anything, "states", []any{"a", data}).Return(nil)
+		rdb.On("HGet", mock.Anything, "states", "a").Return(string(data), nil)
+		rdb.On("HGet", mock.Anything, "states", "b").Return("", redis.Nil)
+		rdb.On("HDel", mock.Anything, "states", []string{"a"}).Return(nil)
+
+		assert.Error(t, s.Set(ctx, "a", nil))
+		assert.NoError(t, s.Set(ctx, "a", state))
+
+		got, ok, err := s.Get(ctx, "a")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, state, got)
+
+		got, ok, err = s.Get(ctx, "b")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, got)
+
+		assert.NoError(t, s.Delete(ctx, "a"))
+		rdb.AssertExpectations(t)
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		rdb := new(mockRedisClient)
+		s := NewStore(rdb)
+		rdb.On("HGet", mock.Anything, "eino:loader:tracker", "a").Return("", errors.New("get error"))
+
+		_, ok, err := s.Get(ctx, "a")
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		rdb := new(mockRedisClient)
+		s := NewStore(rdb, WithScanCount(2))
+
+		rdb.On("HScan", mock.Anything, "eino:loader:tracker", uint64(0), `s3://b/\[x\]*`, int64(2)).
+			Return([]string{"s3://b/[x]/2", "v", "s3://b/[x]/1", "v"}, 7, nil)
+		rdb.On("HScan", mock.Anything, "eino:loader:tracker", uint64(7), `s3://b/\[x\]*`, int64(2)).
+			Return([]string{"s3://b/[x]/1", "v"}, 0, nil)
+
+		keys, err := s.Keys(ctx, "s3://b/[x]")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"s3://b/[x]/1", "s3://b/[x]/2"}, keys)
+		rdb.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is a Store keeping states in memory, it is lost when the process exits.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	states map[string]State
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates a new MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		states: make(map[string]State),
+	}
+}
+
+func (m *MemoryStore) Get(_ context.Context, key string) (*State, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	state, ok := m.states[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return &state, true, nil
+}
+
+func (m *MemoryStore) Set(_ context.Context, key string, state *State) error {
+	if state == nil {
+		return errors.New("state is nil")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[key] = *state
+	return nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, key)
+	return nil
+}
+
+func (m *MemoryStore) Keys(_ context.Context, prefix string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return keysWithPrefix(m.states, prefix), nil
+}
+
+// FileStore is a Store persisting states to a local file.
+// Changes are appended to the file as JSON lines, and the file is compacted when it is opened,
+// so that recording thousands of sources in one load does not rewrite the whole file each time.
+// A FileStore must not be shared by multiple processes.
+type FileStore struct {
+	mu     sync.RWMutex
+	states map[string]State
+	file   *os.File
+}
+
+var _ Store = (*FileStore)(nil)
+
+type fileRecord struct {
+	Key     string `json:"key"`
+	State   *State `json:"state,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// NewFileStore opens the FileStore at path, the file is created if it does not exist.
+func NewFileStore(path string) (*FileStore, error) {
+	states, err := readFileRecords(path)
+	if err != nil {
+		return nil, fmt.Errorf("read tracker file [%s] fail: %w", path, err)
+	}
+
+	// compact the records into a temporary file, then replace the original one
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("create tracker file fail: %w", err)
+	}
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, key := range keysWithPrefix(states, "") {
+		state := states[key]
+		if err = enc.Encode(&fileRecord{Key: key, State: &state}); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, fmt.Errorf("compact tracker file [%s] fail: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open tracker file [%s] fail: %w", path, err)
+	}
+
+	return &FileStore{
+		states: states,
+		file:   f,
+	}, nil
+}
+
+func readFileRecords(path string) (map[string]State, error) {
+	states := make(map[string]State)
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return states, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record fileRecord
+		if err = json.Unmarshal(line, &record); err != nil {
+			// the last line may be truncated if the process crashed while writing it
+			continue
+		}
+		if record.Deleted || record.State == nil {
+			delete(states, record.Key)
+		} else {
+			states[record.Key] = *record.State
+		}
+	}
+	return states, scanner.Err()
+}
+
+func (s *FileStore) Get(_ context.Context, key string) (*State, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok := s.states[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return &state, true, nil
+}
+
+func (s *FileStore) Set(_ context.Context, key string, state *State) error {
+	if state == nil {
+		return errors.New("state is nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.append(&fileRecord{Key: key, State: state}); err != nil {
+		return err
+	}
+	s.states[key] = *state
+	return nil
+}
+
+func (s *FileStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.states[key]; !ok {
+		return nil
+	}
+	if err := s.append(&fileRecord{Key: key, Deleted: true}); err != nil {
+		return err
+	}
+	delete(s.states, key)
+	return nil
+}
+
+func (s *FileStore) Keys(_ context.Context, prefix string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return keysWithPrefix(s.states, prefix), nil
+}
+
+// Close closes the underlying file.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+func (s *FileStore) append(record *fileRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+func keysWithPrefix(states map[string]State, prefix string) []string {
+	keys := make([]string, 0, len(states))
+	for key := range states {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "states.jsonl")
+
+	s, err := NewFileStore(path)
+	require.NoError(t, err)
+	testStore(t, s)
+
+	modTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, s.Set(ctx, "dir/x", &State{Hash: "h1", Size: 1}))
+	assert.NoError(t, s.Set(ctx, "dir/x", &State{Hash: "h2", ModTime: modTime, Size: 2}))
+	assert.NoError(t, s.Set(ctx, "dir/y", &State{Hash: "h3"}))
+	assert.NoError(t, s.Delete(ctx, "dir/y"))
+	assert.NoError(t, s.Close())
+
+	// simulate a line truncated by a crash
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"key":"dir/z","sta`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s, err = NewFileStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	keys, err := s.Keys(ctx, "dir/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dir/x"}, keys)
+	state, ok, err := s.Get(ctx, "dir/x")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "h2", state.Hash)
+	assert.Equal(t, int64(2), state.Size)
+	assert.True(t, modTime.Equal(state.ModTime))
+
+	// compacted on open
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+}
+
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+
+	_, ok, err := s.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Error(t, s.Set(ctx, "a", nil))
+	assert.NoError(t, s.Set(ctx, "a/1", &State{Hash: "1"}))
+	assert.NoError(t, s.Set(ctx, "a/2", &State{Hash: "2"}))
+	assert.NoError(t, s.Set(ctx, "b/1", &State{Hash: "3"}))
+
+	state, ok, err := s.Get(ctx, "a/2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "2", state.Hash)
+
+	keys, err := s.Keys(ctx, "a/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/1", "a/2"}, keys)
+
+	assert.NoError(t, s.Delete(ctx, "a/1"))
+	assert.NoError(t, s.Delete(ctx, "not-exist"))
+	keys, err = s.Keys(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/2", "b/1"}, keys)
+
+	for _, key := range keys {
+		assert.NoError(t, s.Delete(ctx, key))
+	}
+}
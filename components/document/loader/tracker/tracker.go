@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracker records content fingerprints of loaded sources, so that loaders can skip
+// unchanged sources and report added, modified and deleted ones.
+package tracker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// MetaKeyChange is the metadata key of the ChangeType of a loaded document.
+const MetaKeyChange = "_change"
+
+// ChangeType is the change of a source since it was last loaded.
+type ChangeType string
+
+const (
+	ChangeAdded     ChangeType = "added"
+	ChangeModified  ChangeType = "modified"
+	ChangeUnchanged ChangeType = "unchanged"
+	ChangeDeleted   ChangeType = "deleted"
+)
+
+var ErrStoreRequired = errors.New("document/loader/tracker: store is required")
+
+// State is the fingerprint of a source recorded by the Store.
+// Loaders fill the fields they can get cheaply, e.g. ModTime and Size of a local file, or ETag of an s3 object.
+type State struct {
+	Hash    string    `json:"hash,omitempty"`
+	ETag    string    `json:"etag,omitempty"`
+	ModTime time.Time `json:"mod_time,omitempty"`
+	Size    int64     `json:"size"`
+	// Links are the outgoing links of a crawled page, recorded so that a crawl can go on
+	// through a page which is not modified and therefore not fetched again.
+	Links []string `json:"links,omitempty"`
+}
+
+// Store persists the State of every tracked source.
+type Store interface {
+	// Get returns the recorded state of key, the bool return value is false if key is not recorded.
+	Get(ctx context.Context, key string) (*State, bool, error)
+	// Set records the state of key, the previous state is overwritten.
+	Set(ctx context.Context, key string, state *State) error
+	// Delete removes the state of key, it is not an error if key is not recorded.
+	Delete(ctx context.Context, key string) error
+	// Keys returns all recorded keys starting with prefix.
+	Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Config is the config of Tracker.
+type Config struct {
+	// Store persists the recorded states, required.
+	// e.g. NewMemoryStore(), NewFileStore(path), or the redis store in tracker/redis.
+	Store Store
+	// SkipUnchanged specifies whether loaders skip the sources that have not changed since last load.
+	// If false, unchanged sources are loaded and their documents are marked as ChangeUnchanged.
+	SkipUnchanged bool
+	// ReportDeleted specifies whether loaders report the sources that have been removed since last load,
+	// with a document marked as ChangeDeleted and an empty content for each of them.
+	// Only loaders which load a set of sources (e.g. a directory or an s3 prefix) can detect deletions.
+	ReportDeleted bool
+}
+
+// Tracker detects the changes of sources by comparing their current state with the recorded one.
+// Loaders call Detect before loading a source, and Commit after it is loaded successfully.
+type Tracker struct {
+	store         Store
+	skipUnchanged bool
+	reportDeleted bool
+}
+
+// NewTracker creates a new Tracker.
+func NewTracker(_ context.Context, conf *Config) (*Tracker, error) {
+	if conf == nil || conf.Store == nil {
+		return nil, ErrStoreRequired
+	}
+	return &Tracker{
+		store:         conf.Store,
+		skipUnchanged: conf.SkipUnchanged,
+		reportDeleted: conf.ReportDeleted,
+	}, nil
+}
+
+// SkipUnchanged reports whether unchanged sources should be skipped.
+func (t *Tracker) SkipUnchanged() bool {
+	return t.skipUnchanged
+}
+
+// ReportDeleted reports whether deleted sources should be reported.
+func (t *Tracker) ReportDeleted() bool {
+	return t.reportDeleted
+}
+
+// Detect returns the change of key by comparing current with the recorded state.
+// ETag has the highest priority, then Hash, and at last ModTime with Size. A fingerprint is only compared
+// when it is set in both states, so a loader may call Detect with ModTime and Size first, and compute Hash
+// only if the source seems modified.
+func (t *Tracker) Detect(ctx context.Context, key string, current *State) (ChangeType, error) {
+	prev, ok, err := t.store.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("get state of [%s] fail: %w", key, err)
+	}
+	if !ok || prev == nil {
+		return ChangeAdded, nil
+	}
+	if compare(prev, current) {
+		return ChangeUnchanged, nil
+	}
+	return ChangeModified, nil
+}
+
+// Get returns the recorded state of key, e.g. to send the ETag of a web page in a conditional request.
+// The bool return value is false if key is not recorded.
+func (t *Tracker) Get(ctx context.Context, key string) (*State, bool, error) {
+	state, ok, err := t.store.Get(ctx, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("get state of [%s] fail: %w", key, err)
+	}
+	return state, ok && state != nil, nil
+}
+
+// Commit records the state of key after it is loaded.
+func (t *Tracker) Commit(ctx context.Context, key string, state *State) error {
+	if err := t.store.Set(ctx, key, state); err != nil {
+		return fmt.Errorf("set state of [%s] fail: %w", key, err)
+	}
+	return nil
+}
+
+// Keys returns all tracked keys starting with prefix.
+func (t *Tracker) Keys(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := t.store.Keys(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list states with prefix [%s] fail: %w", prefix, err)
+	}
+	return keys, nil
+}
+
+// Forget removes the recorded state of key, e.g. after the source is deleted,
+// or when the documents of key fail to be indexed and should be loaded again next time.
+func (t *Tracker) Forget(ctx context.Context, key string) error {
+	if err := t.store.Delete(ctx, key); err != nil {
+		return fmt.Errorf("delete state of [%s] fail: %w", key, err)
+	}
+	return nil
+}
+
+func compare(prev, current *State) bool {
+	if prev.ETag != "" && current.ETag != "" {
+		return prev.ETag == current.ETag
+	}
+	if prev.Hash != "" && current.Hash != "" {
+		return prev.Hash == current.Hash
+	}
+	return !prev.ModTime.IsZero() && prev.ModTime.Equal(current.ModTime) && prev.Size == current.Size
+}
+
+// Hash returns the hex encoded sha256 of the content read from reader.
+func Hash(reader io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SetChangeType marks doc with the change of its source.
+func SetChangeType(doc *schema.Document, change ChangeType) {
+	if doc == nil {
+		return
+	}
+	if doc.MetaData == nil {
+		doc.MetaData = make(map[string]any)
+	}
+	doc.MetaData[MetaKeyChange] = change
+}
+
+// GetChangeType returns the change of the source of doc, set by loaders with a Tracker.
+func GetChangeType(doc *schema.Document) (ChangeType, bool) {
+	if doc == nil {
+		return "", false
+	}
+	change, ok := doc.MetaData[MetaKeyChange].(ChangeType)
+	return change, ok
+}
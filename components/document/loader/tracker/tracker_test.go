@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewTracker(ctx, nil)
+	assert.Equal(t, ErrStoreRequired, err)
+
+	tr, err := NewTracker(ctx, &Config{Store: NewMemoryStore(), SkipUnchanged: true})
+	assert.NoError(t, err)
+	assert.True(t, tr.SkipUnchanged())
+	assert.False(t, tr.ReportDeleted())
+
+	now := time.Now()
+	hash, err := Hash(strings.NewReader("hello"))
+	assert.NoError(t, err)
+
+	change, err := tr.Detect(ctx, "a", &State{ModTime: now, Size: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeAdded, change)
+	assert.NoError(t, tr.Commit(ctx, "a", &State{ModTime: now, Size: 5, Hash: hash}))
+
+	change, err = tr.Detect(ctx, "a", &State{ModTime: now, Size: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeUnchanged, change)
+
+	// touched but same content
+	change, err = tr.Detect(ctx, "a", &State{ModTime: now.Add(time.Second), Size: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeModified, change)
+	change, err = tr.Detect(ctx, "a", &State{ModTime: now.Add(time.Second), Size: 5, Hash: hash})
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeUnchanged, change)
+
+	// etag takes precedence
+	assert.NoError(t, tr.Commit(ctx, "b", &State{ETag: "v1", Size: 1}))
+	change, err = tr.Detect(ctx, "b", &State{ETag: "v1", Size: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeUnchanged, change)
+	change, err = tr.Detect(ctx, "b", &State{ETag: "v2", Size: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeModified, change)
+
+	state, ok, err := tr.Get(ctx, "b")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v1", state.ETag)
+	_, ok, err = tr.Get(ctx, "c")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	keys, err := tr.Keys(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	assert.NoError(t, tr.Forget(ctx, "a"))
+	keys, err = tr.Keys(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, keys)
+}
+
+func TestChangeType(t *testing.T) {
+	doc := &schema.Document{}
+	_, ok := GetChangeType(doc)
+	assert.False(t, ok)
+
+	SetChangeType(doc, ChangeModified)
+	change, ok := GetChangeType(doc)
+	assert.True(t, ok)
+	assert.Equal(t, ChangeModified, change)
+
+	_, ok = GetChangeType(nil)
+	assert.False(t, ok)
+}
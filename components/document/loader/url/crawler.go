@@ -0,0 +1,682 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+)
+
+const (
+	// MetaKeyDepth is the number of links followed from a seed url to the crawled page, 0 for seed pages.
+	MetaKeyDepth = "_depth"
+	// MetaKeyReferrer is the url of the page linking to the crawled page, or the url of the sitemap listing it.
+	// It is empty for seed pages.
+	MetaKeyReferrer = "_referrer"
+)
+
+// DefaultUserAgent is the User-Agent of crawl requests, if CrawlConfig.UserAgent is empty and the request has none.
+const DefaultUserAgent = "EinoCrawler/1.0"
+
+var (
+	// ErrUnexpectedStatus is reported for pages responding with a status code other than 2xx.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+	// ErrBodyTooLarge is reported for pages larger than CrawlConfig.MaxBodySize.
+	ErrBodyTooLarge = errors.New("response body exceeds the limit")
+)
+
+// Scope limits which links are followed, relative to the seed urls.
+type Scope uint8
+
+const (
+	// ScopeSameHost follows links to the host (including the port) of any seed url.
+	ScopeSameHost Scope = iota
+	// ScopeSameDomain follows links to the host of any seed url and its subdomains,
+	// a leading "www." of the seed host is ignored, e.g. a seed of www.example.com matches docs.example.com.
+	ScopeSameDomain
+	// ScopePathPrefix follows links to the host of any seed url, under the directory of the seed path,
+	// e.g. a seed of https://example.com/docs/intro matches https://example.com/docs/api but not https://example.com/blog.
+	ScopePathPrefix
+	// ScopeAny follows all http and https links.
+	ScopeAny
+)
+
+// CrawlConfig enables the crawling mode of Loader.
+// In crawling mode, pages are crawled breadth first from the source uri and Seeds, every fetched page is parsed by
+// LoaderConfig.Parser, with MetaKeyDepth and MetaKeyReferrer in the metadata of its documents.
+// Requests are built by LoaderConfig.RequestBuilder with the page url as the source uri.
+type CrawlConfig struct {
+	// Seeds are additional urls to start from besides the source uri.
+	Seeds []string
+	// MaxDepth limits how many links are followed from the seed urls, 0 means no limit.
+	MaxDepth int
+	// MaxPages limits the number of pages fetched in one load, 100 by default.
+	MaxPages int
+	// Scope limits which links are followed, ScopeSameHost by default.
+	Scope Scope
+	// Filter is called for every in-scope url before it is queued, return false to skip it.
+	Filter func(u *url.URL) bool
+	// IgnoreRobots disables robots.txt and robots meta tags. By default, disallowed urls are skipped,
+	// the crawl delay of robots.txt applies when it is longer than Interval, and pages with a "noindex" or
+	// "nofollow" robots meta tag are not parsed or not followed respectively.
+	IgnoreRobots bool
+	// UseSitemap queues the pages listed in sitemaps as seeds, the sitemaps are those declared in robots.txt,
+	// or /sitemap.xml of the seed hosts if there are none. Sitemaps which fail to be fetched are ignored.
+	UseSitemap bool
+	// UserAgent is the User-Agent header of requests which have none, and the name matched against robots.txt.
+	// DefaultUserAgent by default.
+	UserAgent string
+	// Interval is the minimum interval between two requests to the same host.
+	Interval time.Duration
+	// Concurrency is the max number of pages fetched at the same time, 4 by default.
+	Concurrency int
+	// MaxBodySize is the maximum size in bytes of a page, larger pages are reported with ErrBodyTooLarge.
+	// 10MB by default.
+	MaxBodySize int64
+	// ContinueOnError specifies whether to keep crawling when a page fails.
+	// If true, Load returns the documents of all successfully loaded pages, together with an error joining
+	// a *PageError for every failed page. Otherwise, the first failed page stops the crawl.
+	ContinueOnError bool
+	// Tracker enables incremental crawling, pages are tracked by their urls.
+	// If the tracker skips unchanged pages, pages are fetched with If-None-Match and If-Modified-Since
+	// headers, and links of pages which are not modified are followed from the recorded state.
+	// Tracked pages responding with 404 or 410 are reported with an empty document marked as
+	// tracker.ChangeDeleted, if ReportDeleted is set.
+	Tracker *tracker.Tracker
+}
+
+// PageError is the error of a single page in crawling mode.
+type PageError struct {
+	URL string
+	Err error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("crawl page [%s] fail: %v", e.URL, e.Err)
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+const (
+	defaultMaxPages      = 100
+	defaultCrawlWorkers  = 4
+	defaultMaxBodySize   = 10 << 20
+	maxSitemapsPerCrawl  = 50
+	robotsTxtMaxBodySize = 512 << 10
+)
+
+type crawlItem struct {
+	url      *url.URL
+	depth    int
+	referrer string
+}
+
+type pageResult struct {
+	docs  []*schema.Document
+	links []string
+	// final is the url after redirects
+	final *url.URL
+	err   error
+}
+
+type crawler struct {
+	loader *Loader
+	conf   *CrawlConfig
+	opts   []document.LoaderOption
+	o      *document.LoaderOptions
+
+	seeds   []*url.URL
+	robots  map[string]*robotsRules
+	limiter *hostLimiter
+}
+
+func (l *Loader) crawl(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	c := &crawler{
+		loader:  l,
+		conf:    l.conf.Crawl,
+		opts:    opts,
+		o:       document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...),
+		robots:  make(map[string]*robotsRules),
+		limiter: &hostLimiter{next: make(map[string]time.Time)},
+	}
+	return c.run(ctx, src.URI)
+}
+
+func (c *crawler) run(ctx context.Context, uri string) ([]*schema.Document, error) {
+	visited := make(map[string]bool)
+	var frontier []crawlItem
+	for _, seed := range append([]string{uri}, c.conf.Seeds...) {
+		u, ok := normalizeURL(seed, nil)
+		if !ok {
+			return nil, fmt.Errorf("invalid seed url [%s], only absolute http and https urls are supported", seed)
+		}
+		c.seeds = append(c.seeds, u)
+		if key := u.String(); !visited[key] {
+			visited[key] = true
+			frontier = append(frontier, crawlItem{url: u})
+		}
+	}
+
+	if c.conf.UseSitemap {
+		for _, item := range c.sitemapItems(ctx) {
+			if key := item.url.String(); !visited[key] && c.inScope(item.url) {
+				visited[key] = true
+				frontier = append(frontier, item)
+			}
+		}
+	}
+
+	var (
+		docs     []*schema.Document
+		errs     []error
+		emitted  = make(map[string]bool)
+		maxPages = c.conf.MaxPages
+		fetched  int
+	)
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	for depth := 0; len(frontier) > 0; depth++ {
+		if c.conf.MaxDepth > 0 && depth > c.conf.MaxDepth {
+			break
+		}
+
+		allowed := frontier[:0]
+		for _, item := range frontier {
+			if c.conf.IgnoreRobots || c.robotsOf(ctx, item.url).allowed(item.url.RequestURI()) {
+				allowed = append(allowed, item)
+			}
+		}
+		if len(allowed) > maxPages-fetched {
+			allowed = allowed[:maxPages-fetched]
+		}
+		fetched += len(allowed)
+
+		results := c.fetchAll(ctx, allowed)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var next []crawlItem
+		for i, result := range results {
+			item := allowed[i]
+			if result.err != nil {
+				pageErr := &PageError{URL: item.url.String(), Err: result.err}
+				if !c.conf.ContinueOnError {
+					return nil, pageErr
+				}
+				errs = append(errs, pageErr)
+				continue
+			}
+
+			if result.final != nil {
+				final := result.final.String()
+				visited[final] = true
+				// pages redirected to the same url are only emitted once
+				if emitted[final] {
+					continue
+				}
+				emitted[final] = true
+			}
+			docs = append(docs, result.docs...)
+
+			if c.conf.MaxDepth > 0 && depth >= c.conf.MaxDepth {
+				continue
+			}
+			for _, link := range result.links {
+				u, ok := normalizeURL(link, nil)
+				if !ok || visited[u.String()] || !c.inScope(u) {
+					continue
+				}
+				visited[u.String()] = true
+				next = append(next, crawlItem{url: u, depth: depth + 1, referrer: item.url.String()})
+			}
+		}
+
+		if fetched >= maxPages {
+			break
+		}
+		frontier = next
+	}
+
+	return docs, errors.Join(errs...)
+}
+
+// fetchAll fetches items concurrently and returns the results in the order of items.
+func (c *crawler) fetchAll(ctx context.Context, items []crawlItem) []pageResult {
+	results := make([]pageResult, len(items))
+	workers := c.conf.Concurrency
+	if workers <= 0 {
+		workers = defaultCrawlWorkers
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, workers)
+	)
+	for i := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					results[i] = pageResult{err: fmt.Errorf("panic: %v", r)}
+				}
+				<-sem
+				wg.Done()
+			}()
+			results[i] = c.fetch(ctx, items[i])
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+func (c *crawler) fetch(ctx context.Context, item crawlItem) pageResult {
+	key := item.url.String()
+
+	interval := c.conf.Interval
+	if !c.conf.IgnoreRobots {
+		// robots.txt of the host is already fetched when the item is checked
+		if delay := c.robots[origin(item.url)].crawlDelayOrZero(); delay > interval {
+			interval = delay
+		}
+	}
+	if err := c.limiter.wait(ctx, item.url.Host, interval); err != nil {
+		return pageResult{err: err}
+	}
+
+	req, err := c.newRequest(ctx, key)
+	if err != nil {
+		return pageResult{err: err}
+	}
+
+	var prev *tracker.State
+	if c.conf.Tracker != nil {
+		if prev, _, err = c.conf.Tracker.Get(ctx, key); err != nil {
+			return pageResult{err: err}
+		}
+		if prev != nil && c.conf.Tracker.SkipUnchanged() {
+			if prev.ETag != "" {
+				req.Header.Set("If-None-Match", prev.ETag)
+			}
+			if !prev.ModTime.IsZero() {
+				req.Header.Set("If-Modified-Since", prev.ModTime.UTC().Format(http.TimeFormat))
+			}
+		}
+	}
+
+	resp, err := c.loader.conf.Client.Do(req)
+	if err != nil {
+		return pageResult{err: err}
+	}
+	defer resp.Body.Close()
+
+	final := item.url
+	if resp.Request != nil && resp.Request.URL != nil {
+		if u, ok := normalizeURL(resp.Request.URL.String(), nil); ok {
+			final = u
+		}
+	}
+	if final.String() != key && !c.inScope(final) {
+		return pageResult{}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && prev != nil:
+		return pageResult{links: prev.Links, final: final}
+	case (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone) &&
+		prev != nil && c.conf.Tracker.ReportDeleted():
+		doc := &schema.Document{
+			MetaData: map[string]any{
+				html.MetaKeySource: key,
+				MetaKeyDepth:       item.depth,
+				MetaKeyReferrer:    item.referrer,
+			},
+		}
+		tracker.SetChangeType(doc, tracker.ChangeDeleted)
+		if err = c.conf.Tracker.Forget(ctx, key); err != nil {
+			return pageResult{err: err}
+		}
+		return pageResult{docs: []*schema.Document{doc}, final: final}
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return pageResult{err: fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)}
+	}
+
+	body, err := readBody(resp.Body, c.maxBodySize())
+	if err != nil {
+		return pageResult{err: err}
+	}
+
+	var (
+		links           []string
+		nofollow, noidx bool
+	)
+	if isHTML(resp.Header.Get("Content-Type"), body) {
+		links, nofollow, noidx = extractLinks(body, final)
+		if c.conf.IgnoreRobots {
+			nofollow, noidx = false, false
+		}
+		if nofollow {
+			links = nil
+		}
+	}
+
+	var (
+		state  *tracker.State
+		change tracker.ChangeType
+	)
+	if c.conf.Tracker != nil {
+		state = &tracker.State{
+			ETag:  resp.Header.Get("ETag"),
+			Size:  int64(len(body)),
+			Links: links,
+		}
+		if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+			state.ModTime = lastModified
+		}
+		if state.Hash, err = tracker.Hash(bytes.NewReader(body)); err != nil {
+			return pageResult{err: err}
+		}
+		if change, err = c.conf.Tracker.Detect(ctx, key, state); err != nil {
+			return pageResult{err: err}
+		}
+		if change == tracker.ChangeUnchanged && c.conf.Tracker.SkipUnchanged() {
+			return pageResult{links: links, final: final, err: c.conf.Tracker.Commit(ctx, key, state)}
+		}
+	}
+
+	var docs []*schema.Document
+	if !noidx {
+		meta := map[string]any{
+			MetaKeyDepth:    item.depth,
+			MetaKeyReferrer: item.referrer,
+		}
+		docs, err = c.loader.conf.Parser.Parse(ctx, bytes.NewReader(body),
+			append([]parser.Option{parser.WithURI(final.String()), parser.WithExtraMeta(meta)}, c.o.ParserOptions...)...)
+		if err != nil {
+			return pageResult{err: fmt.Errorf("parse content err: %w", err)}
+		}
+	}
+
+	if state != nil {
+		for _, doc := range docs {
+			tracker.SetChangeType(doc, change)
+		}
+		if err = c.conf.Tracker.Commit(ctx, key, state); err != nil {
+			return pageResult{err: err}
+		}
+	}
+
+	return pageResult{docs: docs, links: links, final: final}
+}
+
+func (c *crawler) newRequest(ctx context.Context, uri string) (*http.Request, error) {
+	req, err := c.loader.conf.RequestBuilder(ctx, document.Source{URI: uri}, c.opts...)
+	if err != nil {
+		return nil, err
+	}
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.userAgent())
+	}
+	return req, nil
+}
+
+// get fetches uri and returns the status code and the body, the body is nil if the status code is not 2xx.
+func (c *crawler) get(ctx context.Context, uri string, limit int64) (int, []byte, error) {
+	req, err := c.newRequest(ctx, uri)
+	if err != nil {
+		return 0, nil, err
+	}
+	resp, err := c.loader.conf.Client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, nil, nil
+	}
+	body, err := readBody(resp.Body, limit)
+	return resp.StatusCode, body, err
+}
+
+// robotsOf returns the robots.txt rules of the host of u, robots.txt is fetched once per host.
+// A missing robots.txt allows everything, while an unavailable one disallows everything.
+func (c *crawler) robotsOf(ctx context.Context, u *url.URL) *robotsRules {
+	key := origin(u)
+	if rules, ok := c.robots[key]; ok {
+		return rules
+	}
+
+	var rules *robotsRules
+	code, body, err := c.get(ctx, key+"/robots.txt", robotsTxtMaxBodySize)
+	switch {
+	case err != nil && !errors.Is(err, ErrBodyTooLarge), code >= 500:
+		rules = &robotsRules{disallowAll: true}
+	case body == nil:
+		rules = &robotsRules{}
+	default:
+		rules = parseRobots(bytes.NewReader(body), c.userAgent())
+	}
+	c.robots[key] = rules
+	return rules
+}
+
+// sitemapItems returns the pages listed in the sitemaps of the seed hosts.
+func (c *crawler) sitemapItems(ctx context.Context) []crawlItem {
+	var (
+		queue []string
+		seen  = make(map[string]bool)
+		items []crawlItem
+	)
+	for _, seed := range c.seeds {
+		key := origin(seed)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		sitemaps := c.robotsOf(ctx, seed).sitemaps
+		if len(sitemaps) == 0 {
+			sitemaps = []string{key + "/sitemap.xml"}
+		}
+		queue = append(queue, sitemaps...)
+	}
+
+	for fetched := 0; len(queue) > 0 && fetched < maxSitemapsPerCrawl; fetched++ {
+		uri := queue[0]
+		queue = queue[1:]
+		if seen[uri] {
+			continue
+		}
+		seen[uri] = true
+
+		_, body, err := c.get(ctx, uri, c.maxBodySize())
+		if err != nil || body == nil {
+			continue
+		}
+		pages, nested, err := parseSitemap(body)
+		if err != nil {
+			continue
+		}
+		queue = append(queue, nested...)
+		for _, page := range pages {
+			if u, ok := normalizeURL(page, nil); ok {
+				items = append(items, crawlItem{url: u, referrer: uri})
+			}
+		}
+	}
+	return items
+}
+
+func (c *crawler) inScope(u *url.URL) bool {
+	matched := false
+	for _, seed := range c.seeds {
+		if matchScope(c.conf.Scope, seed, u) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	return c.conf.Filter == nil || c.conf.Filter(u)
+}
+
+func matchScope(scope Scope, seed, u *url.URL) bool {
+	switch scope {
+	case ScopeAny:
+		return true
+	case ScopeSameDomain:
+		domain := strings.TrimPrefix(seed.Hostname(), "www.")
+		host := u.Hostname()
+		return host == domain || strings.HasSuffix(host, "."+domain)
+	case ScopePathPrefix:
+		if u.Host != seed.Host {
+			return false
+		}
+		dir := seed.Path[:strings.LastIndex(seed.Path, "/")+1]
+		return strings.HasPrefix(u.Path, dir)
+	default:
+		return u.Host == seed.Host
+	}
+}
+
+func (c *crawler) userAgent() string {
+	if c.conf.UserAgent != "" {
+		return c.conf.UserAgent
+	}
+	return DefaultUserAgent
+}
+
+func (c *crawler) maxBodySize() int64 {
+	if c.conf.MaxBodySize > 0 {
+		return c.conf.MaxBodySize
+	}
+	return defaultMaxBodySize
+}
+
+func (r *robotsRules) crawlDelayOrZero() time.Duration {
+	if r == nil {
+		return 0
+	}
+	return r.crawlDelay
+}
+
+// hostLimiter keeps the requests to the same host at least an interval apart.
+type hostLimiter struct {
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func (l *hostLimiter) wait(ctx context.Context, host string, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[host] = at.Add(interval)
+	l.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func readBody(r io.Reader, limit int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: limit= %d", ErrBodyTooLarge, limit)
+	}
+	return body, nil
+}
+
+func isHTML(contentType string, body []byte) bool {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// normalizeURL resolves ref against base, and returns the url without fragment, default port and empty path.
+// Only http and https urls are accepted.
+func normalizeURL(ref string, base *url.URL) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+	u.Fragment, u.RawFragment = "", ""
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = strings.TrimSuffix(u.Host, ":"+port)
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u, true
+}
+
+func origin(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
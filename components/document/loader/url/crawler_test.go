@@ -0,0 +1,359 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/tracker"
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+)
+
+// echoParser returns the page body as the content.
+type echoParser struct{}
+
+func (echoParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	o := parser.GetCommonOptions(&parser.Options{}, opts...)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	meta := map[string]any{html.MetaKeySource: o.URI}
+	for k, v := range o.ExtraMeta {
+		meta[k] = v
+	}
+	return []*schema.Document{{Content: string(data), MetaData: meta}}, nil
+}
+
+type testSite struct {
+	mu       sync.Mutex
+	pages    map[string]string
+	etags    map[string]string
+	requests []string
+	server   *httptest.Server
+}
+
+func newTestSite(t *testing.T, pages map[string]string) *testSite {
+	s := &testSite{pages: pages, etags: map[string]string{}}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.RequestURI())
+		body, ok := s.pages[r.URL.RequestURI()]
+		etag := s.etags[r.URL.RequestURI()]
+		s.mu.Unlock()
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if strings.HasPrefix(body, "status:") {
+			var code int
+			_, _ = fmt.Sscanf(body, "status:%d", &code)
+			w.WriteHeader(code)
+			return
+		}
+		if strings.HasPrefix(body, "redirect:") {
+			http.Redirect(w, r, strings.TrimPrefix(body, "redirect:"), http.StatusFound)
+			return
+		}
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".txt"):
+			w.Header().Set("Content-Type", "text/plain")
+		case strings.HasSuffix(r.URL.Path, ".xml"):
+			w.Header().Set("Content-Type", "application/xml")
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+		_, _ = io.WriteString(w, strings.ReplaceAll(body, "{{host}}", s.server.URL))
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *testSite) url(path string) string {
+	return s.server.URL + path
+}
+
+func (s *testSite) set(path, body, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pages[path] = body
+	s.etags[path] = etag
+}
+
+func (s *testSite) requested() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := s.requests
+	s.requests = nil
+	return requests
+}
+
+func sources(docs []*schema.Document) []string {
+	uris := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		uris = append(uris, doc.MetaData[html.MetaKeySource].(string))
+	}
+	return uris
+}
+
+func link(paths ...string) string {
+	var sb strings.Builder
+	sb.WriteString("<html><body>")
+	for _, p := range paths {
+		sb.WriteString(`<a href="` + p + `">` + p + `</a>`)
+	}
+	sb.WriteString("</body></html>")
+	return sb.String()
+}
+
+func TestLoader_Crawl(t *testing.T) {
+	ctx := context.Background()
+
+	site := newTestSite(t, map[string]string{
+		"/robots.txt": "User-agent: *\nDisallow: /private\n\nUser-agent: OtherBot\nDisallow: /\n",
+		"/":           link("/a", "/b#section", "/private/x", "https://example.com/external", "mailto:a@example.com"),
+		"/a":          link("/", "/c", `/b" rel="nofollow`),
+		"/b":          link("/a?x=1"),
+		"/c":          link("/d"),
+		"/d":          "<html><body>d</body></html>",
+		"/private/x":  "private",
+		"/a?x=1":      "<html><head><meta name=\"robots\" content=\"noindex\"></head><body>" + link("/d") + "</body></html>",
+	})
+
+	newLoader := func(conf *CrawlConfig) *Loader {
+		loader, err := NewLoader(ctx, &LoaderConfig{Parser: echoParser{}, Crawl: conf})
+		assert.NoError(t, err)
+		return loader
+	}
+
+	t.Run("breadth first with max depth", func(t *testing.T) {
+		docs, err := newLoader(&CrawlConfig{MaxDepth: 1}).Load(ctx, document.Source{URI: site.url("/")})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{site.url("/"), site.url("/a"), site.url("/b")}, sources(docs))
+		assert.Equal(t, 0, docs[0].MetaData[MetaKeyDepth])
+		assert.Equal(t, "", docs[0].MetaData[MetaKeyReferrer])
+		assert.Equal(t, 1, docs[1].MetaData[MetaKeyDepth])
+		assert.Equal(t, site.url("/"), docs[1].MetaData[MetaKeyReferrer])
+	})
+
+	t.Run("robots and meta robots", func(t *testing.T) {
+		site.requested()
+		docs, err := newLoader(&CrawlConfig{Concurrency: 1}).Load(ctx, document.Source{URI: site.url("/")})
+		assert.NoError(t, err)
+		// /a?x=1 is noindex, /d is reached through /c
+		assert.Equal(t, []string{site.url("/"), site.url("/a"), site.url("/b"), site.url("/c"), site.url("/d")}, sources(docs))
+		assert.Equal(t, 2, docs[3].MetaData[MetaKeyDepth])
+		assert.Equal(t, site.url("/a"), docs[3].MetaData[MetaKeyReferrer])
+		assert.NotContains(t, site.requested(), "/private/x")
+
+		docs, err = newLoader(&CrawlConfig{UserAgent: "OtherBot/2.0"}).Load(ctx, document.Source{URI: site.url("/")})
+		assert.NoError(t, err)
+		assert.Empty(t, docs)
+
+		docs, err = newLoader(&CrawlConfig{IgnoreRobots: true, MaxDepth: 1}).Load(ctx, document.Source{URI: site.url("/")})
+		assert.NoError(t, err)
+		assert.Contains(t, sources(docs), site.url("/private/x"))
+	})
+
+	t.Run("max pages", func(t *testing.T) {
+		docs, err := newLoader(&CrawlConfig{MaxPages: 2}).Load(ctx, document.Source{URI: site.url("/")})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{site.url("/"), site.url("/a")}, sources(docs))
+	})
+
+	t.Run("path prefix scope and filter", func(t *testing.T) {
+		site.set("/docs/", link("/docs/intro", "/blog/post", "/docs/skip"), "")
+		site.set("/docs/intro", "intro", "")
+		site.set("/docs/skip", "skip", "")
+		site.set("/blog/post", "post", "")
+
+		docs, err := newLoader(&CrawlConfig{
+			Scope: ScopePathPrefix,
+			Filter: func(u *url.URL) bool {
+				return !strings.HasSuffix(u.Path, "/skip")
+			},
+		}).Load(ctx, document.Source{URI: site.url("/docs/")})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{site.url("/docs/"), site.url("/docs/intro")}, sources(docs))
+	})
+
+	t.Run("sitemap", func(t *testing.T) {
+		sitemapSite := newTestSite(t, map[string]string{
+			"/robots.txt": "User-agent: *\nAllow: /\nSitemap: {{host}}/sitemap_index.xml\n",
+			"/sitemap_index.xml": `<?xml version="1.0"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+				`<sitemap><loc>{{host}}/sitemap.xml</loc></sitemap></sitemapindex>`,
+			"/sitemap.xml": `<?xml version="1.0"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+				`<url><loc>{{host}}/orphan</loc></url><url><loc>https://example.com/out</loc></url></urlset>`,
+			"/":       link(),
+			"/orphan": "orphan",
+		})
+
+		docs, err := newLoader(&CrawlConfig{UseSitemap: true}).Load(ctx, document.Source{URI: sitemapSite.url("/")})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{sitemapSite.url("/"), sitemapSite.url("/orphan")}, sources(docs))
+		assert.Equal(t, sitemapSite.url("/sitemap.xml"), docs[1].MetaData[MetaKeyReferrer])
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		redirectSite := newTestSite(t, map[string]string{
+			"/":    link("/old", "/new"),
+			"/old": "redirect:/new",
+			"/new": "new",
+		})
+		docs, err := newLoader(&CrawlConfig{}).Load(ctx, document.Source{URI: redirectSite.url("/")})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{redirectSite.url("/"), redirectSite.url("/new")}, sources(docs))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		errSite := newTestSite(t, map[string]string{
+			"/":       link("/broken", "/ok"),
+			"/broken": "status:500",
+			"/ok":     "ok",
+		})
+
+		_, err := newLoader(&CrawlConfig{}).Load(ctx, document.Source{URI: errSite.url("/")})
+		assert.ErrorIs(t, err, ErrUnexpectedStatus)
+
+		docs, err := newLoader(&CrawlConfig{ContinueOnError: true}).Load(ctx, document.Source{URI: errSite.url("/")})
+		assert.ErrorIs(t, err, ErrUnexpectedStatus)
+		assert.Equal(t, []string{errSite.url("/"), errSite.url("/ok")}, sources(docs))
+		var pageErr *PageError
+		assert.True(t, errors.As(err, &pageErr))
+		assert.Equal(t, errSite.url("/broken"), pageErr.URL)
+
+		_, err = newLoader(&CrawlConfig{MaxBodySize: 4}).Load(ctx, document.Source{URI: errSite.url("/")})
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+
+		_, err = newLoader(&CrawlConfig{}).Load(ctx, document.Source{URI: "ftp://example.com"})
+		assert.ErrorContains(t, err, "invalid seed url")
+	})
+}
+
+func TestLoader_CrawlWithTracker(t *testing.T) {
+	ctx := context.Background()
+
+	site := newTestSite(t, map[string]string{
+		"/":  link("/a", "/b"),
+		"/a": "a",
+		"/b": "b",
+	})
+	site.set("/", link("/a", "/b"), `"v1"`)
+
+	tr, err := tracker.NewTracker(ctx, &tracker.Config{
+		Store:         tracker.NewMemoryStore(),
+		SkipUnchanged: true,
+		ReportDeleted: true,
+	})
+	assert.NoError(t, err)
+	loader, err := NewLoader(ctx, &LoaderConfig{
+		Parser: echoParser{},
+		Crawl:  &CrawlConfig{Tracker: tr, Concurrency: 1},
+	})
+	assert.NoError(t, err)
+
+	docs, err := loader.Load(ctx, document.Source{URI: site.url("/")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{site.url("/"), site.url("/a"), site.url("/b")}, sources(docs))
+	for _, doc := range docs {
+		change, _ := tracker.GetChangeType(doc)
+		assert.Equal(t, tracker.ChangeAdded, change)
+	}
+
+	// the root page is not modified, its links are followed from the recorded state
+	site.set("/a", "a2", "")
+	site.set("/b", "status:404", "")
+	site.requested()
+	docs, err = loader.Load(ctx, document.Source{URI: site.url("/")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{site.url("/a"), site.url("/b")}, sources(docs))
+	change, _ := tracker.GetChangeType(docs[0])
+	assert.Equal(t, tracker.ChangeModified, change)
+	assert.Equal(t, "a2", docs[0].Content)
+	change, _ = tracker.GetChangeType(docs[1])
+	assert.Equal(t, tracker.ChangeDeleted, change)
+	assert.Equal(t, []string{"/robots.txt", "/", "/a", "/b"}, site.requested())
+
+	keys, err := tr.Keys(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{site.url("/"), site.url("/a")}, keys)
+}
+
+func TestRobots(t *testing.T) {
+	rules := parseRobots(strings.NewReader(`
+# comment
+User-agent: EinoCrawler
+User-agent: foo
+Disallow: /tmp
+Allow: /tmp/public
+Disallow: /*.pdf$
+Crawl-delay: 1.5
+
+User-agent: *
+Disallow: /
+
+Sitemap: https://example.com/sitemap.xml
+`), "EinoCrawler/1.0")
+
+	assert.Equal(t, []string{"https://example.com/sitemap.xml"}, rules.sitemaps)
+	assert.Equal(t, "1.5s", rules.crawlDelay.String())
+	assert.True(t, rules.allowed("/"))
+	assert.False(t, rules.allowed("/tmp/x"))
+	assert.True(t, rules.allowed("/tmp/public/x"))
+	assert.False(t, rules.allowed("/docs/a.pdf"))
+	assert.True(t, rules.allowed("/docs/a.pdf?download=1"))
+
+	rules = parseRobots(strings.NewReader("User-agent: *\nDisallow: /\n"), "SomeBot")
+	assert.False(t, rules.allowed("/a"))
+
+	assert.True(t, matchRobotsPattern("/a*/c", "/a/b/c/d"))
+	assert.False(t, matchRobotsPattern("/a*/c$", "/a/b/c/d"))
+	assert.True(t, matchRobotsPattern("/a*/c$", "/a/b/c"))
+}
+
+func TestParseSitemap(t *testing.T) {
+	pages, nested, err := parseSitemap([]byte("https://example.com/a\n\nhttps://example.com/b\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b"}, pages)
+	assert.Empty(t, nested)
+
+	_, _, err = parseSitemap([]byte("<urlset><url>"))
+	assert.Error(t, err)
+}
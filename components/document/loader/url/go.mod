@@ -2,10 +2,14 @@ module github.com/cloudwego/eino-ext/components/document/loader/url
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/components/document/loader/tracker => ../tracker
+
 require (
 	github.com/cloudwego/eino v0.6.0
+	github.com/cloudwego/eino-ext/components/document/loader/tracker v0.0.0-00010101000000-000000000000
 	github.com/cloudwego/eino-ext/components/document/parser/html v0.0.0-20241224063832-9fbcc0e56c28
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/net v0.41.0
 )
 
 require (
@@ -40,7 +44,6 @@ require (
 	github.com/yargevad/filepathx v1.0.0 // indirect
 	golang.org/x/arch v0.11.0 // indirect
 	golang.org/x/exp v0.0.0-20230713183714-613f0c0eb8a1 // indirect
-	golang.org/x/net v0.41.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// extractLinks returns the deduplicated links of an html page, and whether its robots meta tag
+// contains nofollow or noindex. Links with rel="nofollow" are skipped.
+func extractLinks(body []byte, base *url.URL) (links []string, nofollow, noindex bool) {
+	seen := make(map[string]bool)
+	z := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return links, nofollow, noindex
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		name, hasAttr := z.TagName()
+		tag := string(name)
+		if !hasAttr || (tag != "a" && tag != "area" && tag != "base" && tag != "meta") {
+			continue
+		}
+		attrs := make(map[string]string)
+		for hasAttr {
+			var key, val []byte
+			key, val, hasAttr = z.TagAttr()
+			attrs[string(key)] = string(val)
+		}
+
+		switch tag {
+		case "base":
+			if u, ok := normalizeURL(attrs["href"], base); ok {
+				base = u
+			}
+		case "meta":
+			if !strings.EqualFold(attrs["name"], "robots") {
+				continue
+			}
+			content := strings.ToLower(attrs["content"])
+			nofollow = nofollow || strings.Contains(content, "nofollow") || strings.Contains(content, "none")
+			noindex = noindex || strings.Contains(content, "noindex") || strings.Contains(content, "none")
+		default:
+			if strings.Contains(strings.ToLower(attrs["rel"]), "nofollow") {
+				continue
+			}
+			href, ok := attrs["href"]
+			if !ok {
+				continue
+			}
+			if u, ok := normalizeURL(href, base); ok && !seen[u.String()] {
+				seen[u.String()] = true
+				links = append(links, u.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robotsRules is the group of a robots.txt which applies to the crawler.
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+	sitemaps   []string
+	// disallowAll is set when robots.txt is unavailable because of a server error
+	disallowAll bool
+}
+
+type robotsRule struct {
+	pattern string
+	allow   bool
+}
+
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// parseRobots parses robots.txt and returns the rules of the most specific group matching userAgent,
+// or the rules of the "*" group if none matches.
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	var (
+		groups   []*robotsGroup
+		current  *robotsGroup
+		sitemaps []string
+		// a group starts with consecutive user-agent lines
+		inAgents bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 512*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents || current == nil {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgents = true
+		case "allow", "disallow":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			// an empty disallow allows everything
+			if value == "" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{pattern: value, allow: key == "allow"})
+		case "crawl-delay":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		case "sitemap":
+			if value != "" {
+				sitemaps = append(sitemaps, value)
+			}
+		}
+	}
+
+	token := strings.ToLower(userAgent)
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+	var (
+		matched  *robotsGroup
+		matchLen = -1
+	)
+	for _, group := range groups {
+		for _, agent := range group.agents {
+			switch {
+			case agent == "*":
+				if matchLen < 0 {
+					matched, matchLen = group, 0
+				}
+			case token != "" && strings.Contains(token, agent) && len(agent) > matchLen:
+				matched, matchLen = group, len(agent)
+			}
+		}
+	}
+
+	rules := &robotsRules{sitemaps: sitemaps}
+	if matched != nil {
+		rules.rules = matched.rules
+		rules.crawlDelay = matched.crawlDelay
+	}
+	return rules
+}
+
+// allowed reports whether path (with the query) may be crawled, the longest matching rule wins,
+// and allow wins if an allow and a disallow rule are equally long.
+func (r *robotsRules) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+	if r.disallowAll {
+		return false
+	}
+	var (
+		allow   = true
+		longest = -1
+	)
+	for _, rule := range r.rules {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			allow, longest = rule.allow, len(rule.pattern)
+		}
+	}
+	return allow
+}
+
+// matchRobotsPattern matches path against a robots.txt path pattern, which is a prefix with
+// '*' matching any sequence of characters and a trailing '$' anchoring the end.
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = pattern[:len(pattern)-1]
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	if len(parts) == 1 {
+		return !anchored || rest == ""
+	}
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		if last && anchored {
+			return strings.HasSuffix(rest, part)
+		}
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return true
+}
@@ -43,6 +43,10 @@ type LoaderConfig struct {
 
 	// optional, default GET uri.
 	RequestBuilder func(ctx context.Context, source document.Source, opts ...document.LoaderOption) (*http.Request, error)
+
+	// optional, enables crawling from the source uri, see CrawlConfig for details.
+	// If nil, only the source uri is loaded.
+	Crawl *CrawlConfig
 }
 
 func defaultRequestBuilder(ctx context.Context, source document.Source, opts ...document.LoaderOption) (*http.Request, error) {
@@ -93,6 +97,21 @@ func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document
 		}
 	}()
 
+	if l.conf.Crawl != nil {
+		if l.conf.Parser == nil {
+			return nil, errors.New("parser is nil")
+		}
+		docs, err = l.crawl(ctx, src, opts...)
+		if err != nil && len(docs) == 0 {
+			return nil, err
+		}
+		_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+			Source: src,
+			Docs:   docs,
+		})
+		return docs, err
+	}
+
 	var readerCloser io.ReadCloser
 	readerCloser, err = l.load(ctx, src)
 	if err != nil {
@@ -103,7 +122,7 @@ func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document
 	if l.conf.Parser == nil {
 		return nil, errors.New("parser is nil")
 	}
-	
+
 	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
 
 	docs, err = l.conf.Parser.Parse(ctx, readerCloser, append([]parser.Option{parser.WithURI(src.URI)}, o.ParserOptions...)...)
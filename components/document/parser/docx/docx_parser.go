@@ -33,12 +33,17 @@ import (
 
 const (
 	SectionTypeKey = "sectionType"
+	// HeadingPathKey is the metadata key of the heading titles from the top level heading to the section's heading,
+	// only set when both ToMarkdown and ToSections are enabled.
+	HeadingPathKey = "headingPath"
 )
 
 // Config is the configuration for Docx parser.
 type Config struct {
-	ToSections bool // whether to split content by sections
-	// deprecated: Comments are not supported yet.
+	ToSections bool // whether to split content by sections, in Markdown mode the main content is also split by headings
+	// ToMarkdown renders the document as Markdown, keeping headings, ordered and unordered lists, bold and italic text,
+	// tables and hyperlinks. Comments are rendered as footnotes of the text they refer to.
+	ToMarkdown      bool
 	IncludeComments bool // whether to include comments in the parsed content
 	IncludeHeaders  bool // whether to include headers in the parsed content
 	IncludeFooters  bool // whether to include footers in the parsed content
@@ -47,8 +52,8 @@ type Config struct {
 
 // DocxParser reads from io.Reader and parse Docx document content as plain text.
 type DocxParser struct {
-	toSections bool
-	// deprecated: Comments are not supported yet.
+	toSections      bool
+	toMarkdown      bool
 	includeComments bool
 	includeHeaders  bool
 	includeFooters  bool
@@ -77,6 +82,7 @@ func NewDocxParser(_ context.Context, config *Config) (*DocxParser, error) {
 	}
 	return &DocxParser{
 		toSections:      config.ToSections,
+		toMarkdown:      config.ToMarkdown,
 		includeComments: config.IncludeComments,
 		includeHeaders:  config.IncludeHeaders,
 		includeFooters:  config.IncludeFooters,
@@ -88,6 +94,15 @@ func NewDocxParser(_ context.Context, config *Config) (*DocxParser, error) {
 func (wp *DocxParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
 	commonOpts := parser.GetCommonOptions(nil, opts...)
 
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("docx parser failed to read content: %w", err)
+	}
+
+	if wp.toMarkdown {
+		return wp.parseMarkdown(data, commonOpts.ExtraMeta)
+	}
+
 	// Create a temporary file to hold the docx content
 	tempFile, err := os.CreateTemp("", "eino-docx-*.docx")
 	if err != nil {
@@ -100,8 +115,8 @@ func (wp *DocxParser) Parse(_ context.Context, reader io.Reader, opts ...parser.
 		}
 	}()
 
-	// Copy the reader content to the temporary file
-	if _, err = io.Copy(tempFile, reader); err != nil {
+	// Copy the content to the temporary file
+	if _, err = tempFile.Write(data); err != nil {
 		return nil, fmt.Errorf("docx parser failed to write to temporary file: %w", err)
 	}
 	// Close the file so it can be read by the library
@@ -120,6 +135,17 @@ func (wp *DocxParser) Parse(_ context.Context, reader io.Reader, opts ...parser.
 		return nil, fmt.Errorf("open Docx document failed: %w", err)
 	}
 
+	// docx2md does not support comments, extract them from the package directly
+	if wp.includeComments {
+		pkg, err := openDocxPackage(data)
+		if err != nil {
+			return nil, err
+		}
+		if comments := pkg.plainComments(); comments != "" {
+			sections["comments"] = comments
+		}
+	}
+
 	// Extract content based on configuration
 	if wp.toSections {
 		for key, section := range sections {
@@ -165,6 +191,89 @@ func (wp *DocxParser) Parse(_ context.Context, reader io.Reader, opts ...parser.
 	return docs, nil
 }
 
+// parseMarkdown renders the docx package as Markdown.
+func (wp *DocxParser) parseMarkdown(data []byte, extraMeta map[string]any) (docs []*schema.Document, err error) {
+	pkg, err := openDocxPackage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := pkg.renderPart("word/document.xml", wp.includeTables, wp.includeComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers, footers string
+	if wp.includeHeaders {
+		if headers, err = wp.renderParts(pkg, pkg.headers); err != nil {
+			return nil, err
+		}
+	}
+	if wp.includeFooters {
+		if footers, err = wp.renderParts(pkg, pkg.footers); err != nil {
+			return nil, err
+		}
+	}
+
+	newDoc := func(content, sectionType string) *schema.Document {
+		metadata := make(map[string]interface{}, len(extraMeta)+2)
+		for k, v := range extraMeta {
+			metadata[k] = v
+		}
+		metadata[SectionTypeKey] = sectionType
+		return &schema.Document{
+			ID:       uuid.New().String(),
+			Content:  content,
+			MetaData: metadata,
+		}
+	}
+
+	if !wp.toSections {
+		var parts []string
+		for _, part := range []string{headers, pkg.joinBlocks(blocks), footers} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) == 0 {
+			return nil, nil
+		}
+		return []*schema.Document{newDoc(strings.Join(parts, "\n\n"), "fullContent")}, nil
+	}
+
+	if headers != "" {
+		docs = append(docs, newDoc(headers, "headers"))
+	}
+	for _, section := range pkg.splitByHeadings(blocks) {
+		doc := newDoc(section.content, "main")
+		doc.MetaData[HeadingPathKey] = section.headingPath
+		docs = append(docs, doc)
+	}
+	if footers != "" {
+		docs = append(docs, newDoc(footers, "footers"))
+	}
+	return docs, nil
+}
+
+// renderParts renders header or footer parts, identical parts such as the first page and default headers are merged.
+func (wp *DocxParser) renderParts(pkg *docxPackage, names []string) (string, error) {
+	var (
+		parts []string
+		seen  = make(map[string]bool)
+	)
+	for _, name := range names {
+		blocks, err := pkg.renderPart(name, wp.includeTables, wp.includeComments)
+		if err != nil {
+			return "", err
+		}
+		if content := pkg.joinBlocks(blocks); content != "" && !seen[content] {
+			seen[content] = true
+			parts = append(parts, content)
+		}
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
 func GetSectionType(doc *schema.Document) (string, bool) {
 	if doc == nil {
 		return "", false
@@ -172,3 +281,12 @@ func GetSectionType(doc *schema.Document) (string, bool) {
 	sectionType, ok := doc.MetaData[SectionTypeKey].(string)
 	return sectionType, ok
 }
+
+// GetHeadingPath returns the heading path of a section parsed in Markdown mode.
+func GetHeadingPath(doc *schema.Document) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	headingPath, ok := doc.MetaData[HeadingPathKey].([]string)
+	return headingPath, ok
+}
@@ -17,8 +17,11 @@
 package docx
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -73,3 +76,158 @@ func TestDocxParser_Parse(t *testing.T) {
 
 	})
 }
+
+const (
+	testNS = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	testRelNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+// buildDocx writes a docx package with the given body of word/document.xml.
+func buildDocx(t *testing.T, body string) []byte {
+	parts := map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"word/document.xml": `<w:document ` + testNS + `><w:body>` + body + `</w:body></w:document>`,
+		"word/styles.xml": `<w:styles ` + testNS + `>` +
+			`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="MyHeading"><w:name w:val="My Heading"/><w:basedOn w:val="Heading2"/></w:style>` +
+			`</w:styles>`,
+		"word/numbering.xml": `<w:numbering ` + testNS + `>` +
+			`<w:abstractNum w:abstractNumId="0"><w:lvl w:ilvl="0"><w:numFmt w:val="bullet"/></w:lvl><w:lvl w:ilvl="1"><w:numFmt w:val="decimal"/></w:lvl></w:abstractNum>` +
+			`<w:abstractNum w:abstractNumId="1"><w:lvl w:ilvl="0"><w:numFmt w:val="decimal"/></w:lvl></w:abstractNum>` +
+			`<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num><w:num w:numId="2"><w:abstractNumId w:val="1"/></w:num>` +
+			`</w:numbering>`,
+		"word/_rels/document.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + testRelNS + `/hyperlink" Target="https://example.com" TargetMode="External"/>` +
+			`<Relationship Id="rId2" Type="` + testRelNS + `/header" Target="header1.xml"/>` +
+			`<Relationship Id="rId3" Type="` + testRelNS + `/footer" Target="footer1.xml"/>` +
+			`</Relationships>`,
+		"word/comments.xml": `<w:comments ` + testNS + `>` +
+			`<w:comment w:id="0" w:author="Tom"><w:p><w:r><w:t>Check this</w:t></w:r></w:p></w:comment>` +
+			`</w:comments>`,
+		"word/header1.xml": `<w:hdr ` + testNS + `>` + para("", "Company Header") + `</w:hdr>`,
+		"word/footer1.xml": `<w:ftr ` + testNS + `>` + para("", "Page footer") + `</w:ftr>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// para returns a paragraph with the given style and a single run.
+func para(style, text string) string {
+	pPr := ""
+	if style != "" {
+		pPr = `<w:pPr><w:pStyle w:val="` + style + `"/></w:pPr>`
+	}
+	return `<w:p>` + pPr + `<w:r><w:t xml:space="preserve">` + text + `</w:t></w:r></w:p>`
+}
+
+func listItem(numID, level, text, extra string) string {
+	return `<w:p><w:pPr><w:numPr><w:ilvl w:val="` + level + `"/><w:numId w:val="` + numID + `"/></w:numPr></w:pPr>` +
+		`<w:r><w:t>` + text + `</w:t></w:r>` + extra + `</w:p>`
+}
+
+func cell(text string) string {
+	return `<w:tc>` + para("", text) + `</w:tc>`
+}
+
+func testDocxBody() string {
+	return para("Title", "Report") +
+		`<w:p><w:r><w:t xml:space="preserve">Intro with </w:t></w:r>` +
+		`<w:r><w:rPr><w:b/></w:rPr><w:t>bold</w:t></w:r>` +
+		`<w:r><w:t xml:space="preserve"> and </w:t></w:r>` +
+		`<w:r><w:rPr><w:i/></w:rPr><w:t>italic</w:t></w:r>` +
+		`<w:r><w:rPr><w:b w:val="0"/></w:rPr><w:t xml:space="preserve"> and a </w:t></w:r>` +
+		`<w:hyperlink r:id="rId1"><w:r><w:t>link</w:t></w:r></w:hyperlink>` +
+		`<w:r><w:t>.</w:t></w:r></w:p>` +
+		para("Heading1", "Usage") +
+		listItem("2", "0", "First", "") +
+		listItem("2", "0", "Second", `<w:r><w:commentReference w:id="0"/></w:r>`) +
+		listItem("1", "0", "Apple", "") +
+		listItem("1", "1", "Step", "") +
+		listItem("1", "1", "Step two", "") +
+		para("Heading2", "Details") +
+		`<w:tbl><w:tr>` + cell("Key") + cell("Value") + `</w:tr><w:tr>` + cell("a|b") + cell("1") + `</w:tr></w:tbl>` +
+		para("MyHeading", "Sub") +
+		`<w:sdt><w:sdtContent>` + para("", "Done.") + `</w:sdtContent></w:sdt>`
+}
+
+func TestDocxParser_ParseMarkdown(t *testing.T) {
+	ctx := context.Background()
+	data := buildDocx(t, testDocxBody())
+
+	t.Run("full content", func(t *testing.T) {
+		p, err := NewDocxParser(ctx, &Config{
+			ToMarkdown:      true,
+			IncludeComments: true,
+			IncludeHeaders:  true,
+			IncludeFooters:  true,
+			IncludeTables:   true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "Company Header\n\n"+
+			"# Report\n\n"+
+			"Intro with **bold** and *italic* and a [link](https://example.com).\n\n"+
+			"# Usage\n\n"+
+			"1. First\n2. Second[^comment-1]\n\n"+
+			"- Apple\n    1. Step\n    2. Step two\n\n"+
+			"## Details\n\n"+
+			"| Key | Value |\n| --- | --- |\n| a\\|b | 1 |\n\n"+
+			"## Sub\n\n"+
+			"Done.\n\n"+
+			"[^comment-1]: Tom: Check this\n\n"+
+			"Page footer", docs[0].Content)
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+		typ, _ := GetSectionType(docs[0])
+		assert.Equal(t, "fullContent", typ)
+	})
+
+	t.Run("sections with heading path", func(t *testing.T) {
+		p, err := NewDocxParser(ctx, &Config{
+			ToMarkdown: true,
+			ToSections: true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(docs))
+
+		var paths [][]string
+		for _, doc := range docs {
+			path, ok := GetHeadingPath(doc)
+			assert.True(t, ok)
+			paths = append(paths, path)
+		}
+		assert.Equal(t, [][]string{{"Report"}, {"Usage"}, {"Usage", "Details"}, {"Usage", "Sub"}}, paths)
+		assert.Equal(t, "# Usage\n\n1. First\n2. Second\n\n- Apple\n    1. Step\n    2. Step two", docs[1].Content)
+		assert.Equal(t, "## Details", docs[2].Content)
+		assert.Equal(t, "## Sub\n\nDone.", docs[3].Content)
+	})
+}
+
+func TestDocxPackage_PlainComments(t *testing.T) {
+	pkg, err := openDocxPackage(buildDocx(t, testDocxBody()))
+	assert.NoError(t, err)
+	assert.Equal(t, "Tom: Check this", pkg.plainComments())
+
+	_, err = openDocxPackage([]byte("not a zip"))
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "open docx package failed"))
+}
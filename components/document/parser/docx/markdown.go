@@ -0,0 +1,728 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// xmlNode is a generic element of the WordprocessingML parts.
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []xmlNode  `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+func (n *xmlNode) attr(local string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n *xmlNode) child(local string) *xmlNode {
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local == local {
+			return &n.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// on reports whether a toggle property such as <w:b/> is set.
+func (n *xmlNode) on(local string) bool {
+	c := n.child(local)
+	if c == nil {
+		return false
+	}
+	v := c.attr("val")
+	return v != "0" && v != "false" && v != "none"
+}
+
+var headingStyleRegexp = regexp.MustCompile(`^heading\s*(\d)$`)
+
+// docxPackage holds the parts of a docx file needed to render Markdown.
+type docxPackage struct {
+	files map[string]*zip.File
+
+	headingLevels map[string]int      // style id -> heading level
+	listFormats   map[string][]string // num id -> number format of every level
+	links         map[string]string   // relationship id -> hyperlink target
+	comments      map[string]*comment // comment id -> comment
+	counters      map[string][]int    // num id -> current number of every level
+	headers       []string            // header part names
+	footers       []string            // footer part names
+	commentOrder  []string            // ids of referenced comments in order
+	commentLabels map[string]string   // comment id -> footnote label
+}
+
+type comment struct {
+	author string
+	text   string
+}
+
+func openDocxPackage(data []byte) (*docxPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open docx package failed: %w", err)
+	}
+
+	pkg := &docxPackage{
+		files:         make(map[string]*zip.File, len(zr.File)),
+		headingLevels: make(map[string]int),
+		listFormats:   make(map[string][]string),
+		links:         make(map[string]string),
+		comments:      make(map[string]*comment),
+		counters:      make(map[string][]int),
+		commentLabels: make(map[string]string),
+	}
+	for _, f := range zr.File {
+		pkg.files[f.Name] = f
+	}
+	if _, ok := pkg.files["word/document.xml"]; !ok {
+		return nil, fmt.Errorf("open docx package failed: word/document.xml not found")
+	}
+
+	for _, load := range []func() error{pkg.loadStyles, pkg.loadNumbering, pkg.loadRelationships, pkg.loadComments} {
+		if err = load(); err != nil {
+			return nil, err
+		}
+	}
+	return pkg, nil
+}
+
+// part reads and decodes a part, a missing part returns nil without error.
+func (p *docxPackage) part(name string) (*xmlNode, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open docx part [%s] failed: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read docx part [%s] failed: %w", name, err)
+	}
+	root := &xmlNode{}
+	if err = xml.Unmarshal(data, root); err != nil {
+		return nil, fmt.Errorf("decode docx part [%s] failed: %w", name, err)
+	}
+	return root, nil
+}
+
+func (p *docxPackage) loadStyles() error {
+	root, err := p.part("word/styles.xml")
+	if err != nil || root == nil {
+		return err
+	}
+
+	basedOn := make(map[string]string)
+	for _, style := range root.Nodes {
+		if style.XMLName.Local != "style" || style.attr("type") != "paragraph" {
+			continue
+		}
+		id := style.attr("styleId")
+		if b := style.child("basedOn"); b != nil {
+			basedOn[id] = b.attr("val")
+		}
+
+		name := ""
+		if n := style.child("name"); n != nil {
+			name = strings.ToLower(n.attr("val"))
+		}
+		switch {
+		case name == "title":
+			p.headingLevels[id] = 1
+		case headingStyleRegexp.MatchString(name):
+			level, _ := strconv.Atoi(headingStyleRegexp.FindStringSubmatch(name)[1])
+			p.headingLevels[id] = level
+		default:
+			if pPr := style.child("pPr"); pPr != nil {
+				if level, ok := outlineLevel(pPr); ok {
+					p.headingLevels[id] = level
+				}
+			}
+		}
+	}
+
+	// styles based on a heading style are headings of the same level
+	for id := range basedOn {
+		seen := map[string]bool{}
+		for cur := id; cur != "" && !seen[cur]; cur = basedOn[cur] {
+			seen[cur] = true
+			if level, ok := p.headingLevels[cur]; ok {
+				p.headingLevels[id] = level
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func outlineLevel(pPr *xmlNode) (int, bool) {
+	o := pPr.child("outlineLvl")
+	if o == nil {
+		return 0, false
+	}
+	level, err := strconv.Atoi(o.attr("val"))
+	// level 9 is body text
+	if err != nil || level < 0 || level >= 9 {
+		return 0, false
+	}
+	return level + 1, true
+}
+
+func (p *docxPackage) loadNumbering() error {
+	root, err := p.part("word/numbering.xml")
+	if err != nil || root == nil {
+		return err
+	}
+
+	abstract := make(map[string][]string)
+	for _, n := range root.Nodes {
+		if n.XMLName.Local != "abstractNum" {
+			continue
+		}
+		var formats []string
+		for _, lvl := range n.Nodes {
+			if lvl.XMLName.Local != "lvl" {
+				continue
+			}
+			level, err := strconv.Atoi(lvl.attr("ilvl"))
+			if err != nil || level < 0 || level > 8 {
+				continue
+			}
+			for len(formats) <= level {
+				formats = append(formats, "")
+			}
+			if f := lvl.child("numFmt"); f != nil {
+				formats[level] = f.attr("val")
+			}
+		}
+		abstract[n.attr("abstractNumId")] = formats
+	}
+	for _, n := range root.Nodes {
+		if n.XMLName.Local != "num" {
+			continue
+		}
+		if a := n.child("abstractNumId"); a != nil {
+			p.listFormats[n.attr("numId")] = abstract[a.attr("val")]
+		}
+	}
+	return nil
+}
+
+func (p *docxPackage) loadRelationships() error {
+	root, err := p.part("word/_rels/document.xml.rels")
+	if err != nil || root == nil {
+		return err
+	}
+	for _, rel := range root.Nodes {
+		target := rel.attr("Target")
+		switch path.Base(rel.attr("Type")) {
+		case "hyperlink":
+			p.links[rel.attr("Id")] = target
+		case "header":
+			p.headers = append(p.headers, path.Join("word", target))
+		case "footer":
+			p.footers = append(p.footers, path.Join("word", target))
+		}
+	}
+	sort.Strings(p.headers)
+	sort.Strings(p.footers)
+	return nil
+}
+
+func (p *docxPackage) loadComments() error {
+	root, err := p.part("word/comments.xml")
+	if err != nil || root == nil {
+		return err
+	}
+	for i := range root.Nodes {
+		n := &root.Nodes[i]
+		if n.XMLName.Local != "comment" {
+			continue
+		}
+		var paragraphs []string
+		for j := range n.Nodes {
+			if n.Nodes[j].XMLName.Local == "p" {
+				if text := strings.TrimSpace(plainText(&n.Nodes[j])); text != "" {
+					paragraphs = append(paragraphs, text)
+				}
+			}
+		}
+		p.comments[n.attr("id")] = &comment{
+			author: n.attr("author"),
+			text:   strings.Join(paragraphs, " "),
+		}
+	}
+	return nil
+}
+
+// plainText returns the text of all runs under n.
+func plainText(n *xmlNode) string {
+	var sb strings.Builder
+	var walk func(n *xmlNode)
+	walk = func(n *xmlNode) {
+		switch n.XMLName.Local {
+		case "t":
+			sb.WriteString(n.Text)
+			return
+		case "tab":
+			sb.WriteString("\t")
+			return
+		case "br", "cr":
+			sb.WriteString("\n")
+			return
+		}
+		for i := range n.Nodes {
+			walk(&n.Nodes[i])
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// block is a rendered top level element of the document body.
+type block struct {
+	text     string
+	heading  int      // heading level, 0 if the block is not a heading
+	title    string   // plain text of the heading
+	list     string   // num id of a list item, consecutive items of a list are separated by a single line break
+	comments []string // ids of comments referenced in the block
+}
+
+// renderPart renders a part such as word/document.xml or a header into Markdown blocks.
+func (p *docxPackage) renderPart(name string, includeTables, includeComments bool) ([]*block, error) {
+	root, err := p.part(name)
+	if err != nil || root == nil {
+		return nil, err
+	}
+
+	container := root
+	if body := root.child("body"); body != nil {
+		container = body
+	}
+
+	var blocks []*block
+	var walk func(nodes []xmlNode)
+	walk = func(nodes []xmlNode) {
+		for i := range nodes {
+			n := &nodes[i]
+			switch n.XMLName.Local {
+			case "p":
+				if b := p.renderParagraph(n, includeComments); b != nil {
+					blocks = append(blocks, b)
+				}
+			case "tbl":
+				if includeTables {
+					if b := p.renderTable(n, includeComments); b != nil {
+						blocks = append(blocks, b)
+					}
+				}
+			case "sdt":
+				if c := n.child("sdtContent"); c != nil {
+					walk(c.Nodes)
+				}
+			case "customXml", "ins", "smartTag":
+				walk(n.Nodes)
+			}
+		}
+	}
+	walk(container.Nodes)
+	return blocks, nil
+}
+
+func (p *docxPackage) renderParagraph(n *xmlNode, includeComments bool) *block {
+	var (
+		styleID  string
+		level    int
+		numID    string
+		numLevel int
+	)
+	if pPr := n.child("pPr"); pPr != nil {
+		if s := pPr.child("pStyle"); s != nil {
+			styleID = s.attr("val")
+		}
+		if l, ok := outlineLevel(pPr); ok {
+			level = l
+		}
+		if numPr := pPr.child("numPr"); numPr != nil {
+			if id := numPr.child("numId"); id != nil {
+				numID = id.attr("val")
+			}
+			if l := numPr.child("ilvl"); l != nil {
+				numLevel, _ = strconv.Atoi(l.attr("val"))
+			}
+		}
+	}
+	if level == 0 {
+		level = p.headingLevels[styleID]
+	}
+
+	b := &block{heading: level}
+	inline := &inlineWriter{}
+	p.renderInline(n.Nodes, inline, "", b, includeComments)
+
+	if level > 0 {
+		b.title = strings.Join(strings.Fields(inline.plain.String()), " ")
+		if b.title == "" {
+			return nil
+		}
+		b.text = strings.Repeat("#", min(level, 6)) + " " + b.title
+		for _, id := range b.comments {
+			b.text += "[^" + p.commentLabels[id] + "]"
+		}
+		return b
+	}
+
+	text := strings.TrimSpace(inline.markdown())
+	if text == "" {
+		return nil
+	}
+
+	if formats, ok := p.listFormats[numID]; ok && numID != "0" {
+		numLevel = max(0, min(numLevel, 8))
+		format := ""
+		if numLevel < len(formats) {
+			format = formats[numLevel]
+		}
+		marker := "- "
+		if format != "bullet" && format != "none" && format != "" {
+			marker = strconv.Itoa(p.nextNumber(numID, numLevel)) + ". "
+		}
+		b.text = strings.Repeat("    ", numLevel) + marker + strings.ReplaceAll(text, "\n", "\n"+strings.Repeat("    ", numLevel+1))
+		b.list = numID
+		return b
+	}
+
+	b.text = text
+	return b
+}
+
+// nextNumber returns the number of the next item of a list level, deeper levels are restarted.
+func (p *docxPackage) nextNumber(numID string, level int) int {
+	counters := p.counters[numID]
+	for len(counters) <= level {
+		counters = append(counters, 0)
+	}
+	counters[level]++
+	for i := level + 1; i < len(counters); i++ {
+		counters[i] = 0
+	}
+	p.counters[numID] = counters
+	return counters[level]
+}
+
+type segment struct {
+	text   string
+	bold   bool
+	italic bool
+	link   string
+	marker bool // footnote reference of a comment, not part of the plain text
+}
+
+// inlineWriter collects the runs of a paragraph, adjacent runs with the same format are merged.
+type inlineWriter struct {
+	segments []segment
+	plain    strings.Builder
+}
+
+func (w *inlineWriter) write(s segment) {
+	if !s.marker {
+		w.plain.WriteString(s.text)
+	}
+	if n := len(w.segments); n > 0 {
+		last := &w.segments[n-1]
+		if !last.marker && !s.marker && last.bold == s.bold && last.italic == s.italic && last.link == s.link {
+			last.text += s.text
+			return
+		}
+	}
+	w.segments = append(w.segments, s)
+}
+
+func (w *inlineWriter) markdown() string {
+	var sb strings.Builder
+	for _, s := range w.segments {
+		text := s.text
+		core := strings.TrimSpace(text)
+		if core == "" {
+			sb.WriteString(text)
+			continue
+		}
+		lead := text[:strings.Index(text, core)]
+		trail := text[len(lead)+len(core):]
+
+		switch {
+		case s.bold && s.italic:
+			core = "***" + core + "***"
+		case s.bold:
+			core = "**" + core + "**"
+		case s.italic:
+			core = "*" + core + "*"
+		}
+		if s.link != "" {
+			core = "[" + core + "](" + s.link + ")"
+		}
+		sb.WriteString(lead + core + trail)
+	}
+	return sb.String()
+}
+
+func (p *docxPackage) renderInline(nodes []xmlNode, w *inlineWriter, link string, b *block, includeComments bool) {
+	for i := range nodes {
+		n := &nodes[i]
+		switch n.XMLName.Local {
+		case "r":
+			var bold, italic bool
+			if rPr := n.child("rPr"); rPr != nil {
+				bold, italic = rPr.on("b"), rPr.on("i")
+			}
+			for j := range n.Nodes {
+				c := &n.Nodes[j]
+				switch c.XMLName.Local {
+				case "t":
+					w.write(segment{text: c.Text, bold: bold, italic: italic, link: link})
+				case "tab":
+					w.write(segment{text: "\t", link: link})
+				case "br", "cr":
+					w.write(segment{text: "\n"})
+				case "commentReference":
+					if includeComments {
+						if label := p.commentLabel(c.attr("id")); label != "" {
+							w.write(segment{text: "[^" + label + "]", marker: true})
+							b.comments = append(b.comments, c.attr("id"))
+						}
+					}
+				}
+			}
+		case "hyperlink":
+			target := p.links[n.attr("id")]
+			if target == "" && n.attr("anchor") != "" {
+				target = "#" + n.attr("anchor")
+			}
+			p.renderInline(n.Nodes, w, target, b, includeComments)
+		case "ins", "smartTag", "customXml", "fldSimple":
+			p.renderInline(n.Nodes, w, link, b, includeComments)
+		case "sdt":
+			if c := n.child("sdtContent"); c != nil {
+				p.renderInline(c.Nodes, w, link, b, includeComments)
+			}
+		}
+	}
+}
+
+func (p *docxPackage) commentLabel(id string) string {
+	if _, ok := p.comments[id]; !ok {
+		return ""
+	}
+	if label, ok := p.commentLabels[id]; ok {
+		return label
+	}
+	label := "comment-" + strconv.Itoa(len(p.commentOrder)+1)
+	p.commentLabels[id] = label
+	p.commentOrder = append(p.commentOrder, id)
+	return label
+}
+
+// commentNotes renders the referenced comments as Markdown footnotes.
+func (p *docxPackage) commentNotes(ids []string) string {
+	var lines []string
+	for _, id := range ids {
+		c := p.comments[id]
+		note := "[^" + p.commentLabels[id] + "]: "
+		if c.author != "" {
+			note += c.author + ": "
+		}
+		lines = append(lines, note+c.text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (p *docxPackage) renderTable(n *xmlNode, includeComments bool) *block {
+	b := &block{}
+	var rows [][]string
+	for i := range n.Nodes {
+		tr := &n.Nodes[i]
+		if tr.XMLName.Local != "tr" {
+			continue
+		}
+		var cells []string
+		for j := range tr.Nodes {
+			tc := &tr.Nodes[j]
+			if tc.XMLName.Local != "tc" {
+				continue
+			}
+			var paragraphs []string
+			for k := range tc.Nodes {
+				if tc.Nodes[k].XMLName.Local != "p" {
+					continue
+				}
+				w := &inlineWriter{}
+				p.renderInline(tc.Nodes[k].Nodes, w, "", b, includeComments)
+				if text := strings.TrimSpace(w.markdown()); text != "" {
+					paragraphs = append(paragraphs, text)
+				}
+			}
+			cell := strings.Join(paragraphs, "<br>")
+			cell = strings.ReplaceAll(strings.ReplaceAll(cell, "\n", "<br>"), "|", "\\|")
+			cells = append(cells, cell)
+		}
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	var sb strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("|")
+		for c := 0; c < cols; c++ {
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		if i == 0 {
+			sb.WriteString("\n|" + strings.Repeat(" --- |", cols))
+		}
+	}
+	b.text = sb.String()
+	return b
+}
+
+// joinBlocks joins rendered blocks into Markdown, and appends the footnotes of referenced comments.
+func (p *docxPackage) joinBlocks(blocks []*block) string {
+	var (
+		sb       strings.Builder
+		comments []string
+	)
+	for i, b := range blocks {
+		if i > 0 {
+			if b.list != "" && b.list == blocks[i-1].list {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(b.text)
+		comments = append(comments, b.comments...)
+	}
+	if len(comments) > 0 {
+		sb.WriteString("\n\n" + p.commentNotes(comments))
+	}
+	return sb.String()
+}
+
+// markdownSection is the content under a heading, headingPath contains the titles from the top level heading.
+type markdownSection struct {
+	headingPath []string
+	content     string
+}
+
+// splitByHeadings splits blocks into sections, every heading starts a new section.
+func (p *docxPackage) splitByHeadings(blocks []*block) []*markdownSection {
+	var (
+		sections []*markdownSection
+		stack    []*block
+		current  []*block
+		path     []string
+	)
+	flush := func() {
+		if len(current) > 0 {
+			sections = append(sections, &markdownSection{
+				headingPath: path,
+				content:     p.joinBlocks(current),
+			})
+		}
+		current = nil
+	}
+
+	for _, b := range blocks {
+		if b.heading > 0 {
+			flush()
+			for len(stack) > 0 && stack[len(stack)-1].heading >= b.heading {
+				stack = stack[:len(stack)-1]
+			}
+			stack = append(stack, b)
+			path = make([]string, 0, len(stack))
+			for _, h := range stack {
+				path = append(path, h.title)
+			}
+		}
+		current = append(current, b)
+	}
+	flush()
+	return sections
+}
+
+// plainComments renders all comments as plain text lines, used by the plain text mode.
+func (p *docxPackage) plainComments() string {
+	ids := make([]string, 0, len(p.comments))
+	for id := range p.comments {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return ids[i] < ids[j]
+	})
+
+	var lines []string
+	for _, id := range ids {
+		c := p.comments[id]
+		if c.text == "" {
+			continue
+		}
+		if c.author != "" {
+			lines = append(lines, c.author+": "+c.text)
+		} else {
+			lines = append(lines, c.text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
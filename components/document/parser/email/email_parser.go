@@ -0,0 +1,292 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyFrom       = "_from"        // sender address, e.g. "Alice <alice@example.com>"
+	MetaKeyTo         = "_to"          // []string of recipient addresses
+	MetaKeyCc         = "_cc"          // []string of carbon copy addresses
+	MetaKeySubject    = "_subject"     // decoded subject
+	MetaKeyDate       = "_date"        // time.Time of the Date header
+	MetaKeyMessageID  = "_message_id"  // Message-ID without angle brackets
+	MetaKeyInReplyTo  = "_in_reply_to" // In-Reply-To message id without angle brackets
+	MetaKeyReferences = "_references"  // []string of referenced message ids, oldest first
+	MetaKeyThreadID   = "_thread_id"   // id of the first message of the thread
+	MetaKeyAttachment = "_attachment"  // file name of the attachment a document is parsed from
+
+	// MetaKeyAttachmentError is the map[string]string of attachment file names to the errors of parsing them,
+	// recorded in the document of their message with ContinueOnError.
+	MetaKeyAttachmentError = "_attachment_error"
+)
+
+// Format is the file format of the parsed content.
+type Format string
+
+const (
+	// FormatEML is a single RFC 5322 message.
+	FormatEML Format = "eml"
+	// FormatMbox is a mailbox of messages, each starting with a "From " line.
+	FormatMbox Format = "mbox"
+)
+
+const maxNestingDepth = 8
+
+// Config is the configuration for email parser.
+type Config struct {
+	// Format is the format of the parsed content. If empty, it is detected by the extension of the uri
+	// (".mbox" and ".mbx" are mailboxes), and at last by whether the content starts with a "From " line.
+	Format Format
+	// PreferHTML uses the text/html body of a multipart/alternative message instead of the text/plain one.
+	// The html body is always converted to text.
+	PreferHTML bool
+	// AttachmentParser parses the attachments of messages, e.g. a parser.ExtParser choosing a parser by the
+	// extension of the attachment file name, which is passed by parser.WithURI.
+	// Documents of an attachment carry the metadata of its message, together with MetaKeyAttachment.
+	// Forwarded messages (message/rfc822) are parsed by the email parser itself, keeping their own headers.
+	// If nil, attachments and forwarded messages are ignored.
+	AttachmentParser parser.Parser
+	// MaxAttachmentSize skips attachments larger than it in bytes after decoding. 0 means no limit.
+	MaxAttachmentSize int
+	// ContinueOnError specifies whether to keep parsing when an attachment or a message of a mailbox fails.
+	// If true, a failed attachment is skipped and its error is recorded by MetaKeyAttachmentError in the document
+	// of its message if the message has a text body, and a message of a mailbox which fails to be parsed is skipped.
+	ContinueOnError bool
+}
+
+// EmailParser reads from io.Reader and parses email messages, either a single .eml message or a .mbox
+// mailbox, into one document per message. MIME bodies are decoded with their transfer encoding and charset,
+// text/plain bodies are preferred and text/html bodies are converted to text.
+type EmailParser struct {
+	format            Format
+	preferHTML        bool
+	attachmentParser  parser.Parser
+	maxAttachmentSize int
+	continueOnError   bool
+}
+
+// NewEmailParser creates a new email parser.
+func NewEmailParser(_ context.Context, config *Config) (*EmailParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	switch config.Format {
+	case "", FormatEML, FormatMbox:
+	default:
+		return nil, fmt.Errorf("unknown email format: %s", config.Format)
+	}
+	if config.MaxAttachmentSize < 0 {
+		return nil, fmt.Errorf("max attachment size must be non-negative: %d", config.MaxAttachmentSize)
+	}
+	return &EmailParser{
+		format:            config.Format,
+		preferHTML:        config.PreferHTML,
+		attachmentParser:  config.AttachmentParser,
+		maxAttachmentSize: config.MaxAttachmentSize,
+		continueOnError:   config.ContinueOnError,
+	}, nil
+}
+
+// Parse parses the messages from io.Reader into documents.
+func (ep *EmailParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("email parser failed to read content: %w", err)
+	}
+
+	format := ep.format
+	if format == "" {
+		format = detectFormat(commonOpts.URI, data)
+	}
+	var messages [][]byte
+	if format == FormatMbox {
+		messages = splitMbox(data)
+	} else {
+		messages = [][]byte{data}
+	}
+
+	var docs []*schema.Document
+	for i, raw := range messages {
+		msgDocs, err := ep.parseMessage(ctx, raw, commonOpts.ExtraMeta, 0)
+		if err != nil {
+			if format == FormatMbox {
+				if ep.continueOnError {
+					continue
+				}
+				return nil, fmt.Errorf("email parser failed to parse message %d: %w", i, err)
+			}
+			return nil, fmt.Errorf("email parser failed to parse message: %w", err)
+		}
+		docs = append(docs, msgDocs...)
+	}
+	return docs, nil
+}
+
+// parseMessage returns the document of the message followed by the documents of its attachments.
+func (ep *EmailParser) parseMessage(ctx context.Context, raw []byte, extraMeta map[string]any, depth int) ([]*schema.Document, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := make(map[string]any, len(extraMeta)+10)
+	for k, v := range extraMeta {
+		meta[k] = v
+	}
+	for k, v := range headerMeta(msg.Header) {
+		meta[k] = v
+	}
+
+	b := &bodyBuilder{preferHTML: ep.preferHTML}
+	if err = b.walk(part{header: textproto.MIMEHeader(msg.Header), body: msg.Body}); err != nil {
+		return nil, err
+	}
+
+	var (
+		docs      []*schema.Document
+		attErrors map[string]string
+	)
+	if content := strings.TrimSpace(b.text.String()); content != "" {
+		docs = append(docs, &schema.Document{
+			Content:  content,
+			MetaData: meta,
+		})
+	}
+	for _, a := range b.attachments {
+		attDocs, err := ep.parseAttachment(ctx, a, meta, depth)
+		if err != nil {
+			if !ep.continueOnError {
+				return nil, fmt.Errorf("parse attachment [%s] fail: %w", a.name, err)
+			}
+			if attErrors == nil {
+				attErrors = make(map[string]string)
+			}
+			attErrors[a.name] = err.Error()
+			continue
+		}
+		docs = append(docs, attDocs...)
+	}
+	// the documents of attachments have copied the metadata of the message, so the errors only go to the message
+	if attErrors != nil {
+		meta[MetaKeyAttachmentError] = attErrors
+	}
+	return docs, nil
+}
+
+func (ep *EmailParser) parseAttachment(ctx context.Context, a attachment, msgMeta map[string]any, depth int) ([]*schema.Document, error) {
+	if ep.attachmentParser == nil || (ep.maxAttachmentSize > 0 && len(a.data) > ep.maxAttachmentSize) {
+		return nil, nil
+	}
+
+	var (
+		docs []*schema.Document
+		err  error
+	)
+	if a.message {
+		if depth >= maxNestingDepth {
+			return nil, nil
+		}
+		docs, err = ep.parseMessage(ctx, a.data, nil, depth+1)
+	} else {
+		docs, err = ep.attachmentParser.Parse(ctx, bytes.NewReader(a.data), parser.WithURI(a.name))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, doc := range docs {
+		if doc.MetaData == nil {
+			doc.MetaData = make(map[string]any, len(msgMeta)+1)
+		}
+		for k, v := range msgMeta {
+			if _, ok := doc.MetaData[k]; !ok {
+				doc.MetaData[k] = v
+			}
+		}
+		if _, ok := doc.MetaData[MetaKeyAttachment]; !ok {
+			doc.MetaData[MetaKeyAttachment] = a.name
+		}
+	}
+	return docs, nil
+}
+
+func detectFormat(uri string, data []byte) Format {
+	switch strings.ToLower(filepath.Ext(uri)) {
+	case ".mbox", ".mbx":
+		return FormatMbox
+	case ".eml":
+		return FormatEML
+	}
+	if bytes.HasPrefix(data, []byte("From ")) {
+		return FormatMbox
+	}
+	return FormatEML
+}
+
+// splitMbox splits a mailbox into messages on the "From " separator lines, and unescapes the quoted
+// ">From " lines in message bodies.
+func splitMbox(data []byte) [][]byte {
+	var (
+		messages [][]byte
+		current  []byte
+		started  bool
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSuffix(scanner.Bytes(), []byte("\r"))
+		if bytes.HasPrefix(line, []byte("From ")) {
+			if started && len(bytes.TrimSpace(current)) > 0 {
+				messages = append(messages, current)
+			}
+			current = nil
+			started = true
+			continue
+		}
+		if !started {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			started = true
+		}
+		if unquoted := bytes.TrimLeft(line, ">"); len(unquoted) < len(line) && bytes.HasPrefix(unquoted, []byte("From ")) {
+			line = line[1:]
+		}
+		current = append(current, line...)
+		current = append(current, '\n')
+	}
+	if len(bytes.TrimSpace(current)) > 0 {
+		messages = append(messages, current)
+	}
+	return messages
+}
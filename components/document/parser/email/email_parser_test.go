@@ -0,0 +1,249 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const multipartMessage = "From: =?UTF-8?B?QmrDtnJu?= <bjorn@example.com>\r\n" +
+	"To: Support <support@example.com>, ops@example.com\r\n" +
+	"Cc: \"Lee, Ann\" <ann@example.com>\r\n" +
+	"Subject: =?ISO-8859-1?Q?Caf=E9_order_failed?=\r\n" +
+	"Date: Mon, 02 Jun 2025 10:04:05 +0200\r\n" +
+	"Message-ID: <m2@example.com>\r\n" +
+	"In-Reply-To: <m1@example.com>\r\n" +
+	"References: <m0@example.com> <m1@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"preamble\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"My caf=E9 order failed with a long line that is wrapped by the =\r\n" +
+	"encoder.\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"PHA+TXkgY2Fmw6kgb3JkZXIgPGI+ZmFpbGVkPC9iPjwvcD48dWw+PGxpPm9uZTwvbGk+PGxpPnR3\r\n" +
+	"bzwvbGk+PC91bD4=\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: text/plain; name=\"log.txt\"\r\n" +
+	"Content-Disposition: attachment; filename=\"log.txt\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"ZXJyb3I6IHRpbWVvdXQ=\r\n" +
+	"--outer\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBORw0KGgo=\r\n" +
+	"--outer\r\n" +
+	"Content-Type: message/rfc822\r\n" +
+	"\r\n" +
+	"From: ops@example.com\r\n" +
+	"Subject: Incident\r\n" +
+	"Message-ID: <fwd@example.com>\r\n" +
+	"\r\n" +
+	"The database is down.\r\n" +
+	"--outer--\r\n"
+
+type recordParser struct {
+	uris []string
+}
+
+func (r *recordParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	uri := parser.GetCommonOptions(nil, opts...).URI
+	r.uris = append(r.uris, uri)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.Document{{Content: string(data), MetaData: map[string]any{"parsed_by": "record"}}}, nil
+}
+
+// failParser fails every attachment.
+type failParser struct{}
+
+func (failParser) Parse(_ context.Context, _ io.Reader, _ ...parser.Option) ([]*schema.Document, error) {
+	return nil, errors.New("corrupt file")
+}
+
+func TestEmailParser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("multipart message", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(multipartMessage), parser.WithExtraMeta(map[string]any{"source": "tickets"}))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+
+		doc := docs[0]
+		assert.Equal(t, "My café order failed with a long line that is wrapped by the encoder.", doc.Content)
+		assert.Equal(t, "tickets", doc.MetaData["source"])
+		assert.Equal(t, "Björn <bjorn@example.com>", doc.MetaData[MetaKeyFrom])
+		assert.Equal(t, []string{"Support <support@example.com>", "ops@example.com"}, doc.MetaData[MetaKeyTo])
+		assert.Equal(t, []string{"Lee, Ann <ann@example.com>"}, doc.MetaData[MetaKeyCc])
+		assert.Equal(t, "Café order failed", doc.MetaData[MetaKeySubject])
+		assert.Equal(t, "m2@example.com", doc.MetaData[MetaKeyMessageID])
+		assert.Equal(t, "m1@example.com", doc.MetaData[MetaKeyInReplyTo])
+		assert.Equal(t, []string{"m0@example.com", "m1@example.com"}, doc.MetaData[MetaKeyReferences])
+		assert.Equal(t, "m0@example.com", doc.MetaData[MetaKeyThreadID])
+		date, ok := doc.MetaData[MetaKeyDate].(time.Time)
+		assert.True(t, ok)
+		assert.True(t, date.Equal(time.Date(2025, 6, 2, 8, 4, 5, 0, time.UTC)))
+	})
+
+	t.Run("prefer html", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, &Config{PreferHTML: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(multipartMessage))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "My café order failed\n\n- one\n- two", docs[0].Content)
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		rp := &recordParser{}
+		p, err := NewEmailParser(ctx, &Config{AttachmentParser: rp})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(multipartMessage))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 3)
+		assert.Equal(t, []string{"log.txt"}, rp.uris)
+
+		assert.Equal(t, "error: timeout", docs[1].Content)
+		assert.Equal(t, "log.txt", docs[1].MetaData[MetaKeyAttachment])
+		assert.Equal(t, "record", docs[1].MetaData["parsed_by"])
+		assert.Equal(t, "m2@example.com", docs[1].MetaData[MetaKeyMessageID])
+
+		assert.Equal(t, "The database is down.", docs[2].Content)
+		assert.Equal(t, "message.eml", docs[2].MetaData[MetaKeyAttachment])
+		assert.Equal(t, "fwd@example.com", docs[2].MetaData[MetaKeyMessageID])
+		assert.Equal(t, "Incident", docs[2].MetaData[MetaKeySubject])
+		assert.Equal(t, "fwd@example.com", docs[2].MetaData[MetaKeyThreadID])
+		assert.Equal(t, "ops@example.com", docs[2].MetaData[MetaKeyFrom])
+
+		p, err = NewEmailParser(ctx, &Config{AttachmentParser: rp, MaxAttachmentSize: 10})
+		assert.NoError(t, err)
+		docs, err = p.Parse(ctx, strings.NewReader(multipartMessage))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+	})
+
+	t.Run("html only", func(t *testing.T) {
+		msg := "Subject: html\n" +
+			"Content-Type: text/html; charset=windows-1252\n" +
+			"\n" +
+			"<html><head><title>t</title><style>p{}</style></head><body>" +
+			"<h1>Hello</h1><p>caf\xe9<br>second&nbsp;line</p><script>x()</script>" +
+			"<table><tr><td>a</td><td>b</td></tr></table></body></html>\n"
+		p, err := NewEmailParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(msg))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "Hello\n\ncafé\nsecond line\n\na b", docs[0].Content)
+	})
+
+	t.Run("mbox", func(t *testing.T) {
+		mbox := "From alice@example.com Mon Jun  2 10:00:00 2025\n" +
+			"From: alice@example.com\n" +
+			"Subject: first\n" +
+			"Message-ID: <a@example.com>\n" +
+			"\n" +
+			"Hello\n" +
+			">From the docs: it works.\n" +
+			"\n" +
+			"From bob@example.com Mon Jun  2 11:00:00 2025\n" +
+			"From: bob@example.com\n" +
+			"Subject: Re: first\n" +
+			"In-Reply-To: <a@example.com>\n" +
+			"\n" +
+			"Thanks\n"
+		p, err := NewEmailParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(mbox), parser.WithURI("support.mbox"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, "Hello\nFrom the docs: it works.", docs[0].Content)
+		assert.Equal(t, "a@example.com", docs[0].MetaData[MetaKeyThreadID])
+		assert.Equal(t, "Thanks", docs[1].Content)
+		assert.Equal(t, "Re: first", docs[1].MetaData[MetaKeySubject])
+		assert.Equal(t, "a@example.com", docs[1].MetaData[MetaKeyThreadID])
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, &Config{AttachmentParser: failParser{}})
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, strings.NewReader(multipartMessage))
+		assert.ErrorContains(t, err, "corrupt file")
+
+		p, err = NewEmailParser(ctx, &Config{AttachmentParser: failParser{}, ContinueOnError: true})
+		assert.NoError(t, err)
+		docs, err := p.Parse(ctx, strings.NewReader(multipartMessage))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, map[string]string{"log.txt": "corrupt file"}, docs[0].MetaData[MetaKeyAttachmentError])
+		assert.Equal(t, "The database is down.", docs[1].Content)
+		assert.NotContains(t, docs[1].MetaData, MetaKeyAttachmentError)
+
+		mbox := "From a@example.com Mon Jun  2 10:00:00 2025\n" +
+			"Subject: first\n\nHello\n" +
+			"From b@example.com Mon Jun  2 11:00:00 2025\n" +
+			"Content-Type: multipart/mixed\n\nbroken\n" +
+			"From c@example.com Mon Jun  2 12:00:00 2025\n" +
+			"Subject: third\n\nBye\n"
+		docs, err = p.Parse(ctx, strings.NewReader(mbox), parser.WithURI("support.mbox"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, "Hello", docs[0].Content)
+		assert.Equal(t, "Bye", docs[1].Content)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewEmailParser(ctx, &Config{Format: "pst"})
+		assert.Error(t, err)
+
+		p, err := NewEmailParser(ctx, &Config{Format: FormatEML})
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, strings.NewReader("Content-Type: multipart/mixed\n\nbody"))
+		assert.Error(t, err)
+	})
+}
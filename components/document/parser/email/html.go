@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlToText converts an html body to plain text. Block elements start new lines, list items are prefixed
+// with "- ", and scripts, styles and the head are dropped.
+func htmlToText(s string) string {
+	var (
+		sb   strings.Builder
+		skip int
+		pre  int
+	)
+	newline := func(n int) {
+		text := sb.String()
+		trailing := len(text) - len(strings.TrimRight(text, "\n"))
+		if len(text) == 0 || trailing >= n {
+			return
+		}
+		sb.WriteString(strings.Repeat("\n", n-trailing))
+	}
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return cleanText(sb.String())
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			text := string(z.Text())
+			if pre == 0 {
+				text = strings.Join(strings.Fields(text), " ")
+				if text == "" {
+					continue
+				}
+				if prev := sb.String(); len(prev) > 0 && !strings.HasSuffix(prev, "\n") && !strings.HasSuffix(prev, " ") {
+					sb.WriteByte(' ')
+				}
+			}
+			sb.WriteString(text)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch a := atom.Lookup(name); a {
+			case atom.Script, atom.Style, atom.Head, atom.Title, atom.Noscript:
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case atom.Br:
+				sb.WriteByte('\n')
+			case atom.Li:
+				newline(1)
+				sb.WriteString("- ")
+			case atom.Pre:
+				newline(2)
+				pre++
+			default:
+				if n := blockBreaks(a); n > 0 {
+					newline(n)
+				}
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch a := atom.Lookup(name); a {
+			case atom.Script, atom.Style, atom.Head, atom.Title, atom.Noscript:
+				if skip > 0 {
+					skip--
+				}
+			case atom.Pre:
+				if pre > 0 {
+					pre--
+				}
+				newline(2)
+			case atom.Td, atom.Th:
+				sb.WriteByte(' ')
+			default:
+				if n := blockBreaks(a); n > 0 {
+					newline(n)
+				}
+			}
+		}
+	}
+}
+
+// blockBreaks returns the number of line breaks around a block element, 0 for inline elements.
+func blockBreaks(a atom.Atom) int {
+	switch a {
+	case atom.P, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Blockquote, atom.Table, atom.Ul, atom.Ol, atom.Hr:
+		return 2
+	case atom.Div, atom.Tr, atom.Li, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Dl, atom.Dt, atom.Dd:
+		return 1
+	}
+	return 0
+}
+
+// cleanText trims the spaces of every line and collapses consecutive blank lines.
+func cleanText(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			if len(out) == 0 || out[len(out)-1] == "" {
+				continue
+			}
+			line = ""
+		} else if !strings.HasPrefix(line, "  ") {
+			line = strings.TrimLeft(line, " ")
+		}
+		out = append(out, line)
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
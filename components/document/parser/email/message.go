@@ -0,0 +1,310 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+var wordDecoder = &mime.WordDecoder{
+	CharsetReader: charset.NewReaderLabel,
+}
+
+var messageIDPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+
+// headerMeta returns the metadata of the sender, recipients, subject, date and thread of a message.
+func headerMeta(h mail.Header) map[string]any {
+	meta := make(map[string]any, 9)
+	if from := addressList(h, "From"); len(from) > 0 {
+		meta[MetaKeyFrom] = strings.Join(from, ", ")
+	}
+	if to := addressList(h, "To"); len(to) > 0 {
+		meta[MetaKeyTo] = to
+	}
+	if cc := addressList(h, "Cc"); len(cc) > 0 {
+		meta[MetaKeyCc] = cc
+	}
+	if subject := decodeHeader(h.Get("Subject")); subject != "" {
+		meta[MetaKeySubject] = subject
+	}
+	if date, err := h.Date(); err == nil {
+		meta[MetaKeyDate] = date
+	}
+
+	var messageID, inReplyTo string
+	if ids := messageIDs(h.Get("Message-Id")); len(ids) > 0 {
+		messageID = ids[0]
+		meta[MetaKeyMessageID] = messageID
+	}
+	if ids := messageIDs(h.Get("In-Reply-To")); len(ids) > 0 {
+		inReplyTo = ids[0]
+		meta[MetaKeyInReplyTo] = inReplyTo
+	}
+	references := messageIDs(h.Get("References"))
+	if len(references) > 0 {
+		meta[MetaKeyReferences] = references
+	}
+	switch {
+	case len(references) > 0:
+		meta[MetaKeyThreadID] = references[0]
+	case inReplyTo != "":
+		meta[MetaKeyThreadID] = inReplyTo
+	case messageID != "":
+		meta[MetaKeyThreadID] = messageID
+	}
+	return meta
+}
+
+func decodeHeader(v string) string {
+	decoded, err := wordDecoder.DecodeHeader(v)
+	if err != nil {
+		return strings.TrimSpace(v)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+// addressList returns the addresses of header key formatted as "Name <address>", or the decoded header value
+// if it is not a valid address list.
+func addressList(h mail.Header, key string) []string {
+	v := h.Get(key)
+	if strings.TrimSpace(v) == "" {
+		return nil
+	}
+	addrParser := mail.AddressParser{WordDecoder: wordDecoder}
+	list, err := addrParser.ParseList(v)
+	if err != nil {
+		return []string{decodeHeader(v)}
+	}
+	ret := make([]string, 0, len(list))
+	for _, addr := range list {
+		if addr.Name == "" {
+			ret = append(ret, addr.Address)
+		} else {
+			ret = append(ret, addr.Name+" <"+addr.Address+">")
+		}
+	}
+	return ret
+}
+
+// messageIDs returns the message ids in v without angle brackets.
+func messageIDs(v string) []string {
+	matches := messageIDPattern.FindAllStringSubmatch(v, -1)
+	if len(matches) == 0 {
+		return strings.Fields(v)
+	}
+	ids := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m[1])
+	}
+	return ids
+}
+
+type part struct {
+	header textproto.MIMEHeader
+	body   io.Reader
+}
+
+type attachment struct {
+	name string
+	data []byte
+	// message is true for forwarded messages.
+	message bool
+}
+
+// bodyBuilder walks the MIME tree of a message, collecting the text of body parts and the attachments.
+type bodyBuilder struct {
+	preferHTML  bool
+	text        strings.Builder
+	attachments []attachment
+}
+
+func (b *bodyBuilder) walk(p part) error {
+	mediaType, params, err := mime.ParseMediaType(p.header.Get("Content-Type"))
+	if err != nil {
+		// RFC 2045 default
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return b.walkMultipart(mediaType, params["boundary"], p.body)
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(p.header.Get("Content-Disposition"))
+	name := dispParams["filename"]
+	if name == "" {
+		name = params["name"]
+	}
+	name = decodeHeader(name)
+
+	data, err := io.ReadAll(decodeTransfer(p.header.Get("Content-Transfer-Encoding"), p.body))
+	if err != nil {
+		return fmt.Errorf("decode %s part fail: %w", mediaType, err)
+	}
+
+	switch {
+	case (mediaType == "text/plain" || mediaType == "text/html") && disposition != "attachment":
+		text := decodeCharset(data, params["charset"], mediaType)
+		if mediaType == "text/html" {
+			text = htmlToText(text)
+		}
+		b.appendText(text)
+	case mediaType == "message/rfc822":
+		if name == "" {
+			name = "message.eml"
+		}
+		b.attachments = append(b.attachments, attachment{name: name, data: data, message: true})
+	case name != "":
+		b.attachments = append(b.attachments, attachment{name: name, data: data})
+	}
+	return nil
+}
+
+func (b *bodyBuilder) walkMultipart(mediaType, boundary string, body io.Reader) error {
+	if boundary == "" {
+		return fmt.Errorf("%s part has no boundary", mediaType)
+	}
+	var parts []part
+	mr := multipart.NewReader(body, boundary)
+	for {
+		p, err := mr.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read %s part fail: %w", mediaType, err)
+		}
+		data, err := io.ReadAll(p)
+		if err != nil {
+			return fmt.Errorf("read %s part fail: %w", mediaType, err)
+		}
+		parts = append(parts, part{header: p.Header, body: bytes.NewReader(data)})
+	}
+
+	if mediaType == "multipart/alternative" && len(parts) > 0 {
+		return b.walk(b.chooseAlternative(parts))
+	}
+	for _, p := range parts {
+		if err := b.walk(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chooseAlternative returns the preferred text part, or a nested multipart part which may contain it,
+// or at last the first part.
+func (b *bodyBuilder) chooseAlternative(parts []part) part {
+	want := "text/plain"
+	if b.preferHTML {
+		want = "text/html"
+	}
+	var nested, text *part
+	for i := range parts {
+		mediaType, _, _ := mime.ParseMediaType(parts[i].header.Get("Content-Type"))
+		switch {
+		case mediaType == want:
+			return parts[i]
+		case strings.HasPrefix(mediaType, "multipart/") && nested == nil:
+			nested = &parts[i]
+		case strings.HasPrefix(mediaType, "text/") && text == nil:
+			text = &parts[i]
+		}
+	}
+	if nested != nil {
+		return *nested
+	}
+	if text != nil {
+		return *text
+	}
+	return parts[0]
+}
+
+func (b *bodyBuilder) appendText(text string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return
+	}
+	if b.text.Len() > 0 {
+		b.text.WriteString("\n\n")
+	}
+	b.text.WriteString(text)
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	case "base64":
+		// the decoder ignores line breaks, spaces and tabs are removed by spaceSkipper
+		return base64.NewDecoder(base64.StdEncoding, &spaceSkipper{r: r})
+	default:
+		return r
+	}
+}
+
+// spaceSkipper drops spaces and tabs, which some mailers put at the end of base64 lines.
+type spaceSkipper struct {
+	r io.Reader
+}
+
+func (s *spaceSkipper) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	j := 0
+	for _, c := range p[:n] {
+		if c != ' ' && c != '\t' {
+			p[j] = c
+			j++
+		}
+	}
+	return j, err
+}
+
+// decodeCharset converts data in label charset to utf-8. The charset of html without a label is detected
+// by its meta tags, and invalid utf-8 sequences are replaced.
+func decodeCharset(data []byte, label, mediaType string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+	switch {
+	case label == "" && mediaType == "text/html":
+		enc, _, _ := charset.DetermineEncoding(data, mediaType)
+		if out, err := enc.NewDecoder().Bytes(data); err == nil {
+			data = out
+		}
+	case label != "" && label != "utf-8" && label != "us-ascii":
+		if r, err := charset.NewReaderLabel(label, bytes.NewReader(data)); err == nil {
+			if out, err := io.ReadAll(r); err == nil {
+				data = out
+			}
+		}
+	}
+	if !utf8.Valid(data) {
+		return strings.ToValidUTF8(string(data), "�")
+	}
+	return string(data)
+}
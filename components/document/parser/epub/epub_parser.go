@@ -0,0 +1,263 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyChapter      = "_chapter"       // chapter number starting from 1, in reading order
+	MetaKeyChapterTitle = "_chapter_title" // title of the chapter in the table of contents, or its first heading
+	MetaKeyTOCPath      = "_toc_path"      // []string, titles from the top level entry of the table of contents to the chapter
+	MetaKeyHref         = "_href"          // path of the chapter file in the epub package
+	MetaKeyTitle        = "_title"         // title of the publication
+	MetaKeyAuthor       = "_author"        // first creator of the publication
+	MetaKeyLanguage     = "_language"      // language of the publication
+)
+
+// Config is the configuration for EPUB parser.
+type Config struct {
+	// SkipNonLinear skips the auxiliary content marked as non-linear in the spine, e.g. answers and notes.
+	SkipNonLinear bool
+}
+
+// EPUBParser reads from io.Reader and parses every chapter of an EPUB 2 or EPUB 3 e-book into a document.
+// A chapter is a content document of the spine, rendered as text with Markdown headings and lists.
+// Chapters split into several files by the authoring tool share the TOC path of the file they continue.
+type EPUBParser struct {
+	skipNonLinear bool
+}
+
+// NewEPUBParser creates a new EPUB parser.
+func NewEPUBParser(_ context.Context, config *Config) (*EPUBParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	return &EPUBParser{skipNonLinear: config.SkipNonLinear}, nil
+}
+
+// Parse parses the EPUB content from io.Reader, chapters without text are skipped.
+func (ep *EPUBParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("epub parser failed to read content: %w", err)
+	}
+	pkg, err := openEPUBPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := pkg.publication()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := pkg.toc(pub)
+	if err != nil {
+		return nil, err
+	}
+	tocPaths := make(map[string][]string, len(entries))
+	for _, entry := range entries {
+		if _, ok := tocPaths[entry.name]; !ok {
+			tocPaths[entry.name] = entry.path
+		}
+	}
+
+	var tocPath []string
+	for _, ref := range pub.spine {
+		item := pub.manifest[ref.idref]
+		if item == nil || (ep.skipNonLinear && !ref.linear) {
+			continue
+		}
+		if item.mediaType != "application/xhtml+xml" && item.mediaType != "text/html" {
+			continue
+		}
+		if p, ok := tocPaths[item.name]; ok {
+			tocPath = p
+		}
+
+		doc, err := pkg.parse(item.name)
+		if err != nil {
+			return nil, err
+		}
+		r := &renderer{}
+		if body := doc.find(func(n *node) bool { return n.name == "body" }); body != nil {
+			r.render(body)
+		} else {
+			r.render(doc)
+		}
+		content := r.String()
+		if content == "" {
+			continue
+		}
+
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+7)
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyChapter] = len(docs) + 1
+		meta[MetaKeyHref] = item.name
+		if len(tocPath) > 0 {
+			meta[MetaKeyTOCPath] = slices.Clone(tocPath)
+			meta[MetaKeyChapterTitle] = tocPath[len(tocPath)-1]
+		} else if r.firstHeading != "" {
+			meta[MetaKeyChapterTitle] = r.firstHeading
+		}
+		if pub.title != "" {
+			meta[MetaKeyTitle] = pub.title
+		}
+		if pub.author != "" {
+			meta[MetaKeyAuthor] = pub.author
+		}
+		if pub.language != "" {
+			meta[MetaKeyLanguage] = pub.language
+		}
+		docs = append(docs, &schema.Document{
+			Content:  content,
+			MetaData: meta,
+		})
+	}
+	return docs, nil
+}
+
+// GetTOCPath returns the TOC path of a chapter.
+func GetTOCPath(doc *schema.Document) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	tocPath, ok := doc.MetaData[MetaKeyTOCPath].([]string)
+	return tocPath, ok
+}
+
+var (
+	skippedElements = map[string]bool{"head": true, "script": true, "style": true, "svg": true, "math": true}
+	blockElements   = map[string]bool{
+		"p": true, "div": true, "section": true, "article": true, "aside": true, "header": true, "footer": true,
+		"blockquote": true, "pre": true, "table": true, "tr": true, "ul": true, "ol": true, "dl": true, "dt": true,
+		"dd": true, "figure": true, "figcaption": true, "hr": true, "nav": true, "li": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	}
+)
+
+// renderer renders an XHTML content document as text, blocks are separated by blank lines,
+// headings are prefixed by '#' and list items by "- ".
+type renderer struct {
+	blocks       []string
+	tight        []bool // whether a block follows the previous one without a blank line, e.g. list items
+	cur          strings.Builder
+	prefix       string
+	tightBlock   bool
+	pre          int
+	firstHeading string
+}
+
+// flush ends the current block, the prefix is kept for the next block if there is no text,
+// e.g. a paragraph in a list item.
+func (r *renderer) flush() {
+	text := r.cur.String()
+	r.cur.Reset()
+	if r.pre == 0 {
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			lines[i] = strings.Join(strings.Fields(line), " ")
+		}
+		text = strings.Join(lines, "\n")
+	}
+	if text = strings.Trim(text, "\n"); strings.TrimSpace(text) == "" {
+		return
+	}
+	r.blocks = append(r.blocks, r.prefix+text)
+	r.tight = append(r.tight, r.tightBlock)
+	r.prefix, r.tightBlock = "", false
+}
+
+func (r *renderer) render(n *node) {
+	if n.name == "" {
+		text := n.text
+		if r.pre == 0 {
+			// line breaks in the source are spaces, runs of spaces are collapsed when the block is flushed
+			text = strings.Map(func(c rune) rune {
+				if c == '\n' || c == '\r' || c == '\t' {
+					return ' '
+				}
+				return c
+			}, text)
+		}
+		r.cur.WriteString(text)
+		return
+	}
+	if skippedElements[n.name] {
+		return
+	}
+	switch n.name {
+	case "br":
+		r.cur.WriteString("\n")
+		return
+	case "td", "th":
+		r.cur.WriteString(" ")
+	}
+
+	block := blockElements[n.name]
+	if block {
+		r.flush()
+	}
+	switch n.name {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		r.prefix = strings.Repeat("#", int(n.name[1]-'0')) + " "
+		if r.firstHeading == "" {
+			r.firstHeading = n.textContent()
+		}
+	case "li":
+		r.prefix, r.tightBlock = "- ", true
+	case "tr":
+		r.tightBlock = true
+	case "pre":
+		r.pre++
+		defer func() { r.pre-- }()
+	}
+	for _, c := range n.children {
+		r.render(c)
+	}
+	if block {
+		r.flush()
+		r.prefix, r.tightBlock = "", false
+	}
+}
+
+func (r *renderer) String() string {
+	r.flush()
+	var sb strings.Builder
+	for i, block := range r.blocks {
+		if i > 0 {
+			if r.tight[i] && r.tight[i-1] {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(block)
+	}
+	return sb.String()
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document/parser"
+)
+
+func buildZip(t *testing.T, parts map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func xhtml(body string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?><html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">` +
+		`<head><title>ignored</title><style>p { color: red; }</style></head><body>` + body + `</body></html>`
+}
+
+// buildEPUB builds an epub with the navigation document if nav, otherwise with the NCX.
+func buildEPUB(t *testing.T, nav bool) []byte {
+	manifest := `<item id="c1" href="Text/ch1.xhtml" media-type="application/xhtml+xml"/>` +
+		`<item id="c1b" href="Text/ch1b.xhtml" media-type="application/xhtml+xml"/>` +
+		`<item id="c2" href="Text/chapter%202.xhtml" media-type="application/xhtml+xml"/>` +
+		`<item id="notes" href="Text/notes.xhtml" media-type="application/xhtml+xml"/>` +
+		`<item id="cover" href="Images/cover.jpg" media-type="image/jpeg"/>`
+	if nav {
+		manifest += `<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>`
+	} else {
+		manifest += `<item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>`
+	}
+	parts := map[string]string{
+		"mimetype": "application/epub+zip",
+		"META-INF/container.xml": `<?xml version="1.0"?><container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">` +
+			`<rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles></container>`,
+		"OEBPS/content.opf": `<?xml version="1.0"?><package xmlns="http://www.idpf.org/2007/opf" version="3.0">` +
+			`<metadata xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>The Book</dc:title><dc:creator>Jane Doe</dc:creator>` +
+			`<dc:creator>John Doe</dc:creator><dc:language>en</dc:language></metadata>` +
+			`<manifest>` + manifest + `</manifest>` +
+			`<spine toc="ncx"><itemref idref="c1"/><itemref idref="c1b"/><itemref idref="c2"/><itemref idref="notes" linear="no"/></spine></package>`,
+		"OEBPS/Text/ch1.xhtml": xhtml(`<h1>Chapter <em>One</em></h1><p>It was a   dark
+			and <b>stormy</b> night.</p><ul><li><p>first</p></li><li>second</li></ul>`),
+		"OEBPS/Text/ch1b.xhtml": xhtml(`<p>Continued&nbsp;text.<br/>New line.</p><pre>code
+  indented</pre>`),
+		"OEBPS/Text/chapter 2.xhtml": xhtml(`<section><h2>Two</h2><table><tr><td>a</td><td>b</td></tr><tr><td>c</td><td>d</td></tr></table></section>`),
+		"OEBPS/Text/notes.xhtml":     xhtml(`<h1>Notes</h1><p>A note.</p>`),
+		"OEBPS/nav.xhtml": xhtml(`<nav epub:type="landmarks"><ol><li><a href="Text/notes.xhtml">Notes</a></li></ol></nav>` +
+			`<nav epub:type="toc"><ol><li><span>Part I</span><ol>` +
+			`<li><a href="Text/ch1.xhtml">Chapter One</a><ol><li><a href="Text/ch1.xhtml#s1">Section</a></li></ol></li>` +
+			`<li><a href="Text/chapter%202.xhtml#top">Chapter Two</a></li></ol></li></ol></nav>`),
+		"OEBPS/toc.ncx": `<?xml version="1.0"?><ncx xmlns="http://www.daisy.org/z3986/2005/ncx/"><navMap>` +
+			`<navPoint id="p1"><navLabel><text>Part I</text></navLabel><content src="Text/ch1.xhtml"/>` +
+			`<navPoint id="p2"><navLabel><text>Chapter One</text></navLabel><content src="Text/ch1.xhtml"/></navPoint>` +
+			`<navPoint id="p3"><navLabel><text>Chapter Two</text></navLabel><content src="Text/chapter%202.xhtml"/></navPoint>` +
+			`</navPoint></navMap></ncx>`,
+	}
+	return buildZip(t, parts)
+}
+
+func TestEPUBParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewEPUBParser(ctx, nil)
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(ctx, bytes.NewReader(buildEPUB(t, true)), parser.WithExtraMeta(map[string]any{"test": "test"}))
+	assert.NoError(t, err)
+	if assert.Equal(t, 4, len(docs)) {
+		assert.Equal(t, "# Chapter One\n\nIt was a dark and stormy night.\n\n- first\n- second", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			"test":              "test",
+			MetaKeyChapter:      1,
+			MetaKeyChapterTitle: "Chapter One",
+			MetaKeyTOCPath:      []string{"Part I", "Chapter One"},
+			MetaKeyHref:         "OEBPS/Text/ch1.xhtml",
+			MetaKeyTitle:        "The Book",
+			MetaKeyAuthor:       "Jane Doe",
+			MetaKeyLanguage:     "en",
+		}, docs[0].MetaData)
+
+		assert.Equal(t, "Continued text.\nNew line.\n\ncode\n  indented", docs[1].Content)
+		tocPath, _ := GetTOCPath(docs[1])
+		assert.Equal(t, []string{"Part I", "Chapter One"}, tocPath)
+
+		assert.Equal(t, "## Two\n\na b\nc d", docs[2].Content)
+		assert.Equal(t, []string{"Part I", "Chapter Two"}, docs[2].MetaData[MetaKeyTOCPath])
+		assert.Equal(t, "OEBPS/Text/chapter 2.xhtml", docs[2].MetaData[MetaKeyHref])
+
+		// the non-linear notes are not in the toc, and continue the toc path of the previous chapter
+		assert.Equal(t, "# Notes\n\nA note.", docs[3].Content)
+		assert.Equal(t, 4, docs[3].MetaData[MetaKeyChapter])
+	}
+
+	p, err = NewEPUBParser(ctx, &Config{SkipNonLinear: true})
+	assert.NoError(t, err)
+	docs, err = p.Parse(ctx, bytes.NewReader(buildEPUB(t, false)))
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(docs)) {
+		assert.Equal(t, []string{"Part I"}, docs[0].MetaData[MetaKeyTOCPath])
+		assert.Equal(t, "Part I", docs[0].MetaData[MetaKeyChapterTitle])
+		assert.Equal(t, []string{"Part I", "Chapter Two"}, docs[2].MetaData[MetaKeyTOCPath])
+	}
+}
+
+func TestEPUBParser_Invalid(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewEPUBParser(ctx, nil)
+	assert.NoError(t, err)
+
+	_, err = p.Parse(ctx, bytes.NewReader([]byte("not a zip")))
+	assert.Error(t, err)
+	_, err = p.Parse(ctx, bytes.NewReader(buildZip(t, map[string]string{"mimetype": "application/epub+zip"})))
+	assert.ErrorContains(t, err, "META-INF/container.xml")
+}
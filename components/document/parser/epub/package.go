@@ -0,0 +1,267 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// node is an element or a text node of an XML or XHTML document, children are kept in document order.
+type node struct {
+	name     string // local name, empty for text nodes
+	attrs    []xml.Attr
+	text     string
+	children []*node
+}
+
+func (n *node) attr(local string) string {
+	for _, a := range n.attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n *node) child(local string) *node {
+	for _, c := range n.children {
+		if c.name == local {
+			return c
+		}
+	}
+	return nil
+}
+
+// find returns the first descendant in document order for which match returns true.
+func (n *node) find(match func(*node) bool) *node {
+	for _, c := range n.children {
+		if c.name == "" {
+			continue
+		}
+		if match(c) {
+			return c
+		}
+		if found := c.find(match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// textContent returns the text in n with whitespaces collapsed.
+func (n *node) textContent() string {
+	var sb strings.Builder
+	var walk func(*node)
+	walk = func(n *node) {
+		sb.WriteString(n.text)
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// parseXML parses an XML document, XHTML documents are parsed leniently with HTML entities.
+func parseXML(data []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	root := &node{}
+	stack := []*node{root}
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name.Local, attrs: t.Attr}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			top.children = append(top.children, &node{text: string(t)})
+		}
+	}
+	return root, nil
+}
+
+// epubPackage holds the files of an epub file.
+type epubPackage struct {
+	files map[string]*zip.File
+}
+
+func openEPUBPackage(data []byte) (*epubPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open epub package failed: %w", err)
+	}
+	pkg := &epubPackage{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		pkg.files[f.Name] = f
+	}
+	return pkg, nil
+}
+
+// read returns the content of a file in the package.
+func (p *epubPackage) read(name string) ([]byte, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, fmt.Errorf("epub file [%s] not found", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open epub file [%s] failed: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read epub file [%s] failed: %w", name, err)
+	}
+	return data, nil
+}
+
+// parse reads and parses a file in the package.
+func (p *epubPackage) parse(name string) (*node, error) {
+	data, err := p.read(name)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode epub file [%s] failed: %w", name, err)
+	}
+	return root, nil
+}
+
+// resolve resolves an href relative to the file base to a file name in the package, the fragment is dropped.
+func resolve(base, href string) string {
+	if i := strings.IndexByte(href, '#'); i >= 0 {
+		href = href[:i]
+	}
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	if href == "" {
+		return base
+	}
+	return path.Join(path.Dir(base), href)
+}
+
+// manifestItem is a publication resource declared in the package document.
+type manifestItem struct {
+	name       string // file name in the package
+	mediaType  string
+	properties string
+}
+
+// publication is the package document (OPF) of an epub.
+type publication struct {
+	title    string
+	author   string
+	language string
+	manifest map[string]*manifestItem // id -> item
+	spine    []spineItem
+	toc      string // id of the NCX item
+}
+
+type spineItem struct {
+	idref  string
+	linear bool
+}
+
+// publication reads the package document referenced by META-INF/container.xml.
+func (p *epubPackage) publication() (*publication, error) {
+	container, err := p.parse("META-INF/container.xml")
+	if err != nil {
+		return nil, err
+	}
+	rootfile := container.find(func(n *node) bool { return n.name == "rootfile" })
+	if rootfile == nil || rootfile.attr("full-path") == "" {
+		return nil, fmt.Errorf("epub rootfile not found in META-INF/container.xml")
+	}
+	name := rootfile.attr("full-path")
+	opf, err := p.parse(name)
+	if err != nil {
+		return nil, err
+	}
+	pkg := opf.child("package")
+	if pkg == nil {
+		return nil, fmt.Errorf("epub package document [%s] is invalid", name)
+	}
+
+	pub := &publication{manifest: make(map[string]*manifestItem)}
+	if metadata := pkg.child("metadata"); metadata != nil {
+		for _, m := range metadata.children {
+			var target *string
+			switch m.name {
+			case "title":
+				target = &pub.title
+			case "creator":
+				target = &pub.author
+			case "language":
+				target = &pub.language
+			default:
+				continue
+			}
+			if *target == "" {
+				*target = m.textContent()
+			}
+		}
+	}
+	if manifest := pkg.child("manifest"); manifest != nil {
+		for _, item := range manifest.children {
+			if item.name != "item" {
+				continue
+			}
+			pub.manifest[item.attr("id")] = &manifestItem{
+				name:       resolve(name, item.attr("href")),
+				mediaType:  item.attr("media-type"),
+				properties: item.attr("properties"),
+			}
+		}
+	}
+	if spine := pkg.child("spine"); spine != nil {
+		pub.toc = spine.attr("toc")
+		for _, ref := range spine.children {
+			if ref.name != "itemref" {
+				continue
+			}
+			pub.spine = append(pub.spine, spineItem{idref: ref.attr("idref"), linear: ref.attr("linear") != "no"})
+		}
+	}
+	return pub, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"slices"
+	"strings"
+)
+
+// tocEntry is an entry of the table of contents, path is the labels from the top level entry to this one.
+type tocEntry struct {
+	name string // file name the entry points to
+	path []string
+}
+
+// toc reads the table of contents in document order, from the EPUB 3 navigation document if present,
+// otherwise from the EPUB 2 NCX. A publication without table of contents returns nil.
+func (p *epubPackage) toc(pub *publication) ([]tocEntry, error) {
+	for _, item := range pub.manifest {
+		if slices.Contains(strings.Fields(item.properties), "nav") {
+			return p.navTOC(item.name)
+		}
+	}
+	ncx := pub.manifest[pub.toc]
+	if ncx == nil {
+		for _, item := range pub.manifest {
+			if item.mediaType == "application/x-dtbncx+xml" {
+				ncx = item
+				break
+			}
+		}
+	}
+	if ncx == nil {
+		return nil, nil
+	}
+	return p.ncxTOC(ncx.name)
+}
+
+func (p *epubPackage) navTOC(name string) ([]tocEntry, error) {
+	doc, err := p.parse(name)
+	if err != nil {
+		return nil, err
+	}
+	nav := doc.find(func(n *node) bool {
+		return n.name == "nav" && slices.Contains(strings.Fields(n.attr("type")), "toc")
+	})
+	if nav == nil {
+		nav = doc.find(func(n *node) bool { return n.name == "nav" })
+	}
+	if nav == nil {
+		return nil, nil
+	}
+	ol := nav.find(func(n *node) bool { return n.name == "ol" })
+	if ol == nil {
+		return nil, nil
+	}
+
+	var entries []tocEntry
+	var walk func(ol *node, parents []string)
+	walk = func(ol *node, parents []string) {
+		for _, li := range ol.children {
+			if li.name != "li" {
+				continue
+			}
+			label := li.child("a")
+			if label == nil {
+				label = li.child("span")
+			}
+			current := parents
+			if label != nil {
+				current = append(slices.Clip(parents), label.textContent())
+				if href := label.attr("href"); label.name == "a" && href != "" {
+					entries = append(entries, tocEntry{name: resolve(name, href), path: current})
+				}
+			}
+			if nested := li.child("ol"); nested != nil {
+				walk(nested, current)
+			}
+		}
+	}
+	walk(ol, nil)
+	return entries, nil
+}
+
+func (p *epubPackage) ncxTOC(name string) ([]tocEntry, error) {
+	doc, err := p.parse(name)
+	if err != nil {
+		return nil, err
+	}
+	navMap := doc.find(func(n *node) bool { return n.name == "navMap" })
+	if navMap == nil {
+		return nil, nil
+	}
+
+	var entries []tocEntry
+	var walk func(parent *node, parents []string)
+	walk = func(parent *node, parents []string) {
+		for _, point := range parent.children {
+			if point.name != "navPoint" {
+				continue
+			}
+			var label string
+			if navLabel := point.child("navLabel"); navLabel != nil {
+				label = navLabel.textContent()
+			}
+			current := append(slices.Clip(parents), label)
+			if content := point.child("content"); content != nil && content.attr("src") != "" {
+				entries = append(entries, tocEntry{name: resolve(name, content.attr("src")), path: current})
+			}
+			walk(point, current)
+		}
+	}
+	walk(navMap, nil)
+	return entries, nil
+}
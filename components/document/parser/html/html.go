@@ -18,8 +18,11 @@ package html
 
 import (
 	"context"
+	"encoding/json"
 	"io"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/microcosm-cc/bluemonday"
@@ -34,6 +37,14 @@ const (
 	MetaKeyLang    = "_language"
 	MetaKeyCharset = "_charset"
 	MetaKeySource  = "_source"
+	// MetaKeyCanonical is the canonical url of the page, from <link rel="canonical"> or og:url.
+	MetaKeyCanonical = "_canonical_url"
+	// MetaKeyOpenGraph is the Open Graph properties of the page, a map[string]string keyed by the property name
+	// without the "og:" prefix, e.g. "title", "image", "type".
+	MetaKeyOpenGraph = "_open_graph"
+	// MetaKeyPublishedTime is the published time of the page as time.Time, from article:published_time,
+	// date meta tags, JSON-LD datePublished or <time datetime>.
+	MetaKeyPublishedTime = "_published_time"
 )
 
 var _ parser.Parser = (*Parser)(nil)
@@ -41,6 +52,12 @@ var _ parser.Parser = (*Parser)(nil)
 type Config struct {
 	// content selector of goquery. eg: body for <body>, #id for <div id="id">
 	Selector *string
+	// ExtractContent enables the content extraction mode, which isolates the article body by scoring the DOM nodes,
+	// removes boilerplate like navigation, footers, ads and scripts, and converts the result to Markdown,
+	// keeping headings, lists, code blocks, tables and links. Relative links are resolved against the
+	// canonical url or the uri of the source.
+	// If Selector is set, the content is only searched within the first selected node.
+	ExtractContent bool
 }
 
 var (
@@ -73,14 +90,6 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 
 	option := parser.GetCommonOptions(&parser.Options{}, opts...)
 
-	var contentSel *goquery.Selection
-
-	if p.conf.Selector != nil {
-		contentSel = doc.Find(*p.conf.Selector).Contents()
-	} else {
-		contentSel = doc.Contents()
-	}
-
 	meta, err := p.getMetaData(ctx, doc)
 	if err != nil {
 		return nil, err
@@ -93,8 +102,21 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 		}
 	}
 
-	sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
-	content := strings.TrimSpace(sanitized)
+	var content string
+	if p.conf.ExtractContent {
+		content = p.extract(doc, meta, option.URI)
+	} else {
+		var contentSel *goquery.Selection
+
+		if p.conf.Selector != nil {
+			contentSel = doc.Find(*p.conf.Selector).Contents()
+		} else {
+			contentSel = doc.Contents()
+		}
+
+		sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
+		content = strings.TrimSpace(sanitized)
+	}
 
 	document := &schema.Document{
 		Content:  content,
@@ -106,6 +128,36 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 	}, nil
 }
 
+// extract returns the main content of doc as Markdown.
+func (p *Parser) extract(doc *goquery.Document, meta map[string]any, uri string) string {
+	var root *goquery.Selection
+	if p.conf.Selector != nil {
+		root = doc.Find(*p.conf.Selector).First()
+		if root.Length() == 0 {
+			return ""
+		}
+	}
+
+	var base *url.URL
+	for _, ref := range []string{uri, stringOf(meta[MetaKeyCanonical])} {
+		if u, err := url.Parse(ref); err == nil && u.IsAbs() {
+			base = u
+		}
+	}
+	if href, ok := doc.Find("base[href]").Attr("href"); ok {
+		if u, err := url.Parse(href); err == nil {
+			if base != nil {
+				u = base.ResolveReference(u)
+			}
+			if u.IsAbs() {
+				base = u
+			}
+		}
+	}
+
+	return toMarkdown(extractContent(doc, root), base)
+}
+
 func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document) (map[string]any, error) {
 	meta := map[string]any{}
 
@@ -137,5 +189,116 @@ func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document) (map[st
 		}
 	}
 
+	openGraph := map[string]string{}
+	doc.Find("meta[property^='og:']").Each(func(_ int, s *goquery.Selection) {
+		key := strings.TrimPrefix(s.AttrOr("property", ""), "og:")
+		if _, ok := openGraph[key]; ok || key == "" {
+			return
+		}
+		if value := strings.TrimSpace(s.AttrOr("content", "")); value != "" {
+			openGraph[key] = value
+		}
+	})
+	if len(openGraph) > 0 {
+		meta[MetaKeyOpenGraph] = openGraph
+	}
+
+	if canonical := strings.TrimSpace(doc.Find("link[rel=canonical]").AttrOr("href", "")); canonical != "" {
+		meta[MetaKeyCanonical] = canonical
+	} else if ogURL := openGraph["url"]; ogURL != "" {
+		meta[MetaKeyCanonical] = ogURL
+	}
+
+	if _, ok := meta[MetaKeyLang]; !ok {
+		contentLanguage := doc.Find("meta[http-equiv]").FilterFunction(func(_ int, s *goquery.Selection) bool {
+			return strings.EqualFold(s.AttrOr("http-equiv", ""), "content-language")
+		})
+		if language := contentLanguage.AttrOr("content", ""); language != "" {
+			meta[MetaKeyLang] = language
+		} else if locale := openGraph["locale"]; locale != "" {
+			meta[MetaKeyLang] = strings.ReplaceAll(locale, "_", "-")
+		}
+	}
+
+	if published, ok := publishedTime(doc); ok {
+		meta[MetaKeyPublishedTime] = published
+	}
+
 	return meta, nil
 }
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+func publishedTime(doc *goquery.Document) (time.Time, bool) {
+	var candidates []string
+	for _, sel := range []string{
+		"meta[property='article:published_time']",
+		"meta[name='article:published_time']",
+		"meta[itemprop=datePublished]",
+		"meta[name=pubdate]",
+		"meta[name=publishdate]",
+		"meta[name=date]",
+		"meta[name='dc.date']",
+		"meta[name='DC.date.issued']",
+	} {
+		if value := doc.Find(sel).AttrOr("content", ""); value != "" {
+			candidates = append(candidates, value)
+		}
+	}
+	doc.Find("script[type='application/ld+json']").Each(func(_ int, s *goquery.Selection) {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err == nil {
+			if value := findJSONField(data, "datePublished"); value != "" {
+				candidates = append(candidates, value)
+			}
+		}
+	})
+	if value := doc.Find("time[pubdate][datetime], [itemprop=datePublished][datetime], article time[datetime]").AttrOr("datetime", ""); value != "" {
+		candidates = append(candidates, value)
+	}
+
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, candidate); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// findJSONField returns the first string value of key in the decoded JSON data, searched depth first.
+func findJSONField(data any, key string) string {
+	switch v := data.(type) {
+	case map[string]any:
+		if value, ok := v[key].(string); ok {
+			return value
+		}
+		for _, child := range v {
+			if value := findJSONField(child, key); value != "" {
+				return value
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if value := findJSONField(child, key); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+func stringOf(v any) string {
+	s, _ := v.(string)
+	return s
+}
@@ -19,7 +19,9 @@ package html
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
@@ -84,3 +86,79 @@ func TestHTMLParser(t *testing.T) {
 	})
 
 }
+
+func TestHTMLParser_ExtractContent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("article", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{ExtractContent: true})
+		assert.NoError(t, err)
+
+		f, err := os.Open("testdata/article.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("https://example.com/blog/goroutines?from=home"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+
+		expected := "# Understanding Goroutines\n\n" +
+			"Goroutines are lightweight threads managed by the Go runtime, they are cheap to create, and thousands of them can run at the same time.\n\n" +
+			"## Starting a goroutine\n\n" +
+			"Prefix a function call with the `go` keyword, and the call runs **concurrently** with the caller. " +
+			"See the [language spec](https://example.com/docs/spec#Go_statements) for details.\n\n" +
+			"```go\nfunc main() {\n\tgo say(\"hello\")\n\tsay(\"world\")\n}\n```\n\n" +
+			"## Things to remember\n\n" +
+			"- The main function does not wait for other goroutines.\n" +
+			"- Use synchronization:\n" +
+			"  1. sync.WaitGroup\n" +
+			"  2. channels\n\n" +
+			"Goroutines share the same address space, so access to shared memory must be synchronized, otherwise data races happen."
+		assert.Equal(t, expected, docs[0].Content)
+
+		meta := docs[0].MetaData
+		assert.Equal(t, "Understanding Goroutines - Example Blog", meta[MetaKeyTitle])
+		assert.Equal(t, "https://example.com/blog/goroutines", meta[MetaKeyCanonical])
+		assert.Equal(t, "en-US", meta[MetaKeyLang])
+		assert.Equal(t, map[string]string{
+			"title":  "Understanding Goroutines",
+			"type":   "article",
+			"locale": "en_US",
+			"image":  "https://example.com/img/cover.png",
+		}, meta[MetaKeyOpenGraph])
+		assert.Equal(t, time.Date(2024, 3, 5, 8, 30, 0, 0, time.UTC), meta[MetaKeyPublishedTime])
+	})
+
+	t.Run("with selector", func(t *testing.T) {
+		sel := "#xid"
+		p, err := NewParser(ctx, &Config{Selector: &sel, ExtractContent: true})
+		assert.NoError(t, err)
+
+		f, err := os.Open("testdata/normal.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, "content in xid", docs[0].Content)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{ExtractContent: true})
+		assert.NoError(t, err)
+
+		page := `<html><head>
+<script type="application/ld+json">{"@type":"NewsArticle","datePublished":"2024-06-01"}</script>
+</head><body><main>
+<blockquote><p>Simplicity is prerequisite for reliability, as someone famous once said, and it still holds.</p></blockquote>
+<table><tr><th>Name</th><th>Value</th></tr><tr><td>a|b</td><td><em>1</em></td></tr></table>
+<p><img src="img/x.png" alt="X"> <a href="javascript:void(0)">noop</a></p>
+</main></body></html>`
+		docs, err := p.Parse(ctx, strings.NewReader(page), parser.WithURI("https://example.com/posts/"))
+		assert.NoError(t, err)
+		assert.Equal(t, "> Simplicity is prerequisite for reliability, as someone famous once said, and it still holds.\n\n"+
+			"| Name | Value |\n| --- | --- |\n| a\\|b | _1_ |\n\n"+
+			"![X](https://example.com/posts/img/x.png) noop", docs[0].Content)
+		assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), docs[0].MetaData[MetaKeyPublishedTime])
+	})
+}
@@ -0,0 +1,400 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	spaces      = regexp.MustCompile(`\s+`)
+	blankLines  = regexp.MustCompile(`\n{3,}`)
+	codeLangReg = regexp.MustCompile(`(?:^|\s)(?:language|lang)-([\w+#-]+)`)
+)
+
+// markdownWriter converts html nodes to Markdown, relative links and images are resolved against base.
+type markdownWriter struct {
+	base *url.URL
+	sb   strings.Builder
+}
+
+// toMarkdown converts nodes and their descendants to Markdown.
+func toMarkdown(nodes []*html.Node, base *url.URL) string {
+	w := &markdownWriter{base: base}
+	for _, node := range nodes {
+		w.block(node)
+	}
+	content := blankLines.ReplaceAllString(w.sb.String(), "\n\n")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// block writes node as a block, separated by blank lines from its siblings.
+func (w *markdownWriter) block(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		w.sb.WriteString(w.text(node.Data))
+		return
+	case html.DocumentNode:
+		w.children(node)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch node.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := strings.TrimSpace(w.inline(node))
+		if text == "" {
+			return
+		}
+		level := int(node.Data[1] - '0')
+		w.paragraph(strings.Repeat("#", level) + " " + text)
+	case atom.P:
+		w.paragraph(strings.TrimSpace(w.inline(node)))
+	case atom.Pre:
+		w.codeBlock(node)
+	case atom.Ul, atom.Ol:
+		w.list(node)
+	case atom.Blockquote:
+		inner := toMarkdownWith(node, w.base)
+		if inner == "" {
+			return
+		}
+		lines := strings.Split(inner, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		w.paragraph(strings.Join(lines, "\n"))
+	case atom.Table:
+		w.table(node)
+	case atom.Hr:
+		w.paragraph("---")
+	case atom.Br:
+		w.sb.WriteString("\n")
+	case atom.Img:
+		w.paragraph(w.image(node))
+	case atom.A, atom.Strong, atom.B, atom.Em, atom.I, atom.Code, atom.Span, atom.Small, atom.Sub, atom.Sup, atom.Mark, atom.Del, atom.S:
+		w.paragraph(strings.TrimSpace(w.inline(node)))
+	default:
+		if hasBlockChild(node) {
+			w.children(node)
+			return
+		}
+		w.paragraph(strings.TrimSpace(w.inline(node)))
+	}
+}
+
+func (w *markdownWriter) children(node *html.Node) {
+	var inline strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(inline.String()); text != "" {
+			w.paragraph(text)
+		}
+		inline.Reset()
+	}
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && isBlock(c) || c.Type == html.DocumentNode {
+			flush()
+			w.block(c)
+			continue
+		}
+		inline.WriteString(w.inlineNode(c))
+	}
+	flush()
+}
+
+func (w *markdownWriter) paragraph(text string) {
+	if text == "" {
+		return
+	}
+	w.sb.WriteString("\n\n")
+	w.sb.WriteString(text)
+	w.sb.WriteString("\n\n")
+}
+
+// toMarkdownWith converts the children of node with a new writer, used for nested blocks like blockquote and list items.
+func toMarkdownWith(node *html.Node, base *url.URL) string {
+	w := &markdownWriter{base: base}
+	w.children(node)
+	content := blankLines.ReplaceAllString(w.sb.String(), "\n\n")
+	return strings.TrimSpace(content)
+}
+
+func (w *markdownWriter) inline(node *html.Node) string {
+	var sb strings.Builder
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(w.inlineNode(c))
+	}
+	return sb.String()
+}
+
+func (w *markdownWriter) inlineNode(node *html.Node) string {
+	switch node.Type {
+	case html.TextNode:
+		return w.text(node.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch node.DataAtom {
+	case atom.Br:
+		return "  \n"
+	case atom.A:
+		text := strings.TrimSpace(w.inline(node))
+		href := w.resolve(getAttr(node, "href"))
+		if href == "" || strings.HasPrefix(href, "javascript:") {
+			return text
+		}
+		if text == "" {
+			return ""
+		}
+		return "[" + text + "](" + href + ")"
+	case atom.Strong, atom.B:
+		return wrapInline(w.inline(node), "**")
+	case atom.Em, atom.I:
+		return wrapInline(w.inline(node), "_")
+	case atom.Del, atom.S:
+		return wrapInline(w.inline(node), "~~")
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		code := textContent(node)
+		if code == "" {
+			return ""
+		}
+		fence := "`"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		return fence + code + fence
+	case atom.Img:
+		return w.image(node)
+	default:
+		return w.inline(node)
+	}
+}
+
+func (w *markdownWriter) text(data string) string {
+	return spaces.ReplaceAllString(data, " ")
+}
+
+func (w *markdownWriter) image(node *html.Node) string {
+	src := w.resolve(getAttr(node, "src"))
+	if src == "" {
+		return ""
+	}
+	return "![" + strings.TrimSpace(getAttr(node, "alt")) + "](" + src + ")"
+}
+
+func (w *markdownWriter) codeBlock(node *html.Node) {
+	lang := codeLanguage(node)
+	if code := firstChildElement(node, atom.Code); code != nil && lang == "" {
+		lang = codeLanguage(code)
+	}
+	code := strings.Trim(textContent(node), "\n")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	w.paragraph(fence + lang + "\n" + code + "\n" + fence)
+}
+
+func (w *markdownWriter) list(node *html.Node) {
+	ordered := node.DataAtom == atom.Ol
+	index := 1
+	if ordered {
+		if _, err := fmt.Sscanf(getAttr(node, "start"), "%d", &index); err != nil {
+			index = 1
+		}
+	}
+
+	var items []string
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", index)
+			index++
+		}
+		indent := strings.Repeat(" ", len(marker))
+		content := toMarkdownWith(c, w.base)
+		// nested lists directly follow their parent item
+		content = strings.ReplaceAll(content, "\n\n", "\n")
+		lines := strings.Split(content, "\n")
+		for i := 1; i < len(lines); i++ {
+			if lines[i] != "" {
+				lines[i] = indent + lines[i]
+			}
+		}
+		items = append(items, marker+strings.Join(lines, "\n"))
+	}
+	if len(items) > 0 {
+		w.paragraph(strings.Join(items, "\n"))
+	}
+}
+
+func (w *markdownWriter) table(node *html.Node) {
+	var rows [][]string
+	header := false
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(c)
+			case atom.Tr:
+				var row []string
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type != html.ElementNode || (cell.DataAtom != atom.Td && cell.DataAtom != atom.Th) {
+						continue
+					}
+					if len(rows) == 0 && cell.DataAtom == atom.Th {
+						header = true
+					}
+					text := strings.TrimSpace(w.inline(cell))
+					row = append(row, strings.ReplaceAll(text, "|", "\\|"))
+				}
+				if len(row) > 0 {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	walk(node)
+	if len(rows) == 0 {
+		return
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if !header {
+		// markdown tables require a header row
+		empty := make([]string, width)
+		rows = append([][]string{empty}, rows...)
+	}
+
+	var sb strings.Builder
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			sb.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+		}
+	}
+	w.paragraph(strings.TrimSuffix(sb.String(), "\n"))
+}
+
+func (w *markdownWriter) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || w.base == nil {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return w.base.ResolveReference(u).String()
+}
+
+func wrapInline(text, mark string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	// keep the surrounding spaces outside of the marks
+	prefix := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	suffix := text[len(strings.TrimRight(text, " ")):]
+	return prefix + mark + trimmed + mark + suffix
+}
+
+func isBlock(node *html.Node) bool {
+	switch node.DataAtom {
+	case atom.Address, atom.Article, atom.Aside, atom.Blockquote, atom.Details, atom.Dialog, atom.Dd, atom.Div,
+		atom.Dl, atom.Dt, atom.Fieldset, atom.Figcaption, atom.Figure, atom.Footer, atom.Header,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Hgroup, atom.Hr, atom.Li, atom.Main, atom.Nav,
+		atom.Ol, atom.P, atom.Pre, atom.Section, atom.Table, atom.Ul, atom.Body, atom.Html:
+		return true
+	}
+	return false
+}
+
+func hasBlockChild(node *html.Node) bool {
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && isBlock(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func codeLanguage(node *html.Node) string {
+	if lang := getAttr(node, "data-lang"); lang != "" {
+		return lang
+	}
+	if m := codeLangReg.FindStringSubmatch(getAttr(node, "class")); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+func firstChildElement(node *html.Node, a atom.Atom) *html.Node {
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == a {
+			return c
+		}
+	}
+	return nil
+}
+
+func textContent(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+	var sb strings.Builder
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+func getAttr(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// boilerplateSelector matches elements which are never part of the main content.
+	boilerplateSelector = strings.Join([]string{
+		"script", "style", "noscript", "template", "iframe", "object", "embed", "svg", "canvas",
+		"form", "button", "input", "select", "textarea", "nav", "aside", "footer", "dialog",
+		"[hidden]", "[aria-hidden=true]",
+		"[role=navigation]", "[role=complementary]", "[role=banner]", "[role=contentinfo]", "[role=dialog]",
+	}, ",")
+
+	unlikelyCandidates = regexp.MustCompile(`(?i)-ad-|\bads?\b|advert|agegate|banner|breadcrumb|combx|comment|community|cookie|` +
+		`cover-wrap|disqus|extra|gdpr|header|legends|menu|newsletter|pager|pagination|popup|promo|related|remark|replies|` +
+		`rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|supplemental|yom-remote`)
+	maybeCandidate = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+
+	positiveClass = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeClass = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|` +
+		`footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|` +
+		`sponsor|shopping|tags|tool|widget`)
+	hiddenStyle = regexp.MustCompile(`(?i)display\s*:\s*none|visibility\s*:\s*hidden`)
+)
+
+const (
+	// paragraphs shorter than minParagraphLen are not scored
+	minParagraphLen = 25
+	// siblings of the top candidate scoring more than siblingScoreRatio of it are kept as well
+	siblingScoreRatio = 0.2
+)
+
+// extractContent removes boilerplate from doc and returns the nodes of the main content, in document order.
+// root limits the candidates, it is the body if nil.
+func extractContent(doc *goquery.Document, root *goquery.Selection) []*html.Node {
+	doc.Find(boilerplateSelector).Remove()
+	doc.Find("[style]").FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return hiddenStyle.MatchString(s.AttrOr("style", ""))
+	}).Remove()
+	doc.Find("*").FilterFunction(func(_ int, s *goquery.Selection) bool {
+		node := s.Get(0)
+		switch node.DataAtom {
+		case atom.Html, atom.Body, atom.Article, atom.Main:
+			return false
+		}
+		matchString := s.AttrOr("class", "") + " " + s.AttrOr("id", "")
+		return unlikelyCandidates.MatchString(matchString) && !maybeCandidate.MatchString(matchString) &&
+			!s.Is("table,pre,code") && s.Closest("table,pre,code").Length() == 0
+	}).Remove()
+
+	if root == nil {
+		root = doc.Find("body")
+		if root.Length() == 0 {
+			root = doc.Selection
+		}
+	}
+	if root.Length() == 0 {
+		return nil
+	}
+
+	scores := make(map[*html.Node]float64)
+	root.Find("p,pre,td,blockquote,li,div,section").Each(func(_ int, s *goquery.Selection) {
+		node := s.Get(0)
+		// divs and sections are only scored for their own text, which is not wrapped in paragraphs
+		if node.DataAtom == atom.Div || node.DataAtom == atom.Section || node.DataAtom == atom.Li {
+			if s.Children().Filter("p,pre,div,section,table,ul,ol,blockquote").Length() > 0 {
+				return
+			}
+		}
+		text := strings.TrimSpace(s.Text())
+		if len([]rune(text)) < minParagraphLen {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，")) + math.Min(float64(len([]rune(text)))/100, 3)
+		ancestor := node.Parent
+		for level := 0; level < 3 && ancestor != nil && ancestor.Type == html.ElementNode; level++ {
+			if _, ok := scores[ancestor]; !ok {
+				scores[ancestor] = initialScore(ancestor)
+			}
+			switch level {
+			case 0:
+				scores[ancestor] += score
+			case 1:
+				scores[ancestor] += score / 2
+			default:
+				scores[ancestor] += score / float64(level*3)
+			}
+			ancestor = ancestor.Parent
+		}
+	})
+
+	var (
+		top      *html.Node
+		topScore float64
+	)
+	rootNode := root.Get(0)
+	for node, score := range scores {
+		if !within(node, rootNode) {
+			continue
+		}
+		score *= 1 - linkDensity(goquery.NewDocumentFromNode(node).Selection)
+		scores[node] = score
+		if top == nil || score > topScore {
+			top, topScore = node, score
+		}
+	}
+	if top == nil {
+		return []*html.Node{rootNode}
+	}
+
+	// a wrapper containing nothing but the candidate is a better choice, it may hold the title
+	for top.Parent != nil && top.Parent != rootNode && top.Parent.Type == html.ElementNode && onlyElementChild(top.Parent) == top {
+		top = top.Parent
+	}
+
+	if top.Parent == nil {
+		return []*html.Node{top}
+	}
+
+	threshold := math.Max(10, topScore*siblingScoreRatio)
+	var nodes []*html.Node
+	for sibling := top.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+		if sibling == top {
+			nodes = append(nodes, sibling)
+			continue
+		}
+		if sibling.Type != html.ElementNode {
+			continue
+		}
+		if score, ok := scores[sibling]; ok && score >= threshold {
+			nodes = append(nodes, sibling)
+			continue
+		}
+		if sibling.DataAtom == atom.P {
+			s := goquery.NewDocumentFromNode(sibling).Selection
+			text := strings.TrimSpace(s.Text())
+			if len([]rune(text)) > 80 && linkDensity(s) < 0.25 {
+				nodes = append(nodes, sibling)
+			}
+		}
+	}
+	return nodes
+}
+
+func initialScore(node *html.Node) float64 {
+	var score float64
+	switch node.DataAtom {
+	case atom.Article, atom.Main:
+		score = 10
+	case atom.Div:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score = -5
+	}
+	for _, attr := range node.Attr {
+		if attr.Key != "class" && attr.Key != "id" {
+			continue
+		}
+		if negativeClass.MatchString(attr.Val) {
+			score -= 25
+		}
+		if positiveClass.MatchString(attr.Val) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// linkDensity is the ratio of the text inside links to all the text.
+func linkDensity(s *goquery.Selection) float64 {
+	textLen := len([]rune(strings.TrimSpace(s.Text())))
+	if textLen == 0 {
+		return 0
+	}
+	linkLen := 0
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		linkLen += len([]rune(strings.TrimSpace(a.Text())))
+	})
+	return float64(linkLen) / float64(textLen)
+}
+
+func within(node, root *html.Node) bool {
+	for n := node; n != nil; n = n.Parent {
+		if n == root {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyElementChild returns the only element child of node, nil if node has other elements or text.
+func onlyElementChild(node *html.Node) *html.Node {
+	var only *html.Node
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.ElementNode:
+			if only != nil {
+				return nil
+			}
+			only = c
+		case html.TextNode:
+			if strings.TrimSpace(c.Data) != "" {
+				return nil
+			}
+		}
+	}
+	return only
+}
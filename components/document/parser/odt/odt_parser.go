@@ -0,0 +1,392 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package odt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyHeadingPath is the metadata key of the heading titles from the top level heading to the section's heading,
+	// only set when Config.ToSections is enabled.
+	MetaKeyHeadingPath = "_heading_path"
+	MetaKeyTitle       = "_title"  // title in the document metadata
+	MetaKeyAuthor      = "_author" // creator in the document metadata
+)
+
+// Config is the configuration for ODT parser.
+type Config struct {
+	// ToSections splits the document by headings, every section starts with its heading,
+	// and the content before the first heading is a section without heading path.
+	ToSections bool
+}
+
+// ODTParser reads from io.Reader and parses OpenDocument text (odt) files as Markdown,
+// keeping headings, lists and tables. Notes, comments, tracked deletions, drawings and generated indexes are dropped.
+type ODTParser struct {
+	toSections bool
+}
+
+// NewODTParser creates a new ODT parser.
+func NewODTParser(_ context.Context, config *Config) (*ODTParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	return &ODTParser{toSections: config.ToSections}, nil
+}
+
+// Parse parses the ODT content from io.Reader.
+func (op *ODTParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("odt parser failed to read content: %w", err)
+	}
+	pkg, err := openODTPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	title, author, err := pkg.metadata()
+	if err != nil {
+		return nil, err
+	}
+	content, err := pkg.parse("content.xml")
+	if err != nil {
+		return nil, err
+	}
+	body := content.find(officeNS, "text")
+	if body == nil {
+		return nil, fmt.Errorf("odt parser failed to parse content: office:text not found")
+	}
+	r := &renderer{}
+	r.renderBlocks(body, 0)
+
+	newDoc := func(blocks []block) *schema.Document {
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+3)
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		if title != "" {
+			meta[MetaKeyTitle] = title
+		}
+		if author != "" {
+			meta[MetaKeyAuthor] = author
+		}
+		return &schema.Document{
+			Content:  joinBlocks(blocks),
+			MetaData: meta,
+		}
+	}
+
+	if !op.toSections {
+		if len(r.blocks) == 0 {
+			return nil, nil
+		}
+		return []*schema.Document{newDoc(r.blocks)}, nil
+	}
+
+	var (
+		path  []block // the enclosing headings, in ascending levels
+		start int
+	)
+	emit := func(end int) {
+		if end > start {
+			doc := newDoc(r.blocks[start:end])
+			if len(path) > 0 {
+				titles := make([]string, len(path))
+				for i := range path {
+					titles[i] = path[i].title
+				}
+				doc.MetaData[MetaKeyHeadingPath] = titles
+			}
+			docs = append(docs, doc)
+		}
+		start = end
+	}
+	for i, b := range r.blocks {
+		if b.level == 0 {
+			continue
+		}
+		emit(i)
+		for len(path) > 0 && path[len(path)-1].level >= b.level {
+			path = path[:len(path)-1]
+		}
+		path = append(path, b)
+	}
+	emit(len(r.blocks))
+	return docs, nil
+}
+
+// GetHeadingPath returns the heading path of a section.
+func GetHeadingPath(doc *schema.Document) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	headingPath, ok := doc.MetaData[MetaKeyHeadingPath].([]string)
+	return headingPath, ok
+}
+
+// block is a rendered paragraph, heading, list item or table.
+type block struct {
+	text  string
+	level int    // outline level of a heading, 0 for other blocks
+	title string // text of a heading
+	tight bool   // whether the block follows the previous one without a blank line, e.g. list items
+}
+
+func joinBlocks(blocks []block) string {
+	var sb strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			if b.tight && blocks[i-1].tight {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(b.text)
+	}
+	return sb.String()
+}
+
+// skippedElements are the text elements whose content is not text of the document.
+var skippedElements = map[string]bool{
+	"tracked-changes": true, "sequence-decls": true, "variable-decls": true, "user-field-decls": true,
+	"note": true, "table-of-content": true, "alphabetical-index": true, "illustration-index": true,
+	"table-index": true, "object-index": true, "user-index": true, "bibliography": true,
+}
+
+type renderer struct {
+	blocks []block
+}
+
+// renderBlocks renders the block elements in n, list items are indented by depth.
+func (r *renderer) renderBlocks(n *node, depth int) {
+	for _, c := range n.children {
+		switch {
+		case c.name.Space == textNS && skippedElements[c.name.Local]:
+		case c.is(textNS, "h"):
+			text := inlineText(c)
+			if text == "" {
+				continue
+			}
+			level, err := strconv.Atoi(c.attr(textNS, "outline-level"))
+			if err != nil || level < 1 {
+				level = 1
+			}
+			r.blocks = append(r.blocks, block{text: strings.Repeat("#", min(level, 6)) + " " + text, level: level, title: text})
+		case c.is(textNS, "p"):
+			if text := inlineText(c); text != "" {
+				r.blocks = append(r.blocks, block{text: text})
+			}
+		case c.is(textNS, "list"):
+			r.renderList(c, depth)
+		case c.is(tableNS, "table"):
+			if text := renderTable(c); text != "" {
+				r.blocks = append(r.blocks, block{text: text})
+			}
+		case c.name.Space == officeNS:
+			// forms, annotations and scripts
+		default:
+			// sections and other containers
+			r.renderBlocks(c, depth)
+		}
+	}
+}
+
+func (r *renderer) renderList(list *node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, item := range list.children {
+		if !item.is(textNS, "list-item") && !item.is(textNS, "list-header") {
+			continue
+		}
+		first := true
+		for _, c := range item.children {
+			switch {
+			case c.is(textNS, "list"):
+				r.renderList(c, depth+1)
+				first = false
+			case c.is(textNS, "p"), c.is(textNS, "h"):
+				text := inlineText(c)
+				if text == "" {
+					continue
+				}
+				if first {
+					text = indent + "- " + text
+				} else {
+					text = indent + "  " + text
+				}
+				r.blocks = append(r.blocks, block{text: text, tight: true})
+				first = false
+			}
+		}
+	}
+}
+
+// inlineText renders the text of a paragraph or heading. Whitespaces are collapsed as in HTML,
+// except the spaces of text:s, text:tab and text:line-break.
+func inlineText(p *node) string {
+	var (
+		sb        strings.Builder
+		lastSpace = true
+	)
+	var walk func(*node)
+	walk = func(n *node) {
+		if n.name.Local == "" {
+			for _, c := range n.text {
+				if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+					if !lastSpace {
+						sb.WriteByte(' ')
+						lastSpace = true
+					}
+					continue
+				}
+				sb.WriteRune(c)
+				lastSpace = false
+			}
+			return
+		}
+		if n.name.Space == officeNS || n.name.Space == drawNS || (n.name.Space == textNS && skippedElements[n.name.Local]) {
+			return
+		}
+		if n.name.Space == textNS {
+			switch n.name.Local {
+			case "s":
+				count, err := strconv.Atoi(n.attr(textNS, "c"))
+				if err != nil || count < 1 {
+					count = 1
+				}
+				sb.WriteString(strings.Repeat(" ", min(count, 100)))
+				lastSpace = true
+				return
+			case "tab":
+				sb.WriteByte('\t')
+				lastSpace = true
+				return
+			case "line-break":
+				sb.WriteByte('\n')
+				lastSpace = true
+				return
+			}
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(p)
+
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// maxRepeated limits the repeated rows and columns of tables, which are used to fill a sheet with empty cells.
+const maxRepeated = 100
+
+// renderTable renders a table as Markdown, the first row is the header.
+func renderTable(table *node) string {
+	var rows [][]string
+	var collect func(*node)
+	collect = func(n *node) {
+		for _, c := range n.children {
+			switch {
+			case c.is(tableNS, "table-row"):
+				var row []string
+				for _, cell := range c.children {
+					if !cell.is(tableNS, "table-cell") && !cell.is(tableNS, "covered-table-cell") {
+						continue
+					}
+					var texts []string
+					if cell.is(tableNS, "table-cell") {
+						for _, p := range cell.children {
+							if !p.is(textNS, "p") && !p.is(textNS, "h") {
+								continue
+							}
+							if text := inlineText(p); text != "" {
+								texts = append(texts, strings.Join(strings.Fields(text), " "))
+							}
+						}
+					}
+					text := strings.ReplaceAll(strings.Join(texts, " "), "|", `\|`)
+					for i := 0; i < repeated(cell, "number-columns-repeated"); i++ {
+						row = append(row, text)
+					}
+				}
+				for i := 0; i < repeated(c, "number-rows-repeated"); i++ {
+					rows = append(rows, row)
+				}
+			case c.is(tableNS, "table-header-rows"), c.is(tableNS, "table-rows"), c.is(tableNS, "table-row-group"):
+				collect(c)
+			}
+		}
+	}
+	collect(table)
+
+	// drop the trailing empty rows and columns
+	for len(rows) > 0 && strings.Join(rows[len(rows)-1], "") == "" {
+		rows = rows[:len(rows)-1]
+	}
+	width := 0
+	for _, row := range rows {
+		for i := len(row) - 1; i >= 0; i-- {
+			if row[i] != "" {
+				width = max(width, i+1)
+				break
+			}
+		}
+	}
+	if width == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i := 0; i < width; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func repeated(n *node, attr string) int {
+	count, err := strconv.Atoi(n.attr(tableNS, attr))
+	if err != nil || count < 1 {
+		return 1
+	}
+	return min(count, maxRepeated)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package odt
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document/parser"
+)
+
+const testNS = `xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+	`xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+	`xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" ` +
+	`xmlns:dc="http://purl.org/dc/elements/1.1/" ` +
+	`xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0"`
+
+func buildODT(t *testing.T, body string) []byte {
+	parts := map[string]string{
+		"mimetype":    "application/vnd.oasis.opendocument.text",
+		"content.xml": `<?xml version="1.0" encoding="UTF-8"?><office:document-content ` + testNS + `><office:body><office:text>` + body + `</office:text></office:body></office:document-content>`,
+		"meta.xml": `<?xml version="1.0" encoding="UTF-8"?><office:document-meta ` + testNS + `><office:meta>` +
+			`<dc:title>Manual</dc:title><meta:initial-creator>Bob</meta:initial-creator></office:meta></office:document-meta>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+const testBody = `<text:sequence-decls><text:sequence-decl text:name="Table"/></text:sequence-decls>` +
+	`<text:table-of-content><text:index-body><text:p>Install 1</text:p></text:index-body></text:table-of-content>` +
+	`<text:p>Intro   text
+  with <text:span>spans</text:span><text:s text:c="3"/>and<text:tab/>tabs<text:note><text:note-citation>1</text:note-citation>` +
+	`<text:note-body><text:p>A note</text:p></text:note-body></text:note>.</text:p>` +
+	`<text:h text:outline-level="1">Install</text:h>` +
+	`<text:p>Line one<text:line-break/>line two</text:p>` +
+	`<text:list><text:list-item><text:p>first</text:p><text:list><text:list-item><text:p>nested</text:p></text:list-item></text:list></text:list-item>` +
+	`<text:list-item><text:p>second</text:p></text:list-item></text:list>` +
+	`<text:h text:outline-level="2">Linux</text:h>` +
+	`<text:section text:name="s"><table:table><table:table-header-rows><table:table-row>` +
+	`<table:table-cell><text:p>Name</text:p></table:table-cell><table:table-cell><text:p>Value</text:p></table:table-cell>` +
+	`<table:table-cell table:number-columns-repeated="1000"/></table:table-row></table:table-header-rows>` +
+	`<table:table-row><table:table-cell><text:p>a|b</text:p></table:table-cell><table:covered-table-cell/></table:table-row>` +
+	`<table:table-row table:number-rows-repeated="1000"><table:table-cell table:number-columns-repeated="3"/></table:table-row>` +
+	`</table:table></text:section>` +
+	`<text:p><draw:frame><draw:text-box><text:p>boxed</text:p></draw:text-box></draw:frame></text:p>` +
+	`<text:h text:outline-level="1">Usage</text:h><text:p>Run it.</text:p>`
+
+func TestODTParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	data := buildODT(t, testBody)
+
+	p, err := NewODTParser(ctx, nil)
+	assert.NoError(t, err)
+	docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithExtraMeta(map[string]any{"test": "test"}))
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(docs)) {
+		assert.Equal(t, "Intro text with spans   and\ttabs.\n\n"+
+			"# Install\n\nLine one\nline two\n\n- first\n  - nested\n- second\n\n"+
+			"## Linux\n\n| Name | Value |\n| --- | --- |\n| a\\|b |  |\n\n"+
+			"# Usage\n\nRun it.", docs[0].Content)
+		assert.Equal(t, map[string]any{"test": "test", MetaKeyTitle: "Manual", MetaKeyAuthor: "Bob"}, docs[0].MetaData)
+	}
+
+	p, err = NewODTParser(ctx, &Config{ToSections: true})
+	assert.NoError(t, err)
+	docs, err = p.Parse(ctx, bytes.NewReader(data))
+	assert.NoError(t, err)
+	if assert.Equal(t, 4, len(docs)) {
+		_, ok := GetHeadingPath(docs[0])
+		assert.False(t, ok)
+		assert.Equal(t, "# Install\n\nLine one\nline two\n\n- first\n  - nested\n- second", docs[1].Content)
+		assert.Equal(t, []string{"Install"}, docs[1].MetaData[MetaKeyHeadingPath])
+		path, _ := GetHeadingPath(docs[2])
+		assert.Equal(t, []string{"Install", "Linux"}, path)
+		assert.Equal(t, []string{"Usage"}, docs[3].MetaData[MetaKeyHeadingPath])
+		assert.Equal(t, "Manual", docs[3].MetaData[MetaKeyTitle])
+	}
+}
+
+func TestODTParser_SkippedLevel(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewODTParser(ctx, &Config{ToSections: true})
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(ctx, bytes.NewReader(buildODT(t,
+		`<text:h text:outline-level="1">A</text:h>`+
+			`<text:h text:outline-level="3">C</text:h><text:p>c</text:p>`+
+			`<text:h text:outline-level="3">D</text:h><text:p>d</text:p>`+
+			`<text:h text:outline-level="2">B</text:h><text:p>b</text:p>`)))
+	assert.NoError(t, err)
+	var paths [][]string
+	for _, doc := range docs {
+		path, _ := GetHeadingPath(doc)
+		paths = append(paths, path)
+	}
+	assert.Equal(t, [][]string{{"A"}, {"A", "C"}, {"A", "D"}, {"A", "B"}}, paths)
+}
+
+func TestODTParser_Invalid(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewODTParser(ctx, nil)
+	assert.NoError(t, err)
+
+	_, err = p.Parse(ctx, bytes.NewReader([]byte("not a zip")))
+	assert.Error(t, err)
+	_, err = p.Parse(ctx, bytes.NewReader(buildODT(t, `<text:p>unclosed`)))
+	assert.Error(t, err)
+	docs, err := p.Parse(ctx, bytes.NewReader(buildODT(t, `<text:p> </text:p>`)))
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package odt
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// node is an element or a text node of an XML document, children are kept in document order.
+type node struct {
+	name     xml.Name // empty for text nodes
+	attrs    []xml.Attr
+	text     string
+	children []*node
+}
+
+const (
+	textNS   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	tableNS  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	officeNS = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	drawNS   = "urn:oasis:names:tc:opendocument:xmlns:drawing:1.0"
+)
+
+func (n *node) is(space, local string) bool {
+	return n.name.Space == space && n.name.Local == local
+}
+
+func (n *node) attr(space, local string) string {
+	for _, a := range n.attrs {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// find returns the first descendant in document order with the name.
+func (n *node) find(space, local string) *node {
+	for _, c := range n.children {
+		if c.is(space, local) {
+			return c
+		}
+		if found := c.find(space, local); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// textContent returns the text in n with whitespaces collapsed.
+func (n *node) textContent() string {
+	var sb strings.Builder
+	var walk func(*node)
+	walk = func(n *node) {
+		sb.WriteString(n.text)
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+func parseXML(data []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	root := &node{}
+	stack := []*node{root}
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name, attrs: t.Attr}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			top.children = append(top.children, &node{text: string(t)})
+		}
+	}
+	return root, nil
+}
+
+// odtPackage holds the files of an OpenDocument text file.
+type odtPackage struct {
+	files map[string]*zip.File
+}
+
+func openODTPackage(data []byte) (*odtPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open odt package failed: %w", err)
+	}
+	pkg := &odtPackage{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		pkg.files[f.Name] = f
+	}
+	if _, ok := pkg.files["content.xml"]; !ok {
+		return nil, fmt.Errorf("open odt package failed: content.xml not found")
+	}
+	return pkg, nil
+}
+
+// parse reads and parses a file in the package, a missing file returns nil without error.
+func (p *odtPackage) parse(name string) (*node, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open odt file [%s] failed: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read odt file [%s] failed: %w", name, err)
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode odt file [%s] failed: %w", name, err)
+	}
+	return root, nil
+}
+
+// metadata returns the title and author in meta.xml.
+func (p *odtPackage) metadata() (title, author string, err error) {
+	meta, err := p.parse("meta.xml")
+	if err != nil || meta == nil {
+		return "", "", err
+	}
+	const dcNS = "http://purl.org/dc/elements/1.1/"
+	if n := meta.find(dcNS, "title"); n != nil {
+		title = n.textContent()
+	}
+	if n := meta.find(dcNS, "creator"); n != nil {
+		author = n.textContent()
+	} else if n = meta.find("urn:oasis:names:tc:opendocument:xmlns:meta:1.0", "initial-creator"); n != nil {
+		author = n.textContent()
+	}
+	return title, author, nil
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dslipak/pdf"
+)
+
+const (
+	// lines whose baselines differ less than lineTolerance * font size are merged into one line
+	lineTolerance = 0.5
+	// a space is inserted between two glyphs if the gap is larger than wordGap * font size
+	wordGap = 0.15
+	// a line is split into table cells where the gap is larger than cellGap * font size
+	cellGap = 1.5
+	// a blank line is inserted if the distance between two baselines is larger than paragraphGap * font size
+	paragraphGap = 1.6
+	// lines with a font size larger than headingScale * body font size are treated as headings
+	headingScale = 1.15
+	// lines longer than maxHeadingLen are never treated as headings
+	maxHeadingLen = 120
+	// headings are rendered as Markdown headings up to maxHeadingLevel
+	maxHeadingLevel = 6
+)
+
+type textLine struct {
+	y     float64
+	size  float64
+	cells []string
+}
+
+func (l *textLine) text() string {
+	return strings.Join(l.cells, " ")
+}
+
+// pageContent returns the glyphs of a page, the pdf package panics on malformed content streams.
+func pageContent(p pdf.Page) (content pdf.Content, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("read pdf page content panic: %v", r)
+		}
+	}()
+	return p.Content(), nil
+}
+
+// groupLines groups glyphs into lines in reading order, from top to bottom and left to right.
+// Glyphs of a line separated by a wide gap are split into cells, which are used to detect tables.
+func groupLines(texts []pdf.Text) []*textLine {
+	glyphs := make([]pdf.Text, 0, len(texts))
+	for _, t := range texts {
+		if t.S == "" || t.FontSize <= 0 {
+			continue
+		}
+		glyphs = append(glyphs, t)
+	}
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return glyphs[i].Y > glyphs[j].Y
+	})
+
+	var rows [][]pdf.Text
+	for _, g := range glyphs {
+		if n := len(rows); n > 0 {
+			last := rows[n-1][0]
+			if math.Abs(last.Y-g.Y) < lineTolerance*math.Max(last.FontSize, g.FontSize) {
+				rows[n-1] = append(rows[n-1], g)
+				continue
+			}
+		}
+		rows = append(rows, []pdf.Text{g})
+	}
+
+	lines := make([]*textLine, 0, len(rows))
+	for _, row := range rows {
+		if line := buildLine(row); line != nil {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func buildLine(row []pdf.Text) *textLine {
+	sort.SliceStable(row, func(i, j int) bool {
+		return row[i].X < row[j].X
+	})
+
+	var (
+		cells  []string
+		cell   strings.Builder
+		end    = math.Inf(-1)
+		sizes  = make(map[float64]int)
+		baseY  = row[0].Y
+		closeC = func() {
+			if s := strings.Join(strings.Fields(cell.String()), " "); s != "" {
+				cells = append(cells, s)
+			}
+			cell.Reset()
+		}
+	)
+	for _, g := range row {
+		gap := g.X - end
+		switch {
+		case gap > cellGap*g.FontSize:
+			closeC()
+		case gap > wordGap*g.FontSize:
+			cell.WriteByte(' ')
+		}
+		cell.WriteString(g.S)
+
+		w := g.W
+		if w <= 0 {
+			// fonts without widths, assume an average glyph width
+			w = 0.5 * g.FontSize * float64(len([]rune(g.S)))
+		}
+		end = math.Max(end, g.X+w)
+		if strings.TrimSpace(g.S) != "" {
+			sizes[math.Round(g.FontSize*2)/2]++
+		}
+	}
+	closeC()
+	if len(cells) == 0 {
+		return nil
+	}
+	return &textLine{y: baseY, size: dominantSize(sizes), cells: cells}
+}
+
+// dominantSize returns the font size used by most glyphs, the larger one wins a tie.
+func dominantSize(sizes map[float64]int) float64 {
+	var size float64
+	count := -1
+	for s, c := range sizes {
+		if c > count || (c == count && s > size) {
+			size, count = s, c
+		}
+	}
+	return size
+}
+
+// headingLevels returns the Markdown heading level of every font size considered as a heading,
+// the largest size is level 1.
+func headingLevels(pages [][]*textLine) (bodySize float64, levels map[float64]int) {
+	sizes := make(map[float64]int)
+	for _, lines := range pages {
+		for _, line := range lines {
+			sizes[line.size] += len(line.text())
+		}
+	}
+	bodySize = dominantSize(sizes)
+
+	var large []float64
+	for s := range sizes {
+		if s > bodySize*headingScale {
+			large = append(large, s)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(large)))
+
+	levels = make(map[float64]int, len(large))
+	for i, s := range large {
+		levels[s] = min(i+1, maxHeadingLevel)
+	}
+	return bodySize, levels
+}
+
+// renderPage renders the lines of a page as Markdown, and returns the headings of the page.
+func renderPage(lines []*textLine, bodySize float64, levels map[float64]int) (string, []string) {
+	var (
+		sb       strings.Builder
+		headings []string
+		prev     *textLine
+	)
+	newBlock := func() {
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if level, ok := levels[line.size]; ok && len(line.cells) == 1 && len(line.cells[0]) <= maxHeadingLen {
+			heading := line.cells[0]
+			// a heading wrapped into multiple lines
+			for i+1 < len(lines) && lines[i+1].size == line.size && len(lines[i+1].cells) == 1 &&
+				line.y-lines[i+1].y < paragraphGap*line.size {
+				i++
+				heading += " " + lines[i].cells[0]
+				line = lines[i]
+			}
+			newBlock()
+			sb.WriteString(strings.Repeat("#", level) + " " + heading)
+			headings = append(headings, heading)
+			prev = nil
+			continue
+		}
+
+		if j := tableEnd(lines, i); j > i+1 {
+			newBlock()
+			writeTable(&sb, lines[i:j])
+			i = j - 1
+			prev = nil
+			continue
+		}
+
+		switch {
+		case prev == nil:
+			newBlock()
+		case prev.y-line.y > paragraphGap*math.Max(bodySize, line.size):
+			sb.WriteString("\n\n")
+		default:
+			sb.WriteString("\n")
+		}
+		sb.WriteString(line.text())
+		prev = line
+	}
+	return sb.String(), headings
+}
+
+// tableEnd returns the end index of the table starting at lines[start],
+// a table is at least two consecutive lines with the same number of cells (two or more).
+func tableEnd(lines []*textLine, start int) int {
+	cols := len(lines[start].cells)
+	if cols < 2 {
+		return start
+	}
+	end := start + 1
+	for end < len(lines) && len(lines[end].cells) == cols {
+		end++
+	}
+	return end
+}
+
+func writeTable(sb *strings.Builder, rows []*textLine) {
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for _, c := range cells {
+			sb.WriteString(" " + strings.ReplaceAll(c, "|", "\\|") + " |")
+		}
+	}
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		writeRow(row.cells)
+		if i == 0 {
+			sb.WriteString("\n|")
+			sb.WriteString(strings.Repeat(" --- |", len(row.cells)))
+		}
+	}
+}
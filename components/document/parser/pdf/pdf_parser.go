@@ -19,24 +19,52 @@ package pdf
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 	"github.com/dslipak/pdf"
 )
 
+const (
+	MetaKeyPage         = "_page"          // page number starting from 1, only set when parsing to pages
+	MetaKeyHeadings     = "_headings"      // []string, headings detected in the content, only set in layout mode
+	MetaKeyTitle        = "_title"         // title in the document info
+	MetaKeyAuthor       = "_author"        // author in the document info
+	MetaKeySubject      = "_subject"       // subject in the document info
+	MetaKeyCreationDate = "_creation_date" // time.Time, creation date in the document info
+)
+
+var (
+	// ErrEncrypted is returned when the PDF is encrypted and can not be decrypted with Config.Password.
+	ErrEncrypted = errors.New("pdf is encrypted")
+	// ErrScanOnly is returned when no text is extracted but the PDF contains images,
+	// which usually means the PDF is scanned and requires OCR.
+	ErrScanOnly = errors.New("pdf contains only scanned images without text")
+)
+
 // Config is the configuration for PDF parser.
 type Config struct {
-	ToPages bool // whether to
+	ToPages bool // whether to split the PDF into one document per page
+	// Layout enables the layout preserving mode, which keeps the reading order and line breaks, renders
+	// headings and tables as Markdown, and adds page number, headings and document info to metadata.
+	Layout bool
+	// Password is used to decrypt encrypted PDFs, optional.
+	Password string
 }
 
 // PDFParser reads from io.Reader and parse its content as plain text.
 // Attention: This is in alpha stage, and may not support all PDF use cases well enough.
-// For example, it will not preserve whitespace and new line for now.
+// For example, it will not preserve whitespace and new line unless Layout is enabled.
 type PDFParser struct {
-	ToPages bool
+	ToPages  bool
+	Layout   bool
+	Password string
 }
 
 // NewPDFParser creates a new PDF parser.
@@ -44,7 +72,7 @@ func NewPDFParser(ctx context.Context, config *Config) (*PDFParser, error) {
 	if config == nil {
 		config = &Config{}
 	}
-	return &PDFParser{ToPages: config.ToPages}, nil
+	return &PDFParser{ToPages: config.ToPages, Layout: config.Layout, Password: config.Password}, nil
 }
 
 // Parse parses the PDF content from io.Reader.
@@ -53,6 +81,7 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 
 	specificOpts := parser.GetImplSpecificOptions(&options{
 		toPages: &pp.ToPages,
+		layout:  &pp.Layout,
 	}, opts...)
 
 	data, err := io.ReadAll(reader)
@@ -60,17 +89,20 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 		return nil, fmt.Errorf("pdf parser read all from reader failed: %w", err)
 	}
 
-	readerAt := bytes.NewReader(data)
-
-	f, err := pdf.NewReader(readerAt, int64(readerAt.Len()))
+	f, err := pp.newReader(data)
 	if err != nil {
-		return nil, fmt.Errorf("create new pdf reader failed: %w", err)
+		return nil, err
+	}
+
+	toPages := specificOpts.toPages != nil && *specificOpts.toPages
+	if specificOpts.layout != nil && *specificOpts.layout {
+		return parseLayout(f, toPages, commonOpts.ExtraMeta)
 	}
 
 	pages := f.NumPage()
 	var (
 		buf     bytes.Buffer
-		toPages = specificOpts.toPages != nil && *specificOpts.toPages
+		hasText bool
 	)
 	fonts := make(map[string]*pdf.Font)
 	for i := 1; i <= pages; i++ {
@@ -85,6 +117,7 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 		if err != nil {
 			return nil, fmt.Errorf("read pdf page failed: %w, page= %d", err, i)
 		}
+		hasText = hasText || strings.TrimSpace(text) != ""
 
 		if toPages {
 			docs = append(docs, &schema.Document{
@@ -96,6 +129,10 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 		}
 	}
 
+	if !hasText && hasImages(f) {
+		return nil, ErrScanOnly
+	}
+
 	if !toPages {
 		docs = append(docs, &schema.Document{
 			Content:  buf.String(),
@@ -105,3 +142,176 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 
 	return docs, nil
 }
+
+func (pp *PDFParser) newReader(data []byte) (*pdf.Reader, error) {
+	readerAt := bytes.NewReader(data)
+
+	var (
+		f   *pdf.Reader
+		err error
+	)
+	if pp.Password != "" {
+		tried := false
+		f, err = pdf.NewReaderEncrypted(readerAt, int64(readerAt.Len()), func() string {
+			if tried {
+				return ""
+			}
+			tried = true
+			return pp.Password
+		})
+	} else {
+		f, err = pdf.NewReader(readerAt, int64(readerAt.Len()))
+	}
+	if err != nil {
+		if errors.Is(err, pdf.ErrInvalidPassword) || bytes.Contains(data, []byte("/Encrypt")) {
+			return nil, fmt.Errorf("%w: %v", ErrEncrypted, err)
+		}
+		return nil, fmt.Errorf("create new pdf reader failed: %w", err)
+	}
+	return f, nil
+}
+
+func parseLayout(f *pdf.Reader, toPages bool, extraMeta map[string]any) ([]*schema.Document, error) {
+	pages := make([][]*textLine, f.NumPage())
+	hasText := false
+	for i := range pages {
+		content, err := pageContent(f.Page(i + 1))
+		if err != nil {
+			return nil, fmt.Errorf("read pdf page failed: %w, page= %d", err, i+1)
+		}
+		pages[i] = groupLines(content.Text)
+		hasText = hasText || len(pages[i]) > 0
+	}
+	if !hasText && hasImages(f) {
+		return nil, ErrScanOnly
+	}
+
+	info := docInfo(f)
+	newMeta := func() map[string]any {
+		meta := make(map[string]any, len(extraMeta)+len(info)+2)
+		for k, v := range extraMeta {
+			meta[k] = v
+		}
+		for k, v := range info {
+			meta[k] = v
+		}
+		return meta
+	}
+
+	bodySize, levels := headingLevels(pages)
+
+	var (
+		docs        []*schema.Document
+		sb          strings.Builder
+		allHeadings []string
+	)
+	for i, lines := range pages {
+		text, headings := renderPage(lines, bodySize, levels)
+		if toPages {
+			meta := newMeta()
+			meta[MetaKeyPage] = i + 1
+			meta[MetaKeyHeadings] = headings
+			docs = append(docs, &schema.Document{
+				Content:  text,
+				MetaData: meta,
+			})
+			continue
+		}
+
+		if text != "" {
+			if sb.Len() > 0 {
+				sb.WriteString("\n\n")
+			}
+			sb.WriteString(text)
+		}
+		allHeadings = append(allHeadings, headings...)
+	}
+
+	if !toPages {
+		meta := newMeta()
+		meta[MetaKeyHeadings] = allHeadings
+		docs = append(docs, &schema.Document{
+			Content:  sb.String(),
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+// docInfo returns the document info in the trailer.
+func docInfo(f *pdf.Reader) map[string]any {
+	info := f.Trailer().Key("Info")
+	if info.IsNull() {
+		return nil
+	}
+
+	meta := make(map[string]any)
+	for key, metaKey := range map[string]string{
+		"Title":   MetaKeyTitle,
+		"Author":  MetaKeyAuthor,
+		"Subject": MetaKeySubject,
+	} {
+		if v := strings.TrimSpace(info.Key(key).Text()); v != "" {
+			meta[metaKey] = v
+		}
+	}
+	if t, ok := parseDate(info.Key("CreationDate").Text()); ok {
+		meta[MetaKeyCreationDate] = t
+	}
+	return meta
+}
+
+// parseDate parses a PDF date string, e.g. "D:20250102150405+08'00'".
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "D:")
+	if len(s) < 4 {
+		return time.Time{}, false
+	}
+
+	// digits of year, month, day, hour, minute and second, later fields are optional
+	fields := []int{4, 2, 2, 2, 2, 2}
+	values := []int{0, 1, 1, 0, 0, 0}
+	pos := 0
+	for i, n := range fields {
+		if pos+n > len(s) || s[pos] < '0' || s[pos] > '9' {
+			break
+		}
+		v, err := strconv.Atoi(s[pos : pos+n])
+		if err != nil {
+			return time.Time{}, false
+		}
+		values[i] = v
+		pos += n
+	}
+
+	loc := time.UTC
+	if rest := s[pos:]; len(rest) >= 3 && (rest[0] == '+' || rest[0] == '-') {
+		tz := strings.ReplaceAll(rest[1:], "'", "")
+		hour, _ := strconv.Atoi(tz[:min(2, len(tz))])
+		minute := 0
+		if len(tz) >= 4 {
+			minute, _ = strconv.Atoi(tz[2:4])
+		}
+		offset := hour*3600 + minute*60
+		if rest[0] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+
+	return time.Date(values[0], time.Month(values[1]), values[2], values[3], values[4], values[5], 0, loc), true
+}
+
+// hasImages reports whether any page of the PDF draws an image.
+func hasImages(f *pdf.Reader) bool {
+	for i := 1; i <= f.NumPage(); i++ {
+		xObjects := f.Page(i).Resources().Key("XObject")
+		for _, name := range xObjects.Keys() {
+			if xObjects.Key(name).Key("Subtype").Name() == "Image" {
+				return true
+			}
+		}
+	}
+	return false
+}
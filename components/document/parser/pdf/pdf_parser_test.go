@@ -17,9 +17,13 @@
 package pdf
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
@@ -43,4 +47,166 @@ func TestLoader_Load(t *testing.T) {
 		assert.True(t, len(docs[0].Content) > 0)
 		assert.Equal(t, map[string]any{"test": "test"}, docs[1].MetaData)
 	})
+
+	t.Run("TestLoader_LoadLayout", func(t *testing.T) {
+		ctx := context.Background()
+
+		f, err := os.Open("./testdata/test_pdf.pdf")
+		assert.NoError(t, err)
+
+		p, err := NewPDFParser(ctx, &Config{ToPages: true, Layout: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.True(t, len(docs[0].Content) > 0)
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPage])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPage])
+	})
+}
+
+// buildPDF writes a minimal PDF with the given objects, object i is numbered i+1.
+func buildPDF(objects []string, trailer string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R %s >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailer, xref)
+	return buf.Bytes()
+}
+
+func stream(data string) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(data), data)
+}
+
+// text draws s at (x, y) with font size.
+func text(size, x, y int, s string) string {
+	return fmt.Sprintf("BT /F1 %d Tf 1 0 0 1 %d %d Tm (%s) Tj ET\n", size, x, y, s)
+}
+
+// testPDF builds a PDF with a page for every content stream, info is the document info dict.
+func testPDF(info string, contents ...string) []byte {
+	widths := strings.TrimSpace(strings.Repeat("500 ", 95))
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // pages
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /FirstChar 32 /LastChar 126 /Widths [" + widths + "] >>",
+		"<< /Type /XObject /Subtype /Image /Width 1 /Height 1 /ColorSpace /DeviceGray /BitsPerComponent 8 /Length 1 >>\nstream\n\x00\nendstream",
+		info,
+	}
+	var kids []string
+	for _, content := range contents {
+		page := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> /XObject << /Im1 4 0 R >> >> /Contents %d 0 R >>", page+1),
+			stream(content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+	trailer := ""
+	if info != "" {
+		trailer = "/Info 5 0 R"
+	} else {
+		objects[4] = "<< >>"
+	}
+	return buildPDF(objects, trailer)
+}
+
+func TestPDFParser_Layout(t *testing.T) {
+	ctx := context.Background()
+
+	page1 := text(24, 72, 740, "Annual Report") +
+		text(12, 72, 700, "This is the first line.") +
+		text(12, 72, 686, "and it continues here.") +
+		text(12, 72, 650, "Second paragraph.") +
+		text(16, 72, 610, "Results") +
+		text(12, 72, 580, "Name") + text(12, 200, 580, "Score") + text(12, 320, 580, "Rank") +
+		text(12, 72, 566, "Alice") + text(12, 200, 566, "90") + text(12, 320, 566, "1") +
+		text(12, 72, 552, "Bob") + text(12, 200, 552, "85") + text(12, 320, 552, "2") +
+		text(12, 72, 520, "End of page one.")
+	page2 := text(16, 72, 740, "Appendix") +
+		text(12, 72, 700, "See page one.")
+	data := testPDF("<< /Title (Annual Report) /Author (Jane) /CreationDate (D:20250102030405+08'00') >>", page1, page2)
+
+	p, err := NewPDFParser(ctx, &Config{Layout: true})
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(ctx, bytes.NewReader(data), WithToPages(true), parser.WithExtraMeta(map[string]any{"test": "test"}))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(docs))
+	assert.Equal(t, "# Annual Report\n\n"+
+		"This is the first line.\nand it continues here.\n\n"+
+		"Second paragraph.\n\n"+
+		"## Results\n\n"+
+		"| Name | Score | Rank |\n| --- | --- | --- |\n| Alice | 90 | 1 |\n| Bob | 85 | 2 |\n\n"+
+		"End of page one.", docs[0].Content)
+	assert.Equal(t, "## Appendix\n\nSee page one.", docs[1].Content)
+
+	meta := docs[0].MetaData
+	assert.Equal(t, "test", meta["test"])
+	assert.Equal(t, 1, meta[MetaKeyPage])
+	assert.Equal(t, []string{"Annual Report", "Results"}, meta[MetaKeyHeadings])
+	assert.Equal(t, "Annual Report", meta[MetaKeyTitle])
+	assert.Equal(t, "Jane", meta[MetaKeyAuthor])
+	assert.True(t, time.Date(2025, 1, 1, 19, 4, 5, 0, time.UTC).Equal(meta[MetaKeyCreationDate].(time.Time)))
+	assert.Equal(t, 2, docs[1].MetaData[MetaKeyPage])
+
+	docs, err = p.Parse(ctx, bytes.NewReader(data), WithToPages(false))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(docs))
+	assert.True(t, strings.HasSuffix(docs[0].Content, "End of page one.\n\n## Appendix\n\nSee page one."))
+	assert.Equal(t, []string{"Annual Report", "Results", "Appendix"}, docs[0].MetaData[MetaKeyHeadings])
+	assert.Nil(t, docs[0].MetaData[MetaKeyPage])
+}
+
+func TestPDFParser_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("scan only", func(t *testing.T) {
+		data := testPDF("", "q 612 0 0 792 0 0 cm /Im1 Do Q\n")
+
+		p, err := NewPDFParser(ctx, &Config{Layout: true})
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.ErrorIs(t, err, ErrScanOnly)
+
+		_, err = p.Parse(ctx, bytes.NewReader(data), WithLayout(false))
+		assert.ErrorIs(t, err, ErrScanOnly)
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		data := buildPDF([]string{
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [] /Count 0 >>",
+			"<< /Filter /Standard /V 1 /R 2 /O (0123456789abcdef0123456789abcdef) /U (0123456789abcdef0123456789abcdef) /P -4 >>",
+		}, "/Encrypt 3 0 R /ID [(0123456789abcdef) (0123456789abcdef)]")
+
+		p, err := NewPDFParser(ctx, nil)
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.ErrorIs(t, err, ErrEncrypted)
+	})
+}
+
+func TestParseDate(t *testing.T) {
+	tm, ok := parseDate("D:20250102030405Z")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), tm)
+
+	tm, ok = parseDate("D:2025-")
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), tm)
+
+	_, ok = parseDate("")
+	assert.False(t, ok)
 }
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const relationshipsNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+// xmlNode is a generic element of the PresentationML parts.
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []xmlNode  `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+func (n *xmlNode) attr(local string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == local && a.Name.Space != relationshipsNS {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// relID returns the relationship id referenced by the r:id attribute.
+func (n *xmlNode) relID() string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == "id" && a.Name.Space == relationshipsNS {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n *xmlNode) child(local string) *xmlNode {
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local == local {
+			return &n.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// path returns the descendant following the local names, or nil if not found.
+func (n *xmlNode) path(locals ...string) *xmlNode {
+	for _, local := range locals {
+		if n = n.child(local); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// pptxPackage holds the parts of a pptx file.
+type pptxPackage struct {
+	files map[string]*zip.File
+}
+
+func openPPTXPackage(data []byte) (*pptxPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open pptx package failed: %w", err)
+	}
+	pkg := &pptxPackage{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		pkg.files[f.Name] = f
+	}
+	if _, ok := pkg.files["ppt/presentation.xml"]; !ok {
+		return nil, fmt.Errorf("open pptx package failed: ppt/presentation.xml not found")
+	}
+	return pkg, nil
+}
+
+// part reads and decodes a part, a missing part returns nil without error.
+func (p *pptxPackage) part(name string) (*xmlNode, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open pptx part [%s] failed: %w", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read pptx part [%s] failed: %w", name, err)
+	}
+	root := &xmlNode{}
+	if err = xml.Unmarshal(data, root); err != nil {
+		return nil, fmt.Errorf("decode pptx part [%s] failed: %w", name, err)
+	}
+	return root, nil
+}
+
+// relationships returns the targets of the relationships of a part by id, resolved to part names.
+func (p *pptxPackage) relationships(name string) (map[string]string, error) {
+	rels, err := p.part(path.Join(path.Dir(name), "_rels", path.Base(name)+".rels"))
+	if err != nil || rels == nil {
+		return nil, err
+	}
+	ret := make(map[string]string, len(rels.Nodes))
+	for _, rel := range rels.Nodes {
+		if rel.attr("TargetMode") == "External" {
+			continue
+		}
+		target := rel.attr("Target")
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join(path.Dir(name), target)
+		}
+		ret[rel.attr("Id")] = target
+	}
+	return ret, nil
+}
+
+// relationshipOfType returns the target of the first relationship of a part with the type suffix, e.g. "/notesSlide".
+func (p *pptxPackage) relationshipOfType(name, typ string) (string, error) {
+	rels, err := p.part(path.Join(path.Dir(name), "_rels", path.Base(name)+".rels"))
+	if err != nil || rels == nil {
+		return "", err
+	}
+	for _, rel := range rels.Nodes {
+		if strings.HasSuffix(rel.attr("Type"), typ) && rel.attr("TargetMode") != "External" {
+			return path.Join(path.Dir(name), rel.attr("Target")), nil
+		}
+	}
+	return "", nil
+}
+
+// slides returns the part names of the slides in presentation order.
+func (p *pptxPackage) slides() ([]string, error) {
+	const name = "ppt/presentation.xml"
+	pres, err := p.part(name)
+	if err != nil {
+		return nil, err
+	}
+	rels, err := p.relationships(name)
+	if err != nil {
+		return nil, err
+	}
+	list := pres.child("sldIdLst")
+	if list == nil {
+		return nil, nil
+	}
+	var ret []string
+	for _, id := range list.Nodes {
+		if target, ok := rels[id.relID()]; ok {
+			ret = append(ret, target)
+		}
+	}
+	return ret, nil
+}
+
+// coreProperties returns the title and author in docProps/core.xml.
+func (p *pptxPackage) coreProperties() (title, author string, err error) {
+	core, err := p.part("docProps/core.xml")
+	if err != nil || core == nil {
+		return "", "", err
+	}
+	if n := core.child("title"); n != nil {
+		title = strings.TrimSpace(n.Text)
+	}
+	if n := core.child("creator"); n != nil {
+		author = strings.TrimSpace(n.Text)
+	}
+	return title, author, nil
+}
@@ -0,0 +1,291 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySlide      = "_slide"       // slide number starting from 1
+	MetaKeySlideTitle = "_slide_title" // text of the title placeholder, not set if the slide has no title
+	MetaKeyNotes      = "_notes"       // speaker notes of the slide, not set if the slide has no notes
+	MetaKeyTitle      = "_title"       // title in the document properties
+	MetaKeyAuthor     = "_author"      // author in the document properties
+)
+
+// Config is the configuration for PPTX parser.
+type Config struct {
+	SkipNotes  bool // whether to exclude speaker notes from the content and metadata
+	SkipHidden bool // whether to skip hidden slides
+}
+
+// PPTXParser reads from io.Reader and parses every slide of a PowerPoint (pptx) file into a document.
+// The content of a slide is the text of its shapes in order, starting with the title, tables are rendered as
+// Markdown, and speaker notes are appended after a "Notes:" line.
+type PPTXParser struct {
+	skipNotes  bool
+	skipHidden bool
+}
+
+// NewPPTXParser creates a new PPTX parser.
+func NewPPTXParser(_ context.Context, config *Config) (*PPTXParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	return &PPTXParser{
+		skipNotes:  config.SkipNotes,
+		skipHidden: config.SkipHidden,
+	}, nil
+}
+
+// Parse parses the PPTX content from io.Reader, slides without text are skipped.
+func (pp *PPTXParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("pptx parser failed to read content: %w", err)
+	}
+	pkg, err := openPPTXPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	title, author, err := pkg.coreProperties()
+	if err != nil {
+		return nil, err
+	}
+	slides, err := pkg.slides()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range slides {
+		slide, err := pkg.part(name)
+		if err != nil {
+			return nil, err
+		}
+		if slide == nil || (pp.skipHidden && slide.attr("show") == "0") {
+			continue
+		}
+		s := &slideText{}
+		if tree := slide.path("cSld", "spTree"); tree != nil {
+			s.walk(tree, false)
+		}
+
+		var notes string
+		if !pp.skipNotes {
+			if notes, err = pkg.notes(name); err != nil {
+				return nil, err
+			}
+		}
+
+		content := strings.Join(s.blocks, "\n\n")
+		if notes != "" {
+			if content != "" {
+				content += "\n\n"
+			}
+			content += "Notes:\n" + notes
+		}
+		if content == "" {
+			continue
+		}
+
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+5)
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeySlide] = i + 1
+		if s.title != "" {
+			meta[MetaKeySlideTitle] = s.title
+		}
+		if notes != "" {
+			meta[MetaKeyNotes] = notes
+		}
+		if title != "" {
+			meta[MetaKeyTitle] = title
+		}
+		if author != "" {
+			meta[MetaKeyAuthor] = author
+		}
+		docs = append(docs, &schema.Document{
+			Content:  content,
+			MetaData: meta,
+		})
+	}
+	return docs, nil
+}
+
+// notes returns the text of the body placeholders in the notes slide of a slide.
+func (p *pptxPackage) notes(slide string) (string, error) {
+	name, err := p.relationshipOfType(slide, "/notesSlide")
+	if err != nil || name == "" {
+		return "", err
+	}
+	notes, err := p.part(name)
+	if err != nil || notes == nil {
+		return "", err
+	}
+	s := &slideText{}
+	if tree := notes.path("cSld", "spTree"); tree != nil {
+		s.walk(tree, true)
+	}
+	return strings.Join(s.blocks, "\n\n"), nil
+}
+
+// placeholders whose text is generated, e.g. slide numbers, or repeated on every slide.
+var skippedPlaceholders = map[string]bool{
+	"sldNum": true,
+	"dt":     true,
+	"ftr":    true,
+	"hdr":    true,
+	"sldImg": true,
+}
+
+// slideText collects the text of the shapes in a shape tree.
+type slideText struct {
+	title  string
+	blocks []string
+}
+
+// walk visits the shapes in document order, only body placeholders are collected if bodyOnly.
+func (s *slideText) walk(tree *xmlNode, bodyOnly bool) {
+	for i := range tree.Nodes {
+		shape := &tree.Nodes[i]
+		switch shape.XMLName.Local {
+		case "grpSp":
+			s.walk(shape, bodyOnly)
+		case "sp":
+			typ, isPlaceholder := "", false
+			if ph := shape.path("nvSpPr", "nvPr", "ph"); ph != nil {
+				typ, isPlaceholder = ph.attr("type"), true
+			}
+			if skippedPlaceholders[typ] || (bodyOnly && (!isPlaceholder || (typ != "body" && typ != ""))) {
+				continue
+			}
+			body := shape.child("txBody")
+			if body == nil {
+				continue
+			}
+			text := textBody(body)
+			if text == "" {
+				continue
+			}
+			if (typ == "title" || typ == "ctrTitle") && s.title == "" {
+				s.title = strings.Join(strings.Fields(text), " ")
+				s.blocks = append([]string{text}, s.blocks...)
+				continue
+			}
+			s.blocks = append(s.blocks, text)
+		case "graphicFrame":
+			if bodyOnly {
+				continue
+			}
+			if tbl := shape.path("graphic", "graphicData", "tbl"); tbl != nil {
+				if table := renderTable(tbl); table != "" {
+					s.blocks = append(s.blocks, table)
+				}
+			}
+		}
+	}
+}
+
+// textBody returns the text of the paragraphs in a text body, one line for each paragraph.
+func textBody(body *xmlNode) string {
+	var lines []string
+	for i := range body.Nodes {
+		p := &body.Nodes[i]
+		if p.XMLName.Local != "p" {
+			continue
+		}
+		var sb strings.Builder
+		for j := range p.Nodes {
+			switch run := &p.Nodes[j]; run.XMLName.Local {
+			case "r", "fld":
+				if t := run.child("t"); t != nil {
+					sb.WriteString(t.Text)
+				}
+			case "br":
+				sb.WriteString("\n")
+			}
+		}
+		if line := strings.TrimSpace(sb.String()); line != "" {
+			if pPr := p.child("pPr"); pPr != nil {
+				if lvl, err := strconv.Atoi(pPr.attr("lvl")); err == nil && lvl > 0 {
+					line = strings.Repeat("  ", lvl) + line
+				}
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTable renders a table as Markdown, the first row is the header.
+func renderTable(tbl *xmlNode) string {
+	var rows [][]string
+	width := 0
+	for i := range tbl.Nodes {
+		tr := &tbl.Nodes[i]
+		if tr.XMLName.Local != "tr" {
+			continue
+		}
+		var row []string
+		for j := range tr.Nodes {
+			tc := &tr.Nodes[j]
+			if tc.XMLName.Local != "tc" {
+				continue
+			}
+			var cell string
+			if body := tc.child("txBody"); body != nil && tc.attr("hMerge") == "" && tc.attr("vMerge") == "" {
+				cell = strings.Join(strings.Fields(textBody(body)), " ")
+			}
+			row = append(row, strings.ReplaceAll(cell, "|", `\|`))
+		}
+		width = max(width, len(row))
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 || width == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i := 0; i < width; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
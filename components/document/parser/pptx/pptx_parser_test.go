@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document/parser"
+)
+
+const (
+	testNS = `xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" ` +
+		`xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	testRelNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+func buildZip(t *testing.T, parts map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// shape returns a shape with the placeholder type and paragraphs.
+func shape(phType string, paragraphs ...string) string {
+	ph := ""
+	if phType != "-" {
+		ph = `<p:ph type="` + phType + `"/>`
+		if phType == "" {
+			ph = `<p:ph idx="1"/>`
+		}
+	}
+	body := ""
+	for _, p := range paragraphs {
+		body += `<a:p><a:r><a:t>` + p + `</a:t></a:r></a:p>`
+	}
+	return `<p:sp><p:nvSpPr><p:cNvPr id="1" name="s"/><p:cNvSpPr/><p:nvPr>` + ph + `</p:nvPr></p:nvSpPr>` +
+		`<p:txBody><a:bodyPr/>` + body + `</p:txBody></p:sp>`
+}
+
+func slide(attrs string, shapes ...string) string {
+	tree := ""
+	for _, s := range shapes {
+		tree += s
+	}
+	return `<p:sld ` + testNS + attrs + `><p:cSld><p:spTree>` + tree + `</p:spTree></p:cSld></p:sld>`
+}
+
+func cell(text string) string {
+	return `<a:tc><a:txBody><a:bodyPr/><a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></a:txBody></a:tc>`
+}
+
+func buildPPTX(t *testing.T) []byte {
+	table := `<p:graphicFrame><p:nvGraphicFramePr><p:cNvPr id="5" name="t"/><p:cNvGraphicFramePr/><p:nvPr/></p:nvGraphicFramePr>` +
+		`<a:graphic><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/table"><a:tbl>` +
+		`<a:tr>` + cell("Year") + cell("Revenue") + `</a:tr><a:tr>` + cell("2024") + cell("1|2") + `</a:tr>` +
+		`</a:tbl></a:graphicData></a:graphic></p:graphicFrame>`
+	return buildZip(t, map[string]string{
+		"ppt/presentation.xml": `<p:presentation ` + testNS + `><p:sldIdLst>` +
+			`<p:sldId id="256" r:id="rId3"/><p:sldId id="257" r:id="rId2"/><p:sldId id="258" r:id="rId4"/>` +
+			`</p:sldIdLst></p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId2" Type="` + testRelNS + `/slide" Target="slides/slide1.xml"/>` +
+			`<Relationship Id="rId3" Type="` + testRelNS + `/slide" Target="slides/slide2.xml"/>` +
+			`<Relationship Id="rId4" Type="` + testRelNS + `/slide" Target="/ppt/slides/slide3.xml"/>` +
+			`</Relationships>`,
+		"ppt/slides/slide2.xml": slide("", shape("ctrTitle", "Quarterly", "Review"), shape("subTitle", "Finance team"), shape("sldNum", "1")),
+		"ppt/slides/slide1.xml": slide("",
+			`<p:grpSp>`+shape("-", "Grouped text")+`</p:grpSp>`,
+			shape("title", "Results"),
+			table,
+		),
+		"ppt/slides/_rels/slide1.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + testRelNS + `/slideLayout" Target="../slideLayouts/slideLayout1.xml"/>` +
+			`<Relationship Id="rId2" Type="` + testRelNS + `/notesSlide" Target="../notesSlides/notesSlide1.xml"/>` +
+			`</Relationships>`,
+		"ppt/notesSlides/notesSlide1.xml": `<p:notes ` + testNS + `><p:cSld><p:spTree>` +
+			shape("sldImg") + shape("body", "Mention the growth.") + shape("sldNum", "2") +
+			`</p:spTree></p:cSld></p:notes>`,
+		"ppt/slides/slide3.xml": slide(` show="0"`, shape("title", "Backup")),
+		"docProps/core.xml": `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+			`xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Review Deck</dc:title><dc:creator>Alice</dc:creator></cp:coreProperties>`,
+	})
+}
+
+func TestPPTXParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	data := buildPPTX(t)
+
+	p, err := NewPPTXParser(ctx, nil)
+	assert.NoError(t, err)
+	docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithExtraMeta(map[string]any{"test": "test"}))
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(docs)) {
+		assert.Equal(t, "Quarterly\nReview\n\nFinance team", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			"test":            "test",
+			MetaKeySlide:      1,
+			MetaKeySlideTitle: "Quarterly Review",
+			MetaKeyTitle:      "Review Deck",
+			MetaKeyAuthor:     "Alice",
+		}, docs[0].MetaData)
+
+		assert.Equal(t, "Results\n\nGrouped text\n\n| Year | Revenue |\n| --- | --- |\n| 2024 | 1\\|2 |\n\nNotes:\nMention the growth.", docs[1].Content)
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeySlide])
+		assert.Equal(t, "Results", docs[1].MetaData[MetaKeySlideTitle])
+		assert.Equal(t, "Mention the growth.", docs[1].MetaData[MetaKeyNotes])
+
+		assert.Equal(t, "Backup", docs[2].Content)
+		assert.Equal(t, 3, docs[2].MetaData[MetaKeySlide])
+	}
+
+	p, err = NewPPTXParser(ctx, &Config{SkipNotes: true, SkipHidden: true})
+	assert.NoError(t, err)
+	docs, err = p.Parse(ctx, bytes.NewReader(data))
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(docs)) {
+		assert.NotContains(t, docs[1].Content, "Notes:")
+		assert.Nil(t, docs[1].MetaData[MetaKeyNotes])
+	}
+}
+
+func TestPPTXParser_ExtParser(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewPPTXParser(ctx, nil)
+	assert.NoError(t, err)
+	ext, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{Parsers: map[string]parser.Parser{".pptx": p}})
+	assert.NoError(t, err)
+	docs, err := ext.Parse(ctx, bytes.NewReader(buildPPTX(t)), parser.WithURI("deck.pptx"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(docs))
+
+	_, err = p.Parse(ctx, bytes.NewReader([]byte("not a zip")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rtf
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// maxDepth limits the nesting of groups.
+const maxDepth = 1024
+
+// skippedDestinations are the destinations whose content is not text of the document.
+var skippedDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "listtable": true, "listoverridetable": true,
+	"revtbl": true, "rsidtbl": true, "filetbl": true, "pgdsctbl": true, "xmlnstbl": true, "generator": true,
+	"pict": true, "object": true, "objdata": true, "nonshppict": true, "shpinst": true, "themedata": true,
+	"colorschememapping": true, "latentstyles": true, "datastore": true, "datafield": true, "mmathPr": true,
+	"fldinst": true, "bkmkstart": true, "bkmkend": true, "footnote": true, "annotation": true, "atnid": true,
+	"header": true, "headerl": true, "headerr": true, "headerf": true,
+	"footer": true, "footerl": true, "footerr": true, "footerf": true,
+}
+
+// infoFields are the fields of the information group recorded in metadata.
+var infoFields = map[string]bool{"title": true, "author": true, "subject": true}
+
+// symbols are the control words of special characters.
+var symbols = map[string]string{
+	"par": "\n", "line": "\n", "sect": "\n", "page": "\n", "row": "\n", "nestrow": "\n",
+	"tab": "\t", "cell": " | ", "nestcell": " | ",
+	"emdash": "—", "endash": "–", "bullet": "•", "emspace": " ", "enspace": " ", "qmspace": " ",
+	"lquote": "‘", "rquote": "’", "ldblquote": "“", "rdblquote": "”",
+}
+
+// cp1252 maps the bytes 0x80 to 0x9f of windows-1252 to runes, the other bytes are the same as latin-1.
+var cp1252 = [32]rune{
+	'€', '�', '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', '�', 'Ž', '�',
+	'�', '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', '�', 'ž', 'Ÿ',
+}
+
+// groupState is the state of a group, inherited by nested groups.
+type groupState struct {
+	skip bool   // the content is not text of the document
+	info string // the info field the content belongs to
+	uc   int    // number of fallback characters following \u
+}
+
+// decoder extracts the text and the information fields of RTF content.
+type decoder struct {
+	data  []byte
+	pos   int
+	stack []groupState
+	state groupState
+	// skipChars is the number of remaining fallback characters of a \u to skip
+	skipChars int
+	// highSurrogate is the pending high surrogate of a \u, combined with the low surrogate of the next \u
+	highSurrogate rune
+	text      bytes.Buffer
+	info      map[string]string
+}
+
+func newDecoder(data []byte) *decoder {
+	return &decoder{
+		data:  data,
+		state: groupState{uc: 1},
+		info:  make(map[string]string),
+	}
+}
+
+func (d *decoder) decode() error {
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		d.pos++
+		switch c {
+		case '{':
+			if len(d.stack) >= maxDepth {
+				return errors.New("groups are nested too deeply")
+			}
+			d.stack = append(d.stack, d.state)
+		case '}':
+			if len(d.stack) == 0 {
+				// content after the closing brace of the document is ignored
+				d.flushSurrogate()
+				return nil
+			}
+			d.state = d.stack[len(d.stack)-1]
+			d.stack = d.stack[:len(d.stack)-1]
+			d.skipChars = 0
+		case '\\':
+			if err := d.control(); err != nil {
+				return err
+			}
+		case '\r', '\n':
+		default:
+			d.char(decodeByte(c))
+		}
+	}
+	d.flushSurrogate()
+	return nil
+}
+
+// control handles a control word or a control symbol after a backslash.
+func (d *decoder) control() error {
+	if d.pos >= len(d.data) {
+		return nil
+	}
+	c := d.data[d.pos]
+	if !isLetter(c) {
+		d.pos++
+		switch c {
+		case '\'':
+			if d.pos+2 > len(d.data) {
+				return errors.New("incomplete hex escape")
+			}
+			b, err := strconv.ParseUint(string(d.data[d.pos:d.pos+2]), 16, 8)
+			if err != nil {
+				return errors.New("invalid hex escape")
+			}
+			d.pos += 2
+			d.char(decodeByte(byte(b)))
+		case '\\', '{', '}':
+			d.char(rune(c))
+		case '~':
+			d.char(' ')
+		case '_':
+			d.char('-')
+		case '*':
+			d.state.skip = true
+		case '\r', '\n':
+			d.write("\n")
+		}
+		return nil
+	}
+
+	start := d.pos
+	for d.pos < len(d.data) && isLetter(d.data[d.pos]) {
+		d.pos++
+	}
+	word := string(d.data[start:d.pos])
+	param, hasParam := 0, false
+	if d.pos < len(d.data) && (d.data[d.pos] == '-' || isDigit(d.data[d.pos])) {
+		numStart := d.pos
+		d.pos++
+		for d.pos < len(d.data) && isDigit(d.data[d.pos]) {
+			d.pos++
+		}
+		if n, err := strconv.Atoi(string(d.data[numStart:d.pos])); err == nil {
+			param, hasParam = n, true
+		}
+	}
+	if d.pos < len(d.data) && d.data[d.pos] == ' ' {
+		d.pos++
+	}
+
+	switch {
+	case word == "bin":
+		// binary data is not text
+		d.pos = min(d.pos+max(param, 0), len(d.data))
+	case word == "u" && hasParam:
+		if param < 0 {
+			param += 65536
+		}
+		r := rune(param)
+		switch {
+		case r >= 0xd800 && r < 0xdc00:
+			// characters out of the BMP are written as a UTF-16 surrogate pair of \u
+			d.flushSurrogate()
+			d.highSurrogate = r
+		case utf16.IsSurrogate(r) && d.highSurrogate != 0:
+			r, d.highSurrogate = utf16.DecodeRune(d.highSurrogate, r), 0
+			d.char(r)
+		default:
+			d.char(r)
+		}
+		d.skipChars = d.state.uc
+	case word == "uc" && hasParam:
+		d.state.uc = max(param, 0)
+	case word == "info":
+		d.state.skip = true
+	case infoFields[word] && d.state.skip:
+		d.state.info = word
+	case skippedDestinations[word]:
+		d.state.skip = true
+	default:
+		if s, ok := symbols[word]; ok {
+			if word == "row" || word == "nestrow" {
+				d.trimCell()
+			}
+			d.write(s)
+		}
+	}
+	return nil
+}
+
+// char writes a character of the content, unless it is a fallback character of \u.
+func (d *decoder) char(r rune) {
+	if d.skipChars > 0 {
+		d.skipChars--
+		return
+	}
+	var buf [utf8.UTFMax]byte
+	d.write(string(buf[:utf8.EncodeRune(buf[:], r)]))
+}
+
+func (d *decoder) write(s string) {
+	d.flushSurrogate()
+	switch {
+	case d.state.info != "":
+		d.info[d.state.info] += s
+	case !d.state.skip:
+		d.text.WriteString(s)
+	}
+}
+
+// flushSurrogate writes a pending high surrogate which is not followed by a low surrogate as U+FFFD.
+func (d *decoder) flushSurrogate() {
+	if d.highSurrogate == 0 {
+		return
+	}
+	d.highSurrogate = 0
+	d.write(string(utf8.RuneError))
+}
+
+// trimCell removes the separator after the last cell of a row.
+func (d *decoder) trimCell() {
+	if !d.state.skip && bytes.HasSuffix(d.text.Bytes(), []byte(symbols["cell"])) {
+		d.text.Truncate(d.text.Len() - len(symbols["cell"]))
+	}
+}
+
+func decodeByte(b byte) rune {
+	if b >= 0x80 && b < 0xa0 {
+		return cp1252[b-0x80]
+	}
+	return rune(b)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
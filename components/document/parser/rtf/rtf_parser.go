@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rtf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyTitle   = "_title"   // title in the document information group
+	MetaKeyAuthor  = "_author"  // author in the document information group
+	MetaKeySubject = "_subject" // subject in the document information group
+)
+
+// Config is the configuration for RTF parser.
+type Config struct {
+	// KeepEmptyLines keeps the empty paragraphs, by default consecutive empty paragraphs are collapsed into one.
+	KeepEmptyLines bool
+}
+
+// RTFParser reads from io.Reader and parses Rich Text Format content as plain text.
+// Paragraphs and line breaks are kept, table cells are separated by " | ", and pictures, objects,
+// fonts, styles, headers and footers are dropped. Unicode escapes are decoded, and other non-ASCII bytes are
+// decoded as windows-1252, the default code page of most RTF writers.
+type RTFParser struct {
+	keepEmptyLines bool
+}
+
+// NewRTFParser creates a new RTF parser.
+func NewRTFParser(_ context.Context, config *Config) (*RTFParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	return &RTFParser{keepEmptyLines: config.KeepEmptyLines}, nil
+}
+
+// Parse parses the RTF content from io.Reader into a document.
+func (rp *RTFParser) Parse(_ context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("rtf parser failed to read content: %w", err)
+	}
+	if !strings.HasPrefix(strings.TrimLeft(string(data[:min(len(data), 16)]), " \t\r\n"), `{\rtf`) {
+		return nil, fmt.Errorf("rtf parser failed to parse content: missing {\\rtf header")
+	}
+
+	d := newDecoder(data)
+	if err = d.decode(); err != nil {
+		return nil, fmt.Errorf("rtf parser failed to parse content: %w", err)
+	}
+
+	lines := strings.Split(d.text.String(), "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && !rp.keepEmptyLines && (len(out) == 0 || out[len(out)-1] == "") {
+			continue
+		}
+		out = append(out, line)
+	}
+	content := strings.TrimSpace(strings.Join(out, "\n"))
+	if content == "" {
+		return nil, nil
+	}
+
+	meta := make(map[string]any, len(commonOpts.ExtraMeta)+3)
+	for k, v := range commonOpts.ExtraMeta {
+		meta[k] = v
+	}
+	for key, value := range map[string]string{MetaKeyTitle: d.info["title"], MetaKeyAuthor: d.info["author"], MetaKeySubject: d.info["subject"]} {
+		if value = strings.TrimSpace(value); value != "" {
+			meta[key] = value
+		}
+	}
+	return []*schema.Document{{
+		Content:  content,
+		MetaData: meta,
+	}}, nil
+}
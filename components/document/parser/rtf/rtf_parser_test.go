@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rtf
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/document/parser"
+)
+
+const testRTF = `{\rtf1\ansi\ansicpg1252\deff0
+{\fonttbl{\f0\fswiss Helvetica;}{\f1 Courier;}}
+{\colortbl;\red255\green0\blue0;}
+{\*\generator Writer 1.0;}
+{\info{\title Quarterly \'93Report\'94}{\author Alice}{\*\company ACME}}
+{\header\pard Page header\par}
+\pard\plain\f0\fs24 {\b Hello}, world!\par
+Caf\'e9 \u8364? and \uc2 \u20013\'d6\'d0 text\line next line\par
+\par
+\par
+{\pict\wmetafile8 0100090000}
+\trowd\cellx1000\cellx2000
+a\cell b\cell\row
+Tab\tab stop \{braces\} \\ done\emdash end\par
+{\field{\*\fldinst HYPERLINK "https://example.com"}{\fldrslt link}}\par
+}`
+
+func TestRTFParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewRTFParser(ctx, nil)
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(ctx, strings.NewReader(testRTF), parser.WithExtraMeta(map[string]any{"test": "test"}))
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(docs)) {
+		assert.Equal(t, "Hello, world!\nCafé € and 中 text\nnext line\n\na | b\nTab\tstop {braces} \\ done—end\nlink", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			"test":        "test",
+			MetaKeyTitle:  "Quarterly “Report”",
+			MetaKeyAuthor: "Alice",
+		}, docs[0].MetaData)
+	}
+
+	p, err = NewRTFParser(ctx, &Config{KeepEmptyLines: true})
+	assert.NoError(t, err)
+	docs, err = p.Parse(ctx, strings.NewReader(testRTF))
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(docs)) {
+		assert.Contains(t, docs[0].Content, "next line\n\n\na | b")
+	}
+}
+
+func TestRTFParser_SurrogatePair(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewRTFParser(ctx, nil)
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(ctx, strings.NewReader(`{\rtf1\ansi smile \u-10179?\u-8701? {\uc0 \u-10179\u-8701} lone \u-10179?x\u-8701?}`))
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(docs)) {
+		assert.Equal(t, "smile 😃 😃 lone \uFFFDx\uFFFD", docs[0].Content)
+	}
+}
+
+func TestRTFParser_Invalid(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewRTFParser(ctx, nil)
+	assert.NoError(t, err)
+
+	_, err = p.Parse(ctx, strings.NewReader("plain text"))
+	assert.Error(t, err)
+	_, err = p.Parse(ctx, strings.NewReader(`{\rtf1 bad \'zz escape}`))
+	assert.Error(t, err)
+	_, err = p.Parse(ctx, strings.NewReader(`{\rtf1 `+strings.Repeat("{", maxDepth+1)))
+	assert.Error(t, err)
+	docs, err := p.Parse(ctx, strings.NewReader(`{\rtf1 {\fonttbl{\f0 Arial;}}}`))
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+}
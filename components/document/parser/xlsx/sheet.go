@@ -0,0 +1,272 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xlsx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is the format of the parsed content.
+type Format string
+
+const (
+	FormatXLSX Format = "xlsx"
+	FormatCSV  Format = "csv"
+	FormatTSV  Format = "tsv"
+)
+
+// formatOf returns the format of uri by its extension, FormatXLSX by default.
+func formatOf(uri string) Format {
+	switch strings.ToLower(filepath.Ext(uri)) {
+	case ".csv":
+		return FormatCSV
+	case ".tsv":
+		return FormatTSV
+	default:
+		return FormatXLSX
+	}
+}
+
+// sheet is a parsed sheet, values holds the typed value of every cell if typed values are enabled.
+type sheet struct {
+	name   string
+	rows   [][]string
+	values [][]any
+}
+
+func readCSV(reader io.Reader, comma rune, typed bool) ([]*sheet, error) {
+	r := csv.NewReader(reader)
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	s := &sheet{}
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv failed: %w", err)
+		}
+		s.rows = append(s.rows, record)
+		if typed {
+			values := make([]any, len(record))
+			for i, field := range record {
+				values[i] = parseValue(field)
+			}
+			s.values = append(s.values, values)
+		}
+	}
+	return []*sheet{s}, nil
+}
+
+// parseValue converts the text of a csv field to a bool or a number if possible.
+func parseValue(text string) any {
+	trimmed := strings.TrimSpace(text)
+	switch strings.ToLower(trimmed) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		return f
+	}
+	return text
+}
+
+// workbook reads sheets from an excelize file.
+type workbook struct {
+	file      *excelize.File
+	date1904  bool
+	dateStyle map[int]bool
+}
+
+func newWorkbook(file *excelize.File) *workbook {
+	wb := &workbook{
+		file:      file,
+		dateStyle: make(map[int]bool),
+	}
+	if props, err := file.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		wb.date1904 = *props.Date1904
+	}
+	return wb
+}
+
+func (wb *workbook) readSheet(name string, typed bool) (*sheet, error) {
+	rows, err := wb.file.GetRows(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// every cell of a merged range gets the value of its top left cell
+	merged, err := wb.file.GetMergeCells(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range merged {
+		startCol, startRow, err := excelize.CellNameToCoordinates(m.GetStartAxis())
+		if err != nil {
+			continue
+		}
+		endCol, endRow, err := excelize.CellNameToCoordinates(m.GetEndAxis())
+		if err != nil {
+			continue
+		}
+		value := m.GetCellValue()
+		for r := startRow - 1; r < endRow; r++ {
+			for len(rows) <= r {
+				rows = append(rows, nil)
+			}
+			for len(rows[r]) < endCol {
+				rows[r] = append(rows[r], "")
+			}
+			for c := startCol - 1; c < endCol; c++ {
+				rows[r][c] = value
+			}
+		}
+	}
+
+	s := &sheet{name: name, rows: rows}
+	if !typed {
+		return s, nil
+	}
+
+	s.values = make([][]any, len(rows))
+	for r, row := range rows {
+		s.values[r] = make([]any, len(row))
+		for c, text := range row {
+			s.values[r][c] = wb.cellValue(name, c, r, text)
+		}
+	}
+	for _, m := range merged {
+		startCol, startRow, err := excelize.CellNameToCoordinates(m.GetStartAxis())
+		if err != nil {
+			continue
+		}
+		endCol, endRow, _ := excelize.CellNameToCoordinates(m.GetEndAxis())
+		value := s.values[startRow-1][startCol-1]
+		for r := startRow - 1; r < endRow; r++ {
+			for c := startCol - 1; c < endCol; c++ {
+				s.values[r][c] = value
+			}
+		}
+	}
+	return s, nil
+}
+
+// cellValue returns the typed value of a cell: bool, int64 or float64 for numbers, time.Time for dates,
+// and the formatted text for others. Formulas are represented by their cached values.
+func (wb *workbook) cellValue(sheet string, col, row int, text string) any {
+	if text == "" {
+		return text
+	}
+	cell, err := excelize.CoordinatesToCellName(col+1, row+1)
+	if err != nil {
+		return text
+	}
+	typ, err := wb.file.GetCellType(sheet, cell)
+	if err != nil {
+		return text
+	}
+	switch typ {
+	case excelize.CellTypeBool:
+		raw, _ := wb.file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+		return raw == "1" || strings.EqualFold(raw, "true")
+	case excelize.CellTypeUnset, excelize.CellTypeNumber, excelize.CellTypeDate:
+	default:
+		return text
+	}
+
+	raw, err := wb.file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return text
+	}
+	number, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return text
+	}
+	if wb.isDate(sheet, cell) || typ == excelize.CellTypeDate {
+		if t, err := excelize.ExcelDateToTime(number, wb.date1904); err == nil {
+			return t
+		}
+	}
+	if number == math.Trunc(number) && math.Abs(number) < 1<<53 {
+		return int64(number)
+	}
+	return number
+}
+
+func (wb *workbook) isDate(sheet, cell string) bool {
+	idx, err := wb.file.GetCellStyle(sheet, cell)
+	if err != nil {
+		return false
+	}
+	if isDate, ok := wb.dateStyle[idx]; ok {
+		return isDate
+	}
+
+	isDate := false
+	if style, err := wb.file.GetStyle(idx); err == nil {
+		switch {
+		case style.CustomNumFmt != nil:
+			isDate = isDateFormat(*style.CustomNumFmt)
+		case style.NumFmt >= 14 && style.NumFmt <= 22, style.NumFmt >= 45 && style.NumFmt <= 47:
+			isDate = true
+		}
+	}
+	wb.dateStyle[idx] = isDate
+	return isDate
+}
+
+// isDateFormat reports whether a custom number format displays a date or time,
+// quoted text, escaped characters and bracketed sections such as colors are ignored.
+func isDateFormat(format string) bool {
+	inQuote, inBracket, escaped := false, false, false
+	for _, r := range strings.ToLower(format) {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '[':
+			inBracket = true
+		case r == ']':
+			inBracket = false
+		case inBracket:
+		case r == 'y', r == 'd', r == 'h', r == 's', r == 'm':
+			return true
+		}
+	}
+	return false
+}
@@ -28,14 +28,32 @@ import (
 )
 
 const (
-	MetaDataRow = "_row"
-	MetaDataExt = "_ext"
+	MetaDataRow      = "_row"
+	MetaDataExt      = "_ext"
+	MetaDataSheet    = "_sheet"     // name of the sheet, not set for csv and tsv
+	MetaDataRowStart = "_row_start" // row number of the first row in the document, starting from 1
+	MetaDataRowEnd   = "_row_end"   // row number of the last row in the document, starting from 1
 )
 
+// Mode specifies how rows are grouped into documents.
+type Mode string
+
+const (
+	// ModeRow emits a document for every row, cells are joined by tabs, this is the default mode.
+	ModeRow Mode = "row"
+	// ModeRows emits a document for every Config.RowsPerDoc rows, rendered as a Markdown table with the header.
+	ModeRows Mode = "rows"
+	// ModeSheet emits a document for every sheet, rendered as a Markdown table.
+	ModeSheet Mode = "sheet"
+)
+
+const defaultRowsPerDoc = 10
+
 // XlsxParser Custom parser for parsing Xlsx file content
 // Can be used to work with Xlsx files with headers or without headers
-// You can also select a specific table from the xlsx file in multiple sheet tables
+// You can also select specific tables from the xlsx file in multiple sheet tables
 // You can also customize the prefix of the document ID
+// CSV and TSV content is also supported, and parsed as a single sheet
 type XlsxParser struct {
 	Config *Config
 }
@@ -44,10 +62,25 @@ type XlsxParser struct {
 type Config struct {
 	// SheetName is set to Sheet1 by default, which means that the first table is processed
 	SheetName string
+	// SheetNames selects multiple sheets to process in order, takes precedence over SheetName
+	SheetNames []string
+	// AllSheets processes all sheets in the workbook, takes precedence over SheetNames and SheetName
+	AllSheets bool
 	// NoHeader is set to false by default, which means that the first row is used as the table header
 	NoHeader bool
 	// IDPrefix is set to customize the prefix of document ID, default 1,2,3, ...
+	// When multiple sheets are processed, the sheet name is added to the ID, e.g. {IDPrefix}{sheet}_1
 	IDPrefix string
+	// Mode specifies how rows are grouped into documents, ModeRow by default
+	Mode Mode
+	// RowsPerDoc is the number of data rows in a document in ModeRows, default 10
+	RowsPerDoc int
+	// TypedValues stores typed values instead of formatted text in the _row metadata:
+	// bool, int64 or float64 for numbers, time.Time for dates, and the cached value for formulas
+	TypedValues bool
+	// Format is the format of the content, detected by the extension of the uri (parser.WithURI) if empty,
+	// and FormatXLSX by default. CSV and TSV content is parsed as a single sheet without name
+	Format Format
 }
 
 // NewXlsxParser Create a new xlsxParser
@@ -56,26 +89,32 @@ func NewXlsxParser(ctx context.Context, config *Config) (xlp parser.Parser, err
 	if config == nil {
 		config = &Config{}
 	}
+	switch config.Mode {
+	case "", ModeRow, ModeRows, ModeSheet:
+	default:
+		return nil, fmt.Errorf("unknown xlsx parser mode: %s", config.Mode)
+	}
 	// NoHeader is false by default, which means HasHeader is true by default
 	xlp = &XlsxParser{Config: config}
 	return xlp, nil
 }
 
 // generateID generates document ID based on configuration
-func (xlp *XlsxParser) generateID(i int) string {
-	if xlp.Config.IDPrefix == "" {
-		return fmt.Sprintf("%d", i)
-	}
-	return fmt.Sprintf("%s%d", xlp.Config.IDPrefix, i)
+func (xlp *XlsxParser) generateID(sheetName string, i int) string {
+	return fmt.Sprintf("%s%s%d", xlp.Config.IDPrefix, sheetName, i)
 }
 
 // buildRowMetaData builds row metadata from row data and headers
-func (xlp *XlsxParser) buildRowMetaData(row []string, headers []string) map[string]any {
+func (xlp *XlsxParser) buildRowMetaData(row []string, values []any, headers []string) map[string]any {
 	metaData := make(map[string]any)
 	if !xlp.Config.NoHeader {
 		for j, header := range headers {
 			if j < len(row) {
-				metaData[header] = row[j]
+				if values != nil && j < len(values) {
+					metaData[header] = values[j]
+				} else {
+					metaData[header] = row[j]
+				}
 			}
 		}
 	}
@@ -85,6 +124,42 @@ func (xlp *XlsxParser) buildRowMetaData(row []string, headers []string) map[stri
 // Parse parses the XLSX content from io.Reader.
 func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
 	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+
+	format := xlp.Config.Format
+	if format == "" {
+		format = formatOf(option.URI)
+	}
+
+	var (
+		sheets []*sheet
+		err    error
+	)
+	switch format {
+	case FormatCSV:
+		sheets, err = readCSV(reader, ',', xlp.Config.TypedValues)
+	case FormatTSV:
+		sheets, err = readCSV(reader, '\t', xlp.Config.TypedValues)
+	case FormatXLSX:
+		sheets, err = xlp.readXlsx(reader)
+	default:
+		return nil, fmt.Errorf("unknown xlsx parser format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*schema.Document
+	for _, s := range sheets {
+		idSheet := ""
+		if len(sheets) > 1 {
+			idSheet = s.name + "_"
+		}
+		ret = append(ret, xlp.sheetDocs(s, idSheet, option.ExtraMeta)...)
+	}
+	return ret, nil
+}
+
+func (xlp *XlsxParser) readXlsx(reader io.Reader) ([]*sheet, error) {
 	xlFile, err := excelize.OpenReader(reader)
 	if err != nil {
 		return nil, err
@@ -92,24 +167,40 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 	defer xlFile.Close()
 
 	// Get all worksheets
-	sheets := xlFile.GetSheetList()
-	if len(sheets) == 0 {
+	sheetList := xlFile.GetSheetList()
+	if len(sheetList) == 0 {
 		return nil, nil
 	}
 
-	// Default
-	sheetName := sheets[0]
-	if xlp.Config.SheetName != "" {
-		sheetName = xlp.Config.SheetName
+	var names []string
+	switch {
+	case xlp.Config.AllSheets:
+		names = sheetList
+	case len(xlp.Config.SheetNames) > 0:
+		names = xlp.Config.SheetNames
+	case xlp.Config.SheetName != "":
+		names = []string{xlp.Config.SheetName}
+	default:
+		names = sheetList[:1]
 	}
 
-	// Get all rows, header + data rows
-	rows, err := xlFile.GetRows(sheetName)
-	if err != nil {
-		return nil, err
+	wb := newWorkbook(xlFile)
+	sheets := make([]*sheet, 0, len(names))
+	for _, name := range names {
+		s, err := wb.readSheet(name, xlp.Config.TypedValues)
+		if err != nil {
+			return nil, err
+		}
+		sheets = append(sheets, s)
 	}
+	return sheets, nil
+}
+
+// sheetDocs converts the rows of a sheet into documents according to the mode.
+func (xlp *XlsxParser) sheetDocs(s *sheet, idSheet string, extraMeta map[string]any) []*schema.Document {
+	rows := s.rows
 	if len(rows) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	var ret []*schema.Document
@@ -122,12 +213,56 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 		startIdx = 1
 	}
 
-	// Process rows of data
+	newMeta := func(first, last int) map[string]any {
+		meta := make(map[string]any)
+		if s.name != "" {
+			meta[MetaDataSheet] = s.name
+		}
+		meta[MetaDataRowStart] = first + 1
+		meta[MetaDataRowEnd] = last + 1
+		// Get the Common ExtraMeta
+		if extraMeta != nil {
+			meta[MetaDataExt] = extraMeta
+		}
+		return meta
+	}
+
+	// indexes of non-empty data rows
+	var dataRows []int
 	for i := startIdx; i < len(rows); i++ {
-		row := rows[i]
-		if len(row) == 0 {
-			continue
+		if !isEmptyRow(rows[i]) {
+			dataRows = append(dataRows, i)
+		}
+	}
+
+	switch xlp.Config.Mode {
+	case ModeRows, ModeSheet:
+		size := len(dataRows)
+		if xlp.Config.Mode == ModeRows {
+			size = xlp.Config.RowsPerDoc
+			if size <= 0 {
+				size = defaultRowsPerDoc
+			}
+		}
+		for start := 0; start < len(dataRows); start += size {
+			chunk := dataRows[start:min(start+size, len(dataRows))]
+			first, last := chunk[0], chunk[len(chunk)-1]
+			chunkRows := make([][]string, 0, len(chunk))
+			for _, i := range chunk {
+				chunkRows = append(chunkRows, rows[i])
+			}
+			ret = append(ret, &schema.Document{
+				ID:       xlp.generateID(idSheet, first),
+				Content:  markdownTable(headers, chunkRows),
+				MetaData: newMeta(first, last),
+			})
 		}
+		return ret
+	}
+
+	// Process rows of data
+	for _, i := range dataRows {
+		row := rows[i]
 		// Convert row data to strings
 		contentParts := make([]string, len(row))
 		for j, cell := range row {
@@ -135,20 +270,19 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 		}
 		content := strings.Join(contentParts, "\t")
 
-		meta := make(map[string]any)
+		meta := newMeta(i, i)
 
 		// Build the row's Meta
-		rowMeta := xlp.buildRowMetaData(row, headers)
-		meta[MetaDataRow] = rowMeta
-
-		// Get the Common ExtraMeta
-		if option.ExtraMeta != nil {
-			meta[MetaDataExt] = option.ExtraMeta
+		var values []any
+		if s.values != nil {
+			values = s.values[i]
 		}
+		rowMeta := xlp.buildRowMetaData(row, values, headers)
+		meta[MetaDataRow] = rowMeta
 
 		// Create New Document
 		nDoc := &schema.Document{
-			ID:       xlp.generateID(i),
+			ID:       xlp.generateID(idSheet, i),
 			Content:  content,
 			MetaData: meta,
 		}
@@ -156,5 +290,49 @@ func (xlp *XlsxParser) Parse(ctx context.Context, reader io.Reader, opts ...pars
 		ret = append(ret, nDoc)
 	}
 
-	return ret, nil
+	return ret
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// markdownTable renders rows as a Markdown table, columns are named A, B, C, ... if there is no header.
+func markdownTable(headers []string, rows [][]string) string {
+	cols := len(headers)
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	if len(headers) == 0 {
+		for i := 1; i <= cols; i++ {
+			name, _ := excelize.ColumnNumberToName(i)
+			headers = append(headers, name)
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = strings.TrimSpace(row[i])
+				cell = strings.ReplaceAll(cell, "|", "\\|")
+				cell = strings.ReplaceAll(strings.ReplaceAll(cell, "\r\n", "<br>"), "\n", "<br>")
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(headers)
+	sb.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
 }
@@ -17,12 +17,16 @@
 package xlsx
 
 import (
+	"bytes"
 	"context"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
 )
 
 func TestXlsxParser_Parse(t *testing.T) {
@@ -103,3 +107,131 @@ func TestXlsxParser_Parse(t *testing.T) {
 		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData[MetaDataExt])
 	})
 }
+
+func newTestWorkbook(t *testing.T) *bytes.Buffer {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	_, err := f.NewSheet("Scores")
+	assert.NoError(t, err)
+
+	data := map[string][][]any{
+		"Sheet1": {
+			{"name", "age", "joined", "active", "score"},
+			{"Tom", 21, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true, 90.5},
+			{},
+			{"Jerry", 22, time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), false, 85},
+			{"Spike", 23, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), true, 70},
+		},
+		"Scores": {
+			{"team", "q1", "q2"},
+			{"red", 1, 2},
+			{nil, 3, 4},
+		},
+	}
+	for sheet, rows := range data {
+		for r, row := range rows {
+			for c, v := range row {
+				cell, err := excelize.CoordinatesToCellName(c+1, r+1)
+				assert.NoError(t, err)
+				assert.NoError(t, f.SetCellValue(sheet, cell, v))
+			}
+		}
+	}
+	assert.NoError(t, f.MergeCell("Scores", "A2", "A3"))
+
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	return buf
+}
+
+func TestXlsxParser_Modes(t *testing.T) {
+	ctx := context.Background()
+	data := newTestWorkbook(t).Bytes()
+
+	t.Run("typed values and merged cells", func(t *testing.T) {
+		p, err := NewXlsxParser(ctx, &Config{AllSheets: true, TypedValues: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 5, len(docs))
+
+		assert.Equal(t, "Sheet1_1", docs[0].ID)
+		assert.Equal(t, "Sheet1", docs[0].MetaData[MetaDataSheet])
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataRowStart])
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataRowEnd])
+		row := docs[0].MetaData[MetaDataRow].(map[string]any)
+		assert.Equal(t, "Tom", row["name"])
+		assert.Equal(t, int64(21), row["age"])
+		assert.Equal(t, true, row["active"])
+		assert.Equal(t, 90.5, row["score"])
+		assert.True(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Equal(row["joined"].(time.Time)))
+
+		// the empty row is skipped
+		assert.Equal(t, "Sheet1_3", docs[1].ID)
+		assert.Equal(t, 4, docs[1].MetaData[MetaDataRowStart])
+
+		assert.Equal(t, "Scores_2", docs[4].ID)
+		assert.Equal(t, "red\t3\t4", docs[4].Content)
+		assert.Equal(t, map[string]any{"team": "red", "q1": int64(3), "q2": int64(4)}, docs[4].MetaData[MetaDataRow])
+	})
+
+	t.Run("rows per doc", func(t *testing.T) {
+		p, err := NewXlsxParser(ctx, &Config{Mode: ModeRows, RowsPerDoc: 2, IDPrefix: "chunk_"})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "chunk_1", docs[0].ID)
+		assert.Equal(t, 2, docs[0].MetaData[MetaDataRowStart])
+		assert.Equal(t, 4, docs[0].MetaData[MetaDataRowEnd])
+		lines := strings.Split(docs[0].Content, "\n")
+		assert.Equal(t, 4, len(lines))
+		assert.Equal(t, "| name | age | joined | active | score |", lines[0])
+		assert.Equal(t, "| --- | --- | --- | --- | --- |", lines[1])
+		assert.True(t, strings.HasPrefix(lines[2], "| Tom | 21 |"))
+		assert.True(t, strings.HasSuffix(lines[3], "| FALSE | 85 |"))
+		assert.Equal(t, 5, docs[1].MetaData[MetaDataRowStart])
+		assert.Nil(t, docs[1].MetaData[MetaDataRow])
+	})
+
+	t.Run("whole sheet", func(t *testing.T) {
+		p, err := NewXlsxParser(ctx, &Config{Mode: ModeSheet, SheetNames: []string{"Scores"}, NoHeader: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "| A | B | C |\n| --- | --- | --- |\n| team | q1 | q2 |\n| red | 1 | 2 |\n| red | 3 | 4 |", docs[0].Content)
+		assert.Equal(t, 1, docs[0].MetaData[MetaDataRowStart])
+		assert.Equal(t, 3, docs[0].MetaData[MetaDataRowEnd])
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := NewXlsxParser(ctx, &Config{Mode: "column"})
+		assert.Error(t, err)
+	})
+}
+
+func TestXlsxParser_CSV(t *testing.T) {
+	ctx := context.Background()
+
+	p, err := NewXlsxParser(ctx, &Config{TypedValues: true})
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(ctx, strings.NewReader("name,age,note\nTom,21,\"a, b\"\n"), parser.WithURI("people.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(docs))
+	assert.Equal(t, "Tom\t21\ta, b", docs[0].Content)
+	assert.Equal(t, map[string]any{"name": "Tom", "age": int64(21), "note": "a, b"}, docs[0].MetaData[MetaDataRow])
+	assert.Nil(t, docs[0].MetaData[MetaDataSheet])
+
+	p, err = NewXlsxParser(ctx, &Config{Format: FormatTSV, Mode: ModeSheet})
+	assert.NoError(t, err)
+
+	docs, err = p.Parse(ctx, strings.NewReader("k\tv\na|b\t1\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "| k | v |\n| --- | --- |\n| a\\|b | 1 |", docs[0].Content)
+}
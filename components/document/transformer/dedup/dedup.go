@@ -0,0 +1,266 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyClusterID is the metadata key of the ID of the duplicate cluster a document belongs to.
+	// Every document has a cluster ID, documents without duplicates are in clusters of their own.
+	MetaKeyClusterID = "_dup_cluster_id"
+	// MetaKeyDuplicateOf is the metadata key of the ID of the representative document, set on duplicates with ActionTag.
+	MetaKeyDuplicateOf = "_duplicate_of"
+)
+
+// Method is the method to detect near-duplicates, exact duplicates are always detected by content hash.
+type Method string
+
+const (
+	// MethodExact only detects documents with the same normalized content.
+	MethodExact Method = "exact"
+	// MethodMinHash detects documents whose word shingles have an estimated Jaccard similarity of at least Threshold.
+	MethodMinHash Method = "minhash"
+	// MethodSimHash detects documents whose 64-bit SimHash fingerprints differ in at most MaxHammingDistance bits.
+	MethodSimHash Method = "simhash"
+)
+
+// KeepPolicy specifies which document of a duplicate cluster is the representative.
+type KeepPolicy string
+
+const (
+	// KeepFirst keeps the earliest document in the input.
+	KeepFirst KeepPolicy = "first"
+	// KeepLongest keeps the document with the longest content, the earliest one among the longest.
+	KeepLongest KeepPolicy = "longest"
+)
+
+// Action specifies what to do with duplicates.
+type Action string
+
+const (
+	// ActionDrop drops duplicates and only returns the representatives.
+	ActionDrop Action = "drop"
+	// ActionTag returns all documents, with duplicates tagged by MetaKeyDuplicateOf.
+	ActionTag Action = "tag"
+)
+
+type Config struct {
+	// Method is the near-duplicate detection method, MethodMinHash by default.
+	Method Method
+	// Threshold is the min estimated Jaccard similarity of near-duplicates for MethodMinHash, 0.8 by default.
+	Threshold float64
+	// MaxHammingDistance is the max Hamming distance of the fingerprints of near-duplicates for MethodSimHash,
+	// 3 if nil. 0 only matches documents with identical fingerprints.
+	MaxHammingDistance *int
+	// ShingleSize is the number of words in a shingle, 5 by default. Each CJK character is counted as a word.
+	ShingleSize int
+	// NumHashes is the number of hash functions of MethodMinHash, 128 by default. It must be divisible by Bands.
+	NumHashes int
+	// Bands is the number of bands of locality sensitive hashing for MethodMinHash, 32 by default.
+	// More bands find more candidates, which are verified by Threshold.
+	Bands int
+	// Keep specifies the representative of each cluster, KeepFirst by default.
+	Keep KeepPolicy
+	// Action specifies what to do with duplicates, ActionDrop by default.
+	Action Action
+	// Normalize normalizes content before hashing, by default letters are lowered and whitespace is collapsed.
+	Normalize func(content string) string
+}
+
+// NewTransformer creates a transformer detecting exact and near-duplicate documents within each call of Transform,
+// e.g. between a loader and an indexer. Duplicates are grouped into clusters transitively, every document is marked
+// with its cluster ID by MetaKeyClusterID, and duplicates are dropped or tagged according to Action.
+// The input documents are not modified, the returned documents are copies of them.
+func NewTransformer(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	t := &transformer{
+		method:      config.Method,
+		threshold:   config.Threshold,
+		maxDistance: 3,
+		shingleSize: config.ShingleSize,
+		numHashes:   config.NumHashes,
+		bands:       config.Bands,
+		keep:        config.Keep,
+		action:      config.Action,
+		normalize:   config.Normalize,
+	}
+	if t.method == "" {
+		t.method = MethodMinHash
+	}
+	if t.threshold == 0 {
+		t.threshold = 0.8
+	}
+	if config.MaxHammingDistance != nil {
+		t.maxDistance = *config.MaxHammingDistance
+	}
+	if t.shingleSize == 0 {
+		t.shingleSize = 5
+	}
+	if t.numHashes == 0 {
+		t.numHashes = 128
+	}
+	if t.bands == 0 {
+		t.bands = 32
+	}
+	if t.keep == "" {
+		t.keep = KeepFirst
+	}
+	if t.action == "" {
+		t.action = ActionDrop
+	}
+	if t.normalize == nil {
+		t.normalize = defaultNormalize
+	}
+
+	switch {
+	case t.method != MethodExact && t.method != MethodMinHash && t.method != MethodSimHash:
+		return nil, fmt.Errorf("unknown dedup method: %s", t.method)
+	case t.keep != KeepFirst && t.keep != KeepLongest:
+		return nil, fmt.Errorf("unknown keep policy: %s", t.keep)
+	case t.action != ActionDrop && t.action != ActionTag:
+		return nil, fmt.Errorf("unknown action: %s", t.action)
+	case t.threshold < 0 || t.threshold > 1:
+		return nil, fmt.Errorf("threshold must be in [0, 1]: %v", t.threshold)
+	case t.maxDistance < 0 || t.maxDistance >= 64:
+		return nil, fmt.Errorf("max hamming distance must be in [0, 64): %d", t.maxDistance)
+	case t.shingleSize < 0 || t.numHashes < 0 || t.bands < 0 || t.numHashes%t.bands != 0:
+		return nil, fmt.Errorf("invalid shingle size %d, num hashes %d or bands %d", t.shingleSize, t.numHashes, t.bands)
+	}
+	t.seeds = minHashSeeds(t.numHashes)
+	return t, nil
+}
+
+type transformer struct {
+	method      Method
+	threshold   float64
+	maxDistance int
+	shingleSize int
+	numHashes   int
+	bands       int
+	keep        KeepPolicy
+	action      Action
+	normalize   func(string) string
+	seeds       []uint64
+}
+
+func (t *transformer) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	uf := newUnionFind(len(src))
+	normalized := make([]string, len(src))
+	hashes := make([]string, len(src))
+	first := make(map[string]int, len(src))
+	for i, doc := range src {
+		normalized[i] = t.normalize(doc.Content)
+		sum := sha256.Sum256([]byte(normalized[i]))
+		hashes[i] = hex.EncodeToString(sum[:])
+		if j, ok := first[hashes[i]]; ok {
+			uf.union(j, i)
+		} else {
+			first[hashes[i]] = i
+		}
+	}
+
+	switch t.method {
+	case MethodMinHash:
+		t.clusterMinHash(normalized, uf)
+	case MethodSimHash:
+		t.clusterSimHash(normalized, uf)
+	}
+
+	// representative of each cluster, indexed by the root
+	reps := make(map[int]int)
+	for i, doc := range src {
+		root := uf.find(i)
+		rep, ok := reps[root]
+		if !ok || (t.keep == KeepLongest && utf8.RuneCountInString(doc.Content) > utf8.RuneCountInString(src[rep].Content)) {
+			reps[root] = i
+		}
+	}
+
+	ret := make([]*schema.Document, 0, len(src))
+	for i, doc := range src {
+		rep := reps[uf.find(i)]
+		if rep != i && t.action == ActionDrop {
+			continue
+		}
+		copied := *doc
+		copied.MetaData = make(map[string]any, len(doc.MetaData)+2)
+		for k, v := range doc.MetaData {
+			copied.MetaData[k] = v
+		}
+		copied.MetaData[MetaKeyClusterID] = hashes[rep][:16]
+		if rep != i {
+			copied.MetaData[MetaKeyDuplicateOf] = src[rep].ID
+		}
+		ret = append(ret, &copied)
+	}
+	return ret, nil
+}
+
+func (t *transformer) GetType() string {
+	return "DedupTransformer"
+}
+
+func defaultNormalize(content string) string {
+	return strings.Join(strings.Fields(strings.ToLower(content)), " ")
+}
+
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	uf := make(unionFind, n)
+	for i := range uf {
+		uf[i] = i
+	}
+	return uf
+}
+
+func (uf unionFind) find(i int) int {
+	for uf[i] != i {
+		uf[i] = uf[uf[i]]
+		i = uf[i]
+	}
+	return i
+}
+
+// union merges the clusters of i and j, the smaller index becomes the root so that roots are stable.
+func (uf unionFind) union(i, j int) {
+	ri, rj := uf.find(i), uf.find(j)
+	if ri == rj {
+		return
+	}
+	if ri < rj {
+		uf[rj] = ri
+	} else {
+		uf[ri] = rj
+	}
+}
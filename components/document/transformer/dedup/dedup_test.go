@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const article = "Eino is a framework for building applications with large language models in Go. " +
+	"It provides components such as chat models, retrievers, indexers and document transformers, " +
+	"and orchestration of them with chains and graphs, together with callbacks and streaming support " +
+	"which make it easy to observe and compose the applications running in production."
+
+func testDocs() []*schema.Document {
+	return []*schema.Document{
+		{ID: "a", Content: article},
+		{ID: "b", Content: "  " + strings.ToUpper(article[:1]) + article[1:] + "\n"},
+		{ID: "c", Content: article + " Read the docs for details."},
+		{ID: "d", Content: "A completely different page about cooking pasta with tomatoes, garlic and basil at home."},
+		{ID: "e", Content: "相同的中文内容用于测试近似重复的检测效果，看看是否能够被正确识别出来。"},
+		{ID: "f", Content: "相同的中文内容用于测试近似重复的检测效果，看看是否能够被正确识别。"},
+	}
+}
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.ID
+	}
+	return ret
+}
+
+func ptrOf[T any](v T) *T {
+	return &v
+}
+
+func TestDedup(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		config *Config
+		want   []string
+	}{
+		{name: "exact", config: &Config{Method: MethodExact}, want: []string{"a", "c", "d", "e", "f"}},
+		{name: "minhash", config: &Config{}, want: []string{"a", "d", "e"}},
+		{name: "minhash keep longest", config: &Config{Keep: KeepLongest}, want: []string{"c", "d", "e"}},
+		{name: "simhash", config: &Config{Method: MethodSimHash, MaxHammingDistance: ptrOf(10)}, want: []string{"a", "d", "e"}},
+		{name: "simhash identical", config: &Config{Method: MethodSimHash, MaxHammingDistance: ptrOf(0)}, want: []string{"a", "c", "d", "e", "f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewTransformer(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := d.Transform(ctx, testDocs())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ids(got), tt.want) {
+				t.Errorf("got %v, want %v", ids(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupTag(t *testing.T) {
+	ctx := context.Background()
+	d, err := NewTransformer(ctx, &Config{Action: ActionTag})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := testDocs()
+	got, err := d.Transform(ctx, docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(docs) {
+		t.Fatalf("got %d documents", len(got))
+	}
+	var dupOf []any
+	for _, doc := range got {
+		dupOf = append(dupOf, doc.MetaData[MetaKeyDuplicateOf])
+	}
+	if !reflect.DeepEqual(dupOf, []any{nil, "a", "a", nil, nil, "e"}) {
+		t.Errorf("unexpected duplicate of %v", dupOf)
+	}
+	if got[0].MetaData[MetaKeyClusterID] != got[2].MetaData[MetaKeyClusterID] ||
+		got[0].MetaData[MetaKeyClusterID] == got[3].MetaData[MetaKeyClusterID] {
+		t.Error("unexpected cluster ids")
+	}
+	if docs[1].MetaData != nil {
+		t.Error("input document is modified")
+	}
+
+	if _, err = NewTransformer(ctx, &Config{NumHashes: 100, Bands: 32}); err == nil {
+		t.Error("expect error of indivisible bands")
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dedup
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// shingles returns the hashes of the distinct shingles of size words in text.
+// Text shorter than size words is a single shingle.
+func shingles(text string, size int) []uint64 {
+	words := tokenize(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if len(words) < size {
+		size = len(words)
+	}
+	seen := make(map[uint64]bool)
+	var ret []uint64
+	for i := 0; i+size <= len(words); i++ {
+		h := hashString(strings.Join(words[i:i+size], " "))
+		if !seen[h] {
+			seen[h] = true
+			ret = append(ret, h)
+		}
+	}
+	return ret
+}
+
+// tokenize splits text into words of letters and digits, every CJK character is a word on its own.
+func tokenize(text string) []string {
+	var words []string
+	start := -1
+	for i, r := range text {
+		switch {
+		case isCJK(r):
+			if start >= 0 {
+				words = append(words, text[start:i])
+				start = -1
+			}
+			words = append(words, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if start < 0 {
+				start = i
+			}
+		default:
+			if start >= 0 {
+				words = append(words, text[start:i])
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, text[start:])
+	}
+	return words
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return mix(h.Sum64())
+}
+
+// mix is the finalizer of splitmix64, which spreads the bits of x.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// minHashSeeds returns deterministic seeds of n hash functions.
+func minHashSeeds(n int) []uint64 {
+	seeds := make([]uint64, n)
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		state += 0x9e3779b97f4a7c15
+		seeds[i] = mix(state)
+	}
+	return seeds
+}
+
+func (t *transformer) minHash(shingles []uint64) []uint64 {
+	sig := make([]uint64, len(t.seeds))
+	for i, seed := range t.seeds {
+		minimum := ^uint64(0)
+		for _, s := range shingles {
+			if h := mix(s ^ seed); h < minimum {
+				minimum = h
+			}
+		}
+		sig[i] = minimum
+	}
+	return sig
+}
+
+// clusterMinHash finds candidate pairs with locality sensitive hashing on MinHash signatures,
+// and unions the pairs whose estimated Jaccard similarity reaches the threshold.
+func (t *transformer) clusterMinHash(texts []string, uf unionFind) {
+	sigs := make([][]uint64, len(texts))
+	for i, text := range texts {
+		if s := shingles(text, t.shingleSize); len(s) > 0 {
+			sigs[i] = t.minHash(s)
+		}
+	}
+	rows := t.numHashes / t.bands
+	for band := 0; band < t.bands; band++ {
+		buckets := make(map[uint64][]int)
+		for i, sig := range sigs {
+			if sig == nil {
+				continue
+			}
+			key := uint64(band)
+			for _, v := range sig[band*rows : (band+1)*rows] {
+				key = mix(key ^ v)
+			}
+			for _, j := range buckets[key] {
+				if uf.find(i) != uf.find(j) && similarity(sigs[i], sigs[j]) >= t.threshold {
+					uf.union(i, j)
+				}
+			}
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+}
+
+// similarity estimates the Jaccard similarity by the fraction of equal signature values.
+func similarity(a, b []uint64) float64 {
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+func simHash(shingles []uint64) uint64 {
+	var weights [64]int
+	for _, s := range shingles {
+		for bit := 0; bit < 64; bit++ {
+			if s&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	var ret uint64
+	for bit, w := range weights {
+		if w > 0 {
+			ret |= 1 << bit
+		}
+	}
+	return ret
+}
+
+// clusterSimHash splits fingerprints into maxDistance+1 blocks, fingerprints within maxDistance bits must share
+// at least one identical block, so only fingerprints in the same block bucket are compared.
+func (t *transformer) clusterSimHash(texts []string, uf unionFind) {
+	fingerprints := make([]uint64, len(texts))
+	valid := make([]bool, len(texts))
+	for i, text := range texts {
+		if s := shingles(text, t.shingleSize); len(s) > 0 {
+			fingerprints[i] = simHash(s)
+			valid[i] = true
+		}
+	}
+	blocks := t.maxDistance + 1
+	for b := 0; b < blocks; b++ {
+		lo, hi := 64*b/blocks, 64*(b+1)/blocks
+		mask := (^uint64(0) >> (64 - (hi - lo))) << lo
+		buckets := make(map[uint64][]int)
+		for i, fp := range fingerprints {
+			if !valid[i] {
+				continue
+			}
+			key := fp & mask
+			for _, j := range buckets[key] {
+				if uf.find(i) != uf.find(j) && bits.OnesCount64(fp^fingerprints[j]) <= t.maxDistance {
+					uf.union(i, j)
+				}
+			}
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+}
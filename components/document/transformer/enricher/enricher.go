@@ -0,0 +1,331 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyParentID is the metadata key of the ID of the parent document of a question document.
+	MetaKeyParentID = "_parent_id"
+	// MetaKeyKind is the metadata key of the kind of generated documents, i.e. KindQuestion.
+	MetaKeyKind = "_kind"
+	// KindQuestion is the kind of question documents.
+	KindQuestion = "question"
+)
+
+// DefaultSchema extracts a title, a summary, keywords and hypothetical questions of a document.
+var DefaultSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"title":     map[string]any{"type": "string", "description": "a short title of the document"},
+		"summary":   map[string]any{"type": "string", "description": "a summary of the document in 1 to 3 sentences"},
+		"keywords":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "up to 10 keywords"},
+		"questions": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "up to 5 questions the document can answer"},
+	},
+	"required": []any{"title", "summary", "keywords", "questions"},
+}
+
+type Config struct {
+	// ChatModel generates the metadata, required.
+	ChatModel model.BaseChatModel
+	// Template formats the messages sent to ChatModel with variables "content", "schema" (the JSON schema in a string)
+	// and "metadata" of each document. A builtin prompt is used by default.
+	Template prompt.ChatTemplate
+	// Schema is the JSON schema of the object to extract, DefaultSchema by default. The output of ChatModel is
+	// validated against it, the supported keywords are type, properties, required, additionalProperties (false only),
+	// items, enum, minItems, maxItems, minLength, maxLength, minimum and maximum.
+	Schema map[string]any
+	// KeyPrefix is prepended to the property names of the extracted object as metadata keys.
+	KeyPrefix string
+	// Concurrency is the max number of documents enriched at the same time, 4 by default.
+	Concurrency int
+	// MaxRetries is the max number of retries of a document when ChatModel fails or the output is invalid, 2 by default.
+	// A negative value disables retries. The validation error is sent back to ChatModel on retry.
+	MaxRetries int
+	// RetryInterval is the interval before the first retry, doubled for each following retry. 500ms by default.
+	RetryInterval time.Duration
+	// ContinueOnError specifies whether to return the documents failed to enrich without the extracted metadata.
+	// If true, Transform returns all documents together with an error joining a *DocumentError for every failed one.
+	ContinueOnError bool
+	// QuestionField is the property of hypothetical questions in the extracted object, which must be an array of strings,
+	// e.g. "questions" of DefaultSchema. If set, every question is emitted as a document after its parent document,
+	// with MetaKeyParentID and MetaKeyKind in metadata, for HyDE style indexing.
+	QuestionField string
+}
+
+// DocumentError is the error of a document failed to enrich.
+type DocumentError struct {
+	ID  string
+	Err error
+}
+
+func (e *DocumentError) Error() string {
+	return fmt.Sprintf("enrich document [%s] fail: %v", e.ID, e.Err)
+}
+
+func (e *DocumentError) Unwrap() error {
+	return e.Err
+}
+
+// NewTransformer creates a transformer which asks a chat model to extract an object described by a JSON schema
+// from every document, e.g. a summary and keywords, and writes the properties of the object into the metadata.
+// The input documents are not modified, the returned documents are copies of them.
+func NewTransformer(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil || config.ChatModel == nil {
+		return nil, errors.New("chat model is required")
+	}
+	jsonSchema := config.Schema
+	if jsonSchema == nil {
+		jsonSchema = DefaultSchema
+	}
+	schemaText, err := json.MarshalIndent(jsonSchema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal schema fail: %w", err)
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	maxRetries := config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 2
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+	retryInterval := config.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = 500 * time.Millisecond
+	}
+	return &transformer{
+		chatModel:       config.ChatModel,
+		template:        config.Template,
+		schema:          jsonSchema,
+		schemaText:      string(schemaText),
+		keyPrefix:       config.KeyPrefix,
+		concurrency:     concurrency,
+		maxRetries:      maxRetries,
+		retryInterval:   retryInterval,
+		continueOnError: config.ContinueOnError,
+		questionField:   config.QuestionField,
+	}, nil
+}
+
+type transformer struct {
+	chatModel       model.BaseChatModel
+	template        prompt.ChatTemplate
+	schema          map[string]any
+	schemaText      string
+	keyPrefix       string
+	concurrency     int
+	maxRetries      int
+	retryInterval   time.Duration
+	continueOnError bool
+	questionField   string
+}
+
+func (t *transformer) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results = make([]map[string]any, len(src))
+		errs    = make([]error, len(src))
+		sem     = make(chan struct{}, t.concurrency)
+		wg      sync.WaitGroup
+
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(i int, err error) {
+		errs[i] = &DocumentError{ID: src[i].ID, Err: err}
+		if !t.continueOnError {
+			// the other documents fail with context canceled after this, keep the root cause
+			once.Do(func() {
+				firstErr = errs[i]
+				cancel()
+			})
+		}
+	}
+	for i, doc := range src {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					fail(i, fmt.Errorf("panic: %v", r))
+				}
+				<-sem
+				wg.Done()
+			}()
+			result, err := t.enrich(ctx, doc)
+			if err != nil {
+				fail(i, err)
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*schema.Document, 0, len(src))
+	for i, doc := range src {
+		copied := *doc
+		copied.MetaData = make(map[string]any, len(doc.MetaData)+len(results[i]))
+		for k, v := range doc.MetaData {
+			copied.MetaData[k] = v
+		}
+		for k, v := range results[i] {
+			copied.MetaData[t.keyPrefix+k] = v
+		}
+		ret = append(ret, &copied)
+		ret = append(ret, t.questionDocs(&copied, results[i])...)
+	}
+	return ret, errors.Join(errs...)
+}
+
+// enrich extracts the object from doc, retrying when the model fails or the output is invalid.
+func (t *transformer) enrich(ctx context.Context, doc *schema.Document) (map[string]any, error) {
+	messages, err := t.formatMessages(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := t.retryInterval
+	for attempt := 0; ; attempt++ {
+		var out *schema.Message
+		out, err = t.chatModel.Generate(ctx, messages)
+		if err == nil {
+			var result map[string]any
+			if result, err = parseOutput(out.Content, t.schema); err == nil {
+				return result, nil
+			}
+			// let the model correct its output on retry
+			messages = append(messages,
+				schema.AssistantMessage(out.Content, nil),
+				schema.UserMessage(fmt.Sprintf("The output is invalid: %v. Respond again with only the JSON object.", err)))
+		} else {
+			err = fmt.Errorf("generate fail: %w", err)
+		}
+		if attempt >= t.maxRetries {
+			return nil, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+}
+
+const defaultSystemPrompt = "You extract metadata from documents. Respond with only a JSON object conforming to the JSON schema below, " +
+	"without any explanation or Markdown formatting. Use the language of the document for text values.\n\nJSON schema:\n"
+
+func (t *transformer) formatMessages(ctx context.Context, doc *schema.Document) ([]*schema.Message, error) {
+	if t.template == nil {
+		return []*schema.Message{
+			schema.SystemMessage(defaultSystemPrompt + t.schemaText),
+			schema.UserMessage("Document:\n" + doc.Content),
+		}, nil
+	}
+	messages, err := t.template.Format(ctx, map[string]any{
+		"content":  doc.Content,
+		"schema":   t.schemaText,
+		"metadata": doc.MetaData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("format template fail: %w", err)
+	}
+	return messages, nil
+}
+
+// parseOutput extracts the JSON object from the model output, which may be wrapped in a Markdown code block,
+// and validates it against jsonSchema.
+func parseOutput(output string, jsonSchema map[string]any) (map[string]any, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return nil, errors.New("no JSON object found")
+	}
+	var result map[string]any
+	if err := json.Unmarshal([]byte(output[start:end+1]), &result); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON object fail: %w", err)
+	}
+	if err := validate(result, jsonSchema, "$"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t *transformer) questionDocs(parent *schema.Document, result map[string]any) []*schema.Document {
+	if t.questionField == "" || result == nil {
+		return nil
+	}
+	questions, _ := result[t.questionField].([]any)
+	var ret []*schema.Document
+	for _, q := range questions {
+		question, ok := q.(string)
+		if !ok || strings.TrimSpace(question) == "" {
+			continue
+		}
+		doc := &schema.Document{
+			ID:       fmt.Sprintf("%s_q%d", parent.ID, len(ret)),
+			Content:  question,
+			MetaData: make(map[string]any, len(parent.MetaData)+2),
+		}
+		for k, v := range parent.MetaData {
+			doc.MetaData[k] = v
+		}
+		doc.MetaData[MetaKeyParentID] = parent.ID
+		doc.MetaData[MetaKeyKind] = KindQuestion
+		ret = append(ret, doc)
+	}
+	return ret
+}
+
+func (t *transformer) GetType() string {
+	return "LLMEnricher"
+}
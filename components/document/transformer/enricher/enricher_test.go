@@ -0,0 +1,218 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+)
+
+// fakeModel answers with the outputs by document content in turn, and records the inputs.
+type fakeModel struct {
+	mu      sync.Mutex
+	outputs map[string][]string
+	inputs  [][]*schema.Message
+}
+
+func (m *fakeModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inputs = append(m.inputs, input)
+	content := strings.TrimPrefix(input[1].Content, "Document:\n")
+	outputs := m.outputs[content]
+	if len(outputs) == 0 {
+		return nil, errors.New("no more output")
+	}
+	m.outputs[content] = outputs[1:]
+	return schema.AssistantMessage(outputs[0], nil), nil
+}
+
+func (m *fakeModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestEnricher(t *testing.T) {
+	ctx := context.Background()
+	m := &fakeModel{outputs: map[string][]string{
+		"doc a": {
+			`{"title": "A", "summary": "about a", "keywords": ["a"]}`,
+			"```json\n" + `{"title": "A", "summary": "about a", "keywords": ["a"], "questions": ["what is a?", "why a?"]}` + "\n```",
+		},
+		"doc b": {
+			`{"title": "B", "summary": "about b", "keywords": [], "questions": []}`,
+		},
+	}}
+	e, err := NewTransformer(ctx, &Config{
+		ChatModel:     m,
+		KeyPrefix:     "llm_",
+		RetryInterval: time.Millisecond,
+		QuestionField: "questions",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := []*schema.Document{
+		{ID: "a", Content: "doc a", MetaData: map[string]any{"source": "x"}},
+		{ID: "b", Content: "doc b"},
+	}
+	got, err := e.Transform(ctx, docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*schema.Document{
+		{ID: "a", Content: "doc a", MetaData: map[string]any{
+			"source": "x", "llm_title": "A", "llm_summary": "about a",
+			"llm_keywords": []any{"a"}, "llm_questions": []any{"what is a?", "why a?"},
+		}},
+		{ID: "a_q0", Content: "what is a?", MetaData: map[string]any{
+			"source": "x", "llm_title": "A", "llm_summary": "about a",
+			"llm_keywords": []any{"a"}, "llm_questions": []any{"what is a?", "why a?"},
+			MetaKeyParentID: "a", MetaKeyKind: KindQuestion,
+		}},
+		{ID: "a_q1", Content: "why a?", MetaData: map[string]any{
+			"source": "x", "llm_title": "A", "llm_summary": "about a",
+			"llm_keywords": []any{"a"}, "llm_questions": []any{"what is a?", "why a?"},
+			MetaKeyParentID: "a", MetaKeyKind: KindQuestion,
+		}},
+		{ID: "b", Content: "doc b", MetaData: map[string]any{
+			"llm_title": "B", "llm_summary": "about b", "llm_keywords": []any{}, "llm_questions": []any{},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if len(docs[0].MetaData) != 1 {
+		t.Error("input document is modified")
+	}
+
+	// the validation error is sent back on retry
+	var retried []*schema.Message
+	for _, input := range m.inputs {
+		if len(input) > 2 {
+			retried = input
+		}
+	}
+	if len(retried) != 4 || !strings.Contains(retried[3].Content, "$.questions is required") {
+		t.Errorf("unexpected retry input %v", retried)
+	}
+}
+
+func TestEnricherErrors(t *testing.T) {
+	ctx := context.Background()
+	m := &fakeModel{outputs: map[string][]string{
+		"doc a": {`{"title": "A", "summary": "about a", "keywords": ["a"], "questions": []}`},
+		"doc b": {"not json", `{"title": 1, "summary": "", "keywords": [], "questions": []}`},
+	}}
+	e, err := NewTransformer(ctx, &Config{
+		ChatModel:       m,
+		MaxRetries:      1,
+		RetryInterval:   time.Millisecond,
+		ContinueOnError: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := e.Transform(ctx, []*schema.Document{{ID: "a", Content: "doc a"}, {ID: "b", Content: "doc b"}})
+	var docErr *DocumentError
+	if !errors.As(err, &docErr) || docErr.ID != "b" || !strings.Contains(err.Error(), "$.title should be of type string") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(got) != 2 || got[0].MetaData["title"] != "A" || len(got[1].MetaData) != 0 {
+		t.Errorf("unexpected documents %v", got)
+	}
+}
+
+// blockingModel blocks on "doc a" until the context is canceled, and fails the other documents.
+type blockingModel struct{}
+
+func (blockingModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	if strings.HasSuffix(input[1].Content, "doc a") {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return nil, errors.New("model unavailable")
+}
+
+func (blockingModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestEnricherFirstError(t *testing.T) {
+	ctx := context.Background()
+	e, err := NewTransformer(ctx, &Config{ChatModel: blockingModel{}, Concurrency: 2, RetryInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = e.Transform(ctx, []*schema.Document{{ID: "a", Content: "doc a"}, {ID: "b", Content: "doc b"}})
+	var docErr *DocumentError
+	if !errors.As(err, &docErr) || docErr.ID != "b" || !strings.Contains(err.Error(), "model unavailable") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+type fakeTemplate struct{}
+
+func (fakeTemplate) Format(ctx context.Context, vars map[string]any, opts ...prompt.Option) ([]*schema.Message, error) {
+	return []*schema.Message{
+		schema.SystemMessage(fmt.Sprint(vars["schema"])),
+		schema.UserMessage("Document:\n" + fmt.Sprint(vars["content"])),
+	}, nil
+}
+
+func TestEnricherSchema(t *testing.T) {
+	ctx := context.Background()
+	m := &fakeModel{outputs: map[string][]string{
+		"doc": {`{"category": "other"}`, `{"category": "tech", "score": 3}`},
+	}}
+	e, err := NewTransformer(ctx, &Config{
+		ChatModel: m,
+		Template:  fakeTemplate{},
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"category": map[string]any{"type": "string", "enum": []any{"tech", "life"}},
+				"score":    map[string]any{"type": "integer", "minimum": 1, "maximum": 5},
+			},
+			"additionalProperties": false,
+		},
+		RetryInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := e.Transform(ctx, []*schema.Document{{ID: "x", Content: "doc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].MetaData["category"] != "tech" || got[0].MetaData["score"] != 3.0 {
+		t.Errorf("unexpected metadata %v", got[0].MetaData)
+	}
+	if !strings.Contains(m.inputs[0][0].Content, `"enum"`) {
+		t.Errorf("schema is not in the prompt: %s", m.inputs[0][0].Content)
+	}
+}
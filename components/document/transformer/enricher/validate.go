@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package enricher
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"unicode/utf8"
+)
+
+// validate checks value against a subset of JSON schema, path is the location of value in error messages.
+func validate(value any, s map[string]any, path string) error {
+	if types, ok := s["type"]; ok && !matchType(value, types) {
+		return fmt.Errorf("%s should be of type %v", path, types)
+	}
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(normalizeNumber(e), value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s should be one of %v", path, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range toStrings(s["required"]) {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s.%s is required", path, name)
+			}
+		}
+		props, _ := s["properties"].(map[string]any)
+		for name, pv := range v {
+			ps, ok := props[name].(map[string]any)
+			if !ok {
+				if additional, ok := s["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s.%s is not allowed", path, name)
+				}
+				continue
+			}
+			if err := validate(pv, ps, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if n, ok := number(s["minItems"]); ok && float64(len(v)) < n {
+			return fmt.Errorf("%s should have at least %v items", path, n)
+		}
+		if n, ok := number(s["maxItems"]); ok && float64(len(v)) > n {
+			return fmt.Errorf("%s should have at most %v items", path, n)
+		}
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, item := range v {
+				if err := validate(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		length := float64(utf8.RuneCountInString(v))
+		if n, ok := number(s["minLength"]); ok && length < n {
+			return fmt.Errorf("%s should have at least %v characters", path, n)
+		}
+		if n, ok := number(s["maxLength"]); ok && length > n {
+			return fmt.Errorf("%s should have at most %v characters", path, n)
+		}
+	case float64:
+		if n, ok := number(s["minimum"]); ok && v < n {
+			return fmt.Errorf("%s should be at least %v", path, n)
+		}
+		if n, ok := number(s["maximum"]); ok && v > n {
+			return fmt.Errorf("%s should be at most %v", path, n)
+		}
+	}
+	return nil
+}
+
+func matchType(value any, types any) bool {
+	for _, t := range toStrings(types) {
+		switch t {
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := value.(float64); ok && f == math.Trunc(f) {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toStrings accepts a string, a []string or a []any of strings.
+func toStrings(v any) []string {
+	switch vv := v.(type) {
+	case string:
+		return []string{vv}
+	case []string:
+		return vv
+	case []any:
+		ret := make([]string, 0, len(vv))
+		for _, e := range vv {
+			if s, ok := e.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	}
+	return nil
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// normalizeNumber converts the numbers of a schema written in Go to float64 as decoded from JSON.
+func normalizeNumber(v any) any {
+	if n, ok := number(v); ok {
+		return n
+	}
+	return v
+}
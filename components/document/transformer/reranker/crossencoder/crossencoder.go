@@ -0,0 +1,268 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crossencoder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+// MetaKeyRerankScore is the default metadata key of the relevance score written by the reranker.
+const MetaKeyRerankScore = "_rerank_score"
+
+type Config struct {
+	// URL is the rerank endpoint, required.
+	// The endpoint should accept the request and response shape of the Cohere rerank API, which is also served by
+	// Jina, Voyage, and most BGE reranker servers such as Xinference and vLLM, e.g. "https://api.jina.ai/v1/rerank".
+	URL string
+	// APIKey is sent in the Authorization header as a bearer token if not empty.
+	APIKey string
+	// Model is the name of the rerank model, e.g. "rerank-v3.5", "jina-reranker-v2-base-multilingual", "bge-reranker-v2-m3".
+	Model string
+	// Headers are extra headers of the request.
+	Headers map[string]string
+	// HTTPClient sends the requests, a client with Timeout by default.
+	HTTPClient *http.Client
+	// Timeout is the timeout of a request when HTTPClient is nil, 30s by default.
+	Timeout time.Duration
+	// BatchSize is the max number of documents sent in one request, 0 means all documents are sent in one request.
+	BatchSize int
+	// TopN keeps the N most relevant documents, 0 means all documents are kept. It can be overridden by WithTopN.
+	TopN int
+	// MinScore drops the documents whose relevance score is lower than it, if not nil.
+	MinScore *float64
+	// ScoreFieldKey is the metadata key to write the relevance score, MetaKeyRerankScore by default.
+	ScoreFieldKey string
+}
+
+// NewReranker creates a reranker which scores the relevance of documents to the query with a cross-encoder model
+// served by an HTTP rerank API. The query is given by WithQuery for every call.
+// Documents are returned in descending order of relevance, with the score written to their metadata.
+// The input documents are not modified, the returned documents are copies of them.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil || config.URL == "" {
+		return nil, errors.New("rerank url is required")
+	}
+	if config.BatchSize < 0 || config.TopN < 0 {
+		return nil, errors.New("batch size and top n must be non-negative")
+	}
+	client := config.HTTPClient
+	if client == nil {
+		timeout := config.Timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+	scoreKey := config.ScoreFieldKey
+	if scoreKey == "" {
+		scoreKey = MetaKeyRerankScore
+	}
+	return &reranker{
+		url:       config.URL,
+		apiKey:    config.APIKey,
+		model:     config.Model,
+		headers:   config.Headers,
+		client:    client,
+		batchSize: config.BatchSize,
+		topN:      config.TopN,
+		minScore:  config.MinScore,
+		scoreKey:  scoreKey,
+	}, nil
+}
+
+type reranker struct {
+	url       string
+	apiKey    string
+	model     string
+	headers   map[string]string
+	client    *http.Client
+	batchSize int
+	topN      int
+	minScore  *float64
+	scoreKey  string
+}
+
+type rerankRequest struct {
+	Model           string   `json:"model,omitempty"`
+	Query           string   `json:"query"`
+	Documents       []string `json:"documents"`
+	ReturnDocuments bool     `json:"return_documents"`
+}
+
+type rerankResult struct {
+	Index          int      `json:"index"`
+	RelevanceScore *float64 `json:"relevance_score"`
+	// Score is returned by some BGE servers instead of RelevanceScore
+	Score *float64 `json:"score"`
+}
+
+type rerankResponse struct {
+	Results []rerankResult `json:"results"`
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{topN: r.topN}, opts...)
+	if o.query == "" {
+		return nil, errors.New("query is required, set it with WithQuery")
+	}
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	batchSize := r.batchSize
+	if batchSize <= 0 {
+		batchSize = len(src)
+	}
+	scores := make([]float64, len(src))
+	for start := 0; start < len(src); start += batchSize {
+		end := min(start+batchSize, len(src))
+		if err := r.rerank(ctx, o.query, src[start:end], scores[start:end]); err != nil {
+			return nil, err
+		}
+	}
+
+	return selectDocs(src, scores, o.topN, r.minScore, r.scoreKey), nil
+}
+
+// rerank requests the relevance scores of docs and writes them to scores.
+func (r *reranker) rerank(ctx context.Context, query string, docs []*schema.Document, scores []float64) error {
+	req := &rerankRequest{
+		Model:     r.model,
+		Query:     query,
+		Documents: make([]string, len(docs)),
+	}
+	for i, doc := range docs {
+		req.Documents[i] = doc.Content
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshal rerank request fail: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create rerank request fail: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	if r.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+	for k, v := range r.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := r.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("send rerank request fail: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read rerank response fail: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("rerank request fail, status= %d, body= %s", resp.StatusCode, respBody)
+	}
+
+	results, err := parseResults(respBody)
+	if err != nil {
+		return err
+	}
+	scored := make([]bool, len(docs))
+	for _, result := range results {
+		if result.Index < 0 || result.Index >= len(docs) {
+			return fmt.Errorf("rerank result index out of range: %d", result.Index)
+		}
+		switch {
+		case result.RelevanceScore != nil:
+			scores[result.Index] = *result.RelevanceScore
+		case result.Score != nil:
+			scores[result.Index] = *result.Score
+		default:
+			return fmt.Errorf("rerank result of index %d has no score", result.Index)
+		}
+		scored[result.Index] = true
+	}
+	for i, ok := range scored {
+		if !ok {
+			return fmt.Errorf("rerank response misses the score of index %d", i)
+		}
+	}
+	return nil
+}
+
+// parseResults accepts both {"results": [...]} and a bare array of results.
+func parseResults(body []byte) ([]rerankResult, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var results []rerankResult
+		if err := json.Unmarshal(body, &results); err != nil {
+			return nil, fmt.Errorf("unmarshal rerank response fail: %w", err)
+		}
+		return results, nil
+	}
+	var resp rerankResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal rerank response fail: %w", err)
+	}
+	return resp.Results, nil
+}
+
+func (r *reranker) GetType() string {
+	return "CrossEncoderReranker"
+}
+
+// selectDocs sorts copies of docs by scores in descending order, and applies minScore and topN.
+func selectDocs(docs []*schema.Document, scores []float64, topN int, minScore *float64, scoreKey string) []*schema.Document {
+	indexes := make([]int, 0, len(docs))
+	for i := range docs {
+		if minScore != nil && scores[i] < *minScore {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+	if topN > 0 && len(indexes) > topN {
+		indexes = indexes[:topN]
+	}
+
+	ret := make([]*schema.Document, 0, len(indexes))
+	for _, i := range indexes {
+		doc := *docs[i]
+		doc.MetaData = make(map[string]any, len(docs[i].MetaData)+1)
+		for k, v := range docs[i].MetaData {
+			doc.MetaData[k] = v
+		}
+		doc.MetaData[scoreKey] = scores[i]
+		ret = append(ret, &doc)
+	}
+	return ret
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package crossencoder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// newStubServer scores documents by the number of query words they contain.
+func newStubServer(t *testing.T, bareArray bool, requests *[]rerankRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req rerankRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request fail: %v", err)
+		}
+		*requests = append(*requests, req)
+
+		type result struct {
+			Index int     `json:"index"`
+			Score float64 `json:"relevance_score"`
+		}
+		var results []result
+		for i := len(req.Documents) - 1; i >= 0; i-- {
+			var score float64
+			for _, word := range strings.Fields(req.Query) {
+				if strings.Contains(req.Documents[i], word) {
+					score++
+				}
+			}
+			results = append(results, result{Index: i, Score: score})
+		}
+		if bareArray {
+			_ = json.NewEncoder(w).Encode(results)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+	}))
+}
+
+func TestCrossEncoderReranker(t *testing.T) {
+	ctx := context.Background()
+	docs := []*schema.Document{
+		{ID: "0", Content: "nothing related"},
+		{ID: "1", Content: "go is a language", MetaData: map[string]any{"k": "v"}},
+		{ID: "2", Content: "go rerank with a cross encoder"},
+		{ID: "3", Content: "rerank"},
+	}
+
+	var requests []rerankRequest
+	server := newStubServer(t, false, &requests)
+	defer server.Close()
+
+	minScore := 1.0
+	r, err := NewReranker(ctx, &Config{
+		URL:       server.URL,
+		APIKey:    "key",
+		Model:     "bge-reranker",
+		BatchSize: 3,
+		MinScore:  &minScore,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Transform(ctx, docs)
+	if err == nil {
+		t.Fatal("expect error without query")
+	}
+
+	got, err := r.Transform(ctx, docs, WithQuery("go rerank"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*schema.Document{
+		{ID: "2", Content: "go rerank with a cross encoder", MetaData: map[string]any{MetaKeyRerankScore: 2.0}},
+		{ID: "1", Content: "go is a language", MetaData: map[string]any{"k": "v", MetaKeyRerankScore: 1.0}},
+		{ID: "3", Content: "rerank", MetaData: map[string]any{MetaKeyRerankScore: 1.0}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if len(docs[1].MetaData) != 1 {
+		t.Errorf("input document is modified: %v", docs[1].MetaData)
+	}
+	if len(requests) != 2 || len(requests[0].Documents) != 3 || requests[0].Model != "bge-reranker" {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+
+	got, err = r.Transform(ctx, docs, WithQuery("go rerank"), WithTopN(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("unexpected top 1 %v", got)
+	}
+}
+
+func TestCrossEncoderRerankerResponses(t *testing.T) {
+	ctx := context.Background()
+	docs := []*schema.Document{{ID: "0", Content: "a"}, {ID: "1", Content: "b"}}
+
+	var requests []rerankRequest
+	server := newStubServer(t, true, &requests)
+	defer server.Close()
+
+	r, err := NewReranker(ctx, &Config{URL: server.URL, APIKey: "key", ScoreFieldKey: "score"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Transform(ctx, docs, WithQuery("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ID != "1" || got[0].MetaData["score"] != 1.0 {
+		t.Errorf("unexpected result %v", got)
+	}
+
+	r, err = NewReranker(ctx, &Config{URL: server.URL, APIKey: "wrong"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.Transform(ctx, docs, WithQuery("b"))
+	if err == nil || !strings.Contains(err.Error(), "status= 401") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
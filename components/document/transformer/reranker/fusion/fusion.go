@@ -0,0 +1,287 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyList is the default metadata key of the name of the ranked list a document belongs to.
+	MetaKeyList = "_fusion_list"
+	// MetaKeyFusionScore is the default metadata key of the fused score.
+	MetaKeyFusionScore = "_fusion_score"
+)
+
+// Method is the method to fuse ranked lists.
+type Method string
+
+const (
+	// MethodRRF is reciprocal rank fusion, the score of a document is the sum of weight/(K+rank) over the lists
+	// containing it, where rank starts from 1. It only depends on ranks, so scores of different scales can be fused.
+	MethodRRF Method = "rrf"
+	// MethodWeighted normalizes the scores of each list to [0, 1] with min-max normalization,
+	// and the score of a document is the weighted sum of its normalized scores.
+	MethodWeighted Method = "weighted"
+)
+
+// DedupKey specifies how the same document in different lists is identified.
+type DedupKey string
+
+const (
+	// DedupByID identifies documents by ID, documents without ID are identified by content.
+	DedupByID DedupKey = "id"
+	// DedupByContent identifies documents by the hash of content.
+	DedupByContent DedupKey = "content"
+)
+
+type Config struct {
+	// Method is the fusion method, MethodRRF by default.
+	Method Method
+	// K is the constant of MethodRRF which reduces the impact of top ranks, 60 by default.
+	K float64
+	// Weights are the weights of lists by name, a list not in Weights has weight 1.
+	Weights map[string]float64
+	// DedupKey specifies how duplicated documents are identified, DedupByID by default.
+	// The first occurrence of a duplicated document is kept.
+	DedupKey DedupKey
+	// ListFieldKey specifies the metadata key of the list name of each input document, MetaKeyList by default.
+	// Input documents are grouped into lists by it, keeping their order as ranks. See also SetList and WithList.
+	ListFieldKey string
+	// ScoreFieldKey specifies the metadata key of the input score used by MethodWeighted.
+	// Use Score() method to get score by default.
+	ScoreFieldKey *string
+	// OutputScoreKey specifies the metadata key to write the fused score, MetaKeyFusionScore by default.
+	OutputScoreKey string
+	// TopN keeps the N documents with the highest fused scores, 0 means all documents are kept.
+	TopN int
+}
+
+// NewReranker creates a transformer fusing several ranked lists of documents into one, e.g. the results of a BM25
+// retriever and a vector retriever for the same query. Documents are returned in descending order of the fused score,
+// as copies of the input documents with the fused score in their metadata.
+//
+// The lists are given by WithList, or by the metadata of input documents, e.g.
+//
+//	docs := append(fusion.SetList(bm25Docs, "bm25"), fusion.SetList(vectorDocs, "vector")...)
+//	fused, err := reranker.Transform(ctx, docs)
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	method := config.Method
+	if method == "" {
+		method = MethodRRF
+	}
+	if method != MethodRRF && method != MethodWeighted {
+		return nil, fmt.Errorf("unknown fusion method: %s", method)
+	}
+	k := config.K
+	if k == 0 {
+		k = 60
+	}
+	if k < 0 || config.TopN < 0 {
+		return nil, errors.New("k and top n must be non-negative")
+	}
+	dedupKey := config.DedupKey
+	if dedupKey == "" {
+		dedupKey = DedupByID
+	}
+	if dedupKey != DedupByID && dedupKey != DedupByContent {
+		return nil, fmt.Errorf("unknown dedup key: %s", dedupKey)
+	}
+	listKey := config.ListFieldKey
+	if listKey == "" {
+		listKey = MetaKeyList
+	}
+	outputKey := config.OutputScoreKey
+	if outputKey == "" {
+		outputKey = MetaKeyFusionScore
+	}
+	getter := func(doc *schema.Document) float64 {
+		return doc.Score()
+	}
+	if config.ScoreFieldKey != nil {
+		key := *config.ScoreFieldKey
+		getter = func(doc *schema.Document) float64 {
+			v, _ := doc.MetaData[key].(float64)
+			return v
+		}
+	}
+	return &reranker{
+		method:      method,
+		k:           k,
+		weights:     config.Weights,
+		dedupKey:    dedupKey,
+		listKey:     listKey,
+		scoreGetter: getter,
+		outputKey:   outputKey,
+		topN:        config.TopN,
+	}, nil
+}
+
+type reranker struct {
+	method      Method
+	k           float64
+	weights     map[string]float64
+	dedupKey    DedupKey
+	listKey     string
+	scoreGetter func(doc *schema.Document) float64
+	outputKey   string
+	topN        int
+}
+
+type fused struct {
+	doc   *schema.Document
+	score float64
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	lists := o.lists
+	if lists == nil {
+		lists = r.groupLists(src)
+	}
+
+	var order []string
+	results := make(map[string]*fused)
+	for _, list := range lists {
+		weight := 1.0
+		if w, ok := r.weights[list.name]; ok {
+			weight = w
+		}
+		scores := r.listScores(list.docs)
+		seen := make(map[string]bool, len(list.docs))
+		for i, doc := range list.docs {
+			key := r.key(doc)
+			if seen[key] {
+				// a document appearing twice in a list only counts its best rank
+				continue
+			}
+			seen[key] = true
+			result, ok := results[key]
+			if !ok {
+				result = &fused{doc: doc}
+				results[key] = result
+				order = append(order, key)
+			}
+			result.score += weight * scores[i]
+		}
+	}
+
+	ret := make([]*schema.Document, 0, len(order))
+	sort.SliceStable(order, func(i, j int) bool {
+		return results[order[i]].score > results[order[j]].score
+	})
+	for _, key := range order {
+		if r.topN > 0 && len(ret) >= r.topN {
+			break
+		}
+		result := results[key]
+		doc := *result.doc
+		doc.MetaData = make(map[string]any, len(result.doc.MetaData)+1)
+		for k, v := range result.doc.MetaData {
+			doc.MetaData[k] = v
+		}
+		doc.MetaData[r.outputKey] = result.score
+		ret = append(ret, &doc)
+	}
+	return ret, nil
+}
+
+// listScores returns the scores of the documents of a list before weighting.
+func (r *reranker) listScores(docs []*schema.Document) []float64 {
+	scores := make([]float64, len(docs))
+	if r.method == MethodRRF {
+		for i := range docs {
+			scores[i] = 1 / (r.k + float64(i+1))
+		}
+		return scores
+	}
+
+	if len(docs) == 0 {
+		return scores
+	}
+	lo, hi := r.scoreGetter(docs[0]), r.scoreGetter(docs[0])
+	for i, doc := range docs {
+		scores[i] = r.scoreGetter(doc)
+		lo = min(lo, scores[i])
+		hi = max(hi, scores[i])
+	}
+	for i := range scores {
+		if hi == lo {
+			scores[i] = 1
+		} else {
+			scores[i] = (scores[i] - lo) / (hi - lo)
+		}
+	}
+	return scores
+}
+
+func (r *reranker) key(doc *schema.Document) string {
+	if r.dedupKey == DedupByID && doc.ID != "" {
+		return "id:" + doc.ID
+	}
+	sum := sha256.Sum256([]byte(doc.Content))
+	return "content:" + hex.EncodeToString(sum[:])
+}
+
+// groupLists groups docs by the list name in their metadata, in the order of first appearance.
+func (r *reranker) groupLists(docs []*schema.Document) []namedList {
+	var lists []namedList
+	indexes := make(map[string]int)
+	for _, doc := range docs {
+		name, _ := doc.MetaData[r.listKey].(string)
+		idx, ok := indexes[name]
+		if !ok {
+			idx = len(lists)
+			indexes[name] = idx
+			lists = append(lists, namedList{name: name})
+		}
+		lists[idx].docs = append(lists[idx].docs, doc)
+	}
+	return lists
+}
+
+func (r *reranker) GetType() string {
+	return "FusionReranker"
+}
+
+// SetList returns copies of docs marked as the ranked list of name with MetaKeyList, the order of docs is their rank.
+// It always uses MetaKeyList, so it only works with the default Config.ListFieldKey. With a custom ListFieldKey,
+// set the list name in the metadata of each document under that key instead.
+func SetList(docs []*schema.Document, name string) []*schema.Document {
+	ret := make([]*schema.Document, len(docs))
+	for i, doc := range docs {
+		copied := *doc
+		copied.MetaData = make(map[string]any, len(doc.MetaData)+1)
+		for k, v := range doc.MetaData {
+			copied.MetaData[k] = v
+		}
+		copied.MetaData[MetaKeyList] = name
+		ret[i] = &copied
+	}
+	return ret
+}
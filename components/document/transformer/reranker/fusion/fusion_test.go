@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, len(docs))
+	for i, doc := range docs {
+		ret[i] = doc.ID
+	}
+	return ret
+}
+
+func TestRRF(t *testing.T) {
+	ctx := context.Background()
+	bm25 := []*schema.Document{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	vector := []*schema.Document{{ID: "c"}, {ID: "d"}, {ID: "a"}}
+
+	r, err := NewReranker(ctx, &Config{K: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Transform(ctx, append(SetList(bm25, "bm25"), SetList(vector, "vector")...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a: 1/2+1/4, c: 1/4+1/2, b: 1/3, d: 1/3
+	if !reflect.DeepEqual(ids(got), []string{"a", "c", "b", "d"}) {
+		t.Errorf("got %v", ids(got))
+	}
+	if got[0].MetaData[MetaKeyFusionScore] != 0.75 || got[0].MetaData[MetaKeyList] != "bm25" {
+		t.Errorf("unexpected metadata %v", got[0].MetaData)
+	}
+	if bm25[0].MetaData != nil {
+		t.Error("input document is modified")
+	}
+
+	r, err = NewReranker(ctx, &Config{K: 1, Weights: map[string]float64{"vector": 2}, TopN: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = r.Transform(ctx, nil, WithList("bm25", bm25), WithList("vector", vector))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// c: 1/4+2/2, a: 1/2+2/4, d: 2/3
+	if !reflect.DeepEqual(ids(got), []string{"c", "a"}) {
+		t.Errorf("got %v", ids(got))
+	}
+}
+
+func TestWeightedFusion(t *testing.T) {
+	ctx := context.Background()
+	bm25 := []*schema.Document{
+		(&schema.Document{ID: "1", Content: "x"}).WithScore(12),
+		(&schema.Document{ID: "2", Content: "y"}).WithScore(8),
+		(&schema.Document{ID: "3", Content: "z"}).WithScore(2),
+	}
+	vector := []*schema.Document{
+		(&schema.Document{ID: "v1", Content: "z"}).WithScore(0.9),
+		(&schema.Document{ID: "v2", Content: "y"}).WithScore(0.6),
+		(&schema.Document{ID: "v3", Content: "w"}).WithScore(0.4),
+	}
+
+	r, err := NewReranker(ctx, &Config{Method: MethodWeighted, DedupKey: DedupByContent, Weights: map[string]float64{"bm25": 0.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Transform(ctx, nil, WithList("bm25", bm25), WithList("vector", vector))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// x: 0.5*1, y: 0.5*0.6+0.4, z: 0+1, w: 0
+	if !reflect.DeepEqual(ids(got), []string{"3", "2", "1", "v3"}) {
+		t.Errorf("got %v", ids(got))
+	}
+	if score := got[1].MetaData[MetaKeyFusionScore].(float64); math.Abs(score-0.7) > 1e-9 {
+		t.Errorf("unexpected score %v", score)
+	}
+
+	if _, err = NewReranker(ctx, &Config{Method: "unknown"}); err == nil {
+		t.Error("expect error of unknown method")
+	}
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fusion
+
+import (
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+type namedList struct {
+	name string
+	docs []*schema.Document
+}
+
+type options struct {
+	lists []namedList
+}
+
+// WithList adds a ranked list of documents named name, the order of docs is their rank.
+// If any list is given by options, the input documents of Transform are ignored.
+func WithList(name string, docs []*schema.Document) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.lists = append(o.lists, namedList{name: name, docs: docs})
+	})
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// MetaKeyRerankScore is the default metadata key of the relevance score written by the reranker.
+const MetaKeyRerankScore = "_rerank_score"
+
+// PromptBuilder builds the messages asking the model to rank passages by their relevance to query.
+// The model should answer with the 1-based identifiers of passages in descending order of relevance, e.g. "[2] > [1] > [3]".
+type PromptBuilder func(ctx context.Context, query string, passages []string) ([]*schema.Message, error)
+
+type Config struct {
+	// ChatModel ranks the documents, required.
+	ChatModel model.BaseChatModel
+	// PromptBuilder builds the ranking prompt, a RankGPT style prompt by default.
+	PromptBuilder PromptBuilder
+	// WindowSize is the max number of documents ranked by the model at once, 20 by default.
+	// More documents are ranked with a window sliding from the last documents to the first ones.
+	WindowSize int
+	// Step is the distance the window slides each time, WindowSize/2 by default.
+	Step int
+	// MaxPassageLength truncates the content of each document to this number of runes in the prompt,
+	// 0 means no truncation.
+	MaxPassageLength int
+	// TopN keeps the N most relevant documents, 0 means all documents are kept. It can be overridden by WithTopN.
+	TopN int
+	// MinScore drops the documents whose score is lower than it, if not nil.
+	// The score of a document is derived from its rank, i.e. 1 - rank/total, so it is 1 for the first document.
+	MinScore *float64
+	// ScoreFieldKey is the metadata key to write the score, MetaKeyRerankScore by default.
+	ScoreFieldKey string
+}
+
+// NewReranker creates a listwise reranker, which asks the chat model to rank the documents by their relevance to
+// the query given by WithQuery. Documents are returned in the ranked order with a rank based score in their metadata.
+// The input documents are not modified, the returned documents are copies of them.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil || config.ChatModel == nil {
+		return nil, errors.New("chat model is required")
+	}
+	windowSize := config.WindowSize
+	if windowSize <= 0 {
+		windowSize = 20
+	}
+	step := config.Step
+	if step <= 0 {
+		step = max(windowSize/2, 1)
+	}
+	if step > windowSize {
+		return nil, fmt.Errorf("step %d must not be greater than window size %d", step, windowSize)
+	}
+	if config.TopN < 0 {
+		return nil, errors.New("top n must be non-negative")
+	}
+	promptBuilder := config.PromptBuilder
+	if promptBuilder == nil {
+		promptBuilder = defaultPromptBuilder
+	}
+	scoreKey := config.ScoreFieldKey
+	if scoreKey == "" {
+		scoreKey = MetaKeyRerankScore
+	}
+	return &reranker{
+		chatModel:        config.ChatModel,
+		promptBuilder:    promptBuilder,
+		windowSize:       windowSize,
+		step:             step,
+		maxPassageLength: config.MaxPassageLength,
+		topN:             config.TopN,
+		minScore:         config.MinScore,
+		scoreKey:         scoreKey,
+	}, nil
+}
+
+type reranker struct {
+	chatModel        model.BaseChatModel
+	promptBuilder    PromptBuilder
+	windowSize       int
+	step             int
+	maxPassageLength int
+	topN             int
+	minScore         *float64
+	scoreKey         string
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{topN: r.topN}, opts...)
+	if o.query == "" {
+		return nil, errors.New("query is required, set it with WithQuery")
+	}
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	// order holds the indexes of src in the ranked order
+	order := make([]int, len(src))
+	for i := range order {
+		order[i] = i
+	}
+	end := len(order)
+	start := max(end-r.windowSize, 0)
+	for {
+		if err := r.rankWindow(ctx, o.query, src, order[start:end]); err != nil {
+			return nil, err
+		}
+		if start == 0 {
+			break
+		}
+		end -= r.step
+		start = max(start-r.step, 0)
+	}
+
+	ret := make([]*schema.Document, 0, len(order))
+	for rank, i := range order {
+		score := 1 - float64(rank)/float64(len(order))
+		if r.minScore != nil && score < *r.minScore {
+			break
+		}
+		if o.topN > 0 && len(ret) >= o.topN {
+			break
+		}
+		doc := *src[i]
+		doc.MetaData = make(map[string]any, len(src[i].MetaData)+1)
+		for k, v := range src[i].MetaData {
+			doc.MetaData[k] = v
+		}
+		doc.MetaData[r.scoreKey] = score
+		ret = append(ret, &doc)
+	}
+	return ret, nil
+}
+
+// rankWindow asks the model to rank the documents of window, and reorders window in place.
+func (r *reranker) rankWindow(ctx context.Context, query string, src []*schema.Document, window []int) error {
+	if len(window) <= 1 {
+		return nil
+	}
+	passages := make([]string, len(window))
+	for i, idx := range window {
+		passages[i] = truncate(src[idx].Content, r.maxPassageLength)
+	}
+	messages, err := r.promptBuilder(ctx, query, passages)
+	if err != nil {
+		return fmt.Errorf("build rerank prompt fail: %w", err)
+	}
+	out, err := r.chatModel.Generate(ctx, messages)
+	if err != nil {
+		return fmt.Errorf("generate ranking fail: %w", err)
+	}
+
+	ranked := parseRanking(out.Content, len(window))
+	reordered := make([]int, len(window))
+	for i, pos := range ranked {
+		reordered[i] = window[pos]
+	}
+	copy(window, reordered)
+	return nil
+}
+
+func (r *reranker) GetType() string {
+	return "LLMReranker"
+}
+
+var identifierRegexp = regexp.MustCompile(`\d+`)
+
+// parseRanking returns the 0-based positions ranked by the model output. Invalid and duplicated identifiers are
+// ignored, and the positions missing from the output are appended in their original order.
+func parseRanking(output string, n int) []int {
+	ret := make([]int, 0, n)
+	seen := make([]bool, n)
+	for _, s := range identifierRegexp.FindAllString(output, -1) {
+		id, err := strconv.Atoi(s)
+		if err != nil || id < 1 || id > n || seen[id-1] {
+			continue
+		}
+		seen[id-1] = true
+		ret = append(ret, id-1)
+	}
+	for i, ok := range seen {
+		if !ok {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+func truncate(s string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes])
+}
+
+const defaultSystemPrompt = "You are RankGPT, an intelligent assistant that can rank passages based on their relevancy to the query."
+
+func defaultPromptBuilder(_ context.Context, query string, passages []string) ([]*schema.Message, error) {
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "I will provide you with %d passages, each indicated by a numerical identifier []. "+
+		"Rank the passages based on their relevance to the search query: %s.\n\n", len(passages), query)
+	for i, passage := range passages {
+		fmt.Fprintf(&sb, "[%d] %s\n", i+1, strings.TrimSpace(passage))
+	}
+	fmt.Fprintf(&sb, "\nSearch Query: %s.\n"+
+		"Rank the %d passages above based on their relevance to the search query. "+
+		"All the passages should be included and listed using identifiers, in descending order of relevance. "+
+		"The output format should be [] > [], e.g., [2] > [1]. Only respond with the ranking results, do not say any word or explain.",
+		query, len(passages))
+	return []*schema.Message{
+		schema.SystemMessage(defaultSystemPrompt),
+		schema.UserMessage(sb.String()),
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// sortingModel ranks the passages in the prompt by the number in their content, larger first.
+type sortingModel struct {
+	calls int
+}
+
+var passageRegexp = regexp.MustCompile(`(?m)^\[(\d+)\] doc (\d+)$`)
+
+func (m *sortingModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.calls++
+	type passage struct{ id, value int }
+	var passages []passage
+	for _, match := range passageRegexp.FindAllStringSubmatch(input[len(input)-1].Content, -1) {
+		var p passage
+		_, _ = fmt.Sscan(match[1], &p.id)
+		_, _ = fmt.Sscan(match[2], &p.value)
+		passages = append(passages, p)
+	}
+	sort.Slice(passages, func(i, j int) bool { return passages[i].value > passages[j].value })
+	ids := make([]string, len(passages))
+	for i, p := range passages {
+		ids[i] = fmt.Sprintf("[%d]", p.id)
+	}
+	return schema.AssistantMessage(strings.Join(ids, " > "), nil), nil
+}
+
+func (m *sortingModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestLLMReranker(t *testing.T) {
+	ctx := context.Background()
+	var docs []*schema.Document
+	for _, v := range []int{3, 9, 1, 7, 5, 8, 2, 6, 4} {
+		docs = append(docs, &schema.Document{ID: fmt.Sprint(v), Content: fmt.Sprintf("doc %d", v)})
+	}
+
+	m := &sortingModel{}
+	minScore := 0.5
+	r, err := NewReranker(ctx, &Config{ChatModel: m, WindowSize: 4, Step: 2, MinScore: &minScore})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Transform(ctx, docs); err == nil {
+		t.Fatal("expect error without query")
+	}
+
+	got, err := r.Transform(ctx, docs, WithQuery("largest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, doc := range got {
+		ids = append(ids, doc.ID)
+	}
+	// a sliding window moves the most relevant documents to the top
+	if !reflect.DeepEqual(ids[:2], []string{"9", "8"}) || len(ids) != 5 {
+		t.Errorf("unexpected ranking %v", ids)
+	}
+	if got[0].MetaData[MetaKeyRerankScore] != 1.0 || docs[0].MetaData != nil {
+		t.Errorf("unexpected metadata %v", got[0].MetaData)
+	}
+	if m.calls != 4 {
+		t.Errorf("model is called %d times", m.calls)
+	}
+
+	got, err = r.Transform(ctx, docs, WithQuery("largest"), WithTopN(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "9" {
+		t.Errorf("unexpected top 1 %v", got)
+	}
+}
+
+func TestParseRanking(t *testing.T) {
+	got := parseRanking("[3] > [1] > [3] > [7]", 4)
+	if !reflect.DeepEqual(got, []int{2, 0, 1, 3}) {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"github.com/cloudwego/eino/components/document"
+)
+
+type options struct {
+	query string
+	topN  int
+}
+
+// WithQuery sets the query which documents are reranked against, required.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.query = query
+	})
+}
+
+// WithTopN overrides Config.TopN for a call, 0 means all documents are kept.
+func WithTopN(n int) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.topN = n
+	})
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+type Config struct {
+	// Lambda balances relevance and diversity, 1 selects by relevance only and 0 by diversity only. 0.5 by default.
+	Lambda *float64
+	// TopN is the number of documents to select, 0 means all documents are reordered.
+	TopN int
+	// ScoreFieldKey specifies the metadata key of the relevance score, used when no query vector is given by
+	// WithQueryVector. Use Score() method to get score by default. Scores are normalized to [0, 1] with min-max
+	// normalization so that they are comparable with cosine similarities.
+	ScoreFieldKey *string
+}
+
+// NewReranker creates a transformer selecting documents with maximal marginal relevance, which penalizes documents
+// similar to those already selected to diversify the results. Documents must have dense vectors, see
+// schema.Document.WithDenseVector. The relevance of a document is its cosine similarity with the query vector
+// given by WithQueryVector, or its normalized score if no query vector is given.
+func NewReranker(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	lambda := 0.5
+	if config.Lambda != nil {
+		lambda = *config.Lambda
+	}
+	if lambda < 0 || lambda > 1 {
+		return nil, fmt.Errorf("lambda must be in [0, 1]: %v", lambda)
+	}
+	if config.TopN < 0 {
+		return nil, errors.New("top n must be non-negative")
+	}
+	getter := func(doc *schema.Document) float64 {
+		return doc.Score()
+	}
+	if config.ScoreFieldKey != nil {
+		key := *config.ScoreFieldKey
+		getter = func(doc *schema.Document) float64 {
+			v, _ := doc.MetaData[key].(float64)
+			return v
+		}
+	}
+	return &reranker{
+		lambda:      lambda,
+		topN:        config.TopN,
+		scoreGetter: getter,
+	}, nil
+}
+
+type reranker struct {
+	lambda      float64
+	topN        int
+	scoreGetter func(doc *schema.Document) float64
+}
+
+func (r *reranker) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	vectors := make([][]float64, len(src))
+	for i, doc := range src {
+		if vectors[i] = doc.DenseVector(); len(vectors[i]) == 0 {
+			return nil, fmt.Errorf("document [%s] has no dense vector", doc.ID)
+		}
+		if !finite(vectors[i]...) {
+			return nil, fmt.Errorf("dense vector of document [%s] contains NaN or Inf", doc.ID)
+		}
+	}
+	relevance, err := r.relevance(src, vectors, o.queryVector)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(src)
+	if r.topN > 0 && r.topN < n {
+		n = r.topN
+	}
+	selected := make([]bool, len(src))
+	// maxSim is the max similarity between each document and the selected ones
+	maxSim := make([]float64, len(src))
+	ret := make([]*schema.Document, 0, n)
+	for len(ret) < n {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range src {
+			if selected[i] {
+				continue
+			}
+			score := r.lambda * relevance[i]
+			if len(ret) > 0 {
+				score -= (1 - r.lambda) * maxSim[i]
+			}
+			// a similarity overflowing to NaN must not leave best unset
+			if best < 0 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		selected[best] = true
+		ret = append(ret, src[best])
+		for i := range src {
+			if !selected[i] {
+				if sim := cosine(vectors[i], vectors[best]); len(ret) == 1 || sim > maxSim[i] {
+					maxSim[i] = sim
+				}
+			}
+		}
+	}
+	return ret, nil
+}
+
+func (r *reranker) relevance(docs []*schema.Document, vectors [][]float64, query []float64) ([]float64, error) {
+	ret := make([]float64, len(docs))
+	if len(query) > 0 {
+		for i, v := range vectors {
+			if len(v) != len(query) {
+				return nil, fmt.Errorf("dense vector of document [%s] has dimension %d, query vector has %d",
+					docs[i].ID, len(v), len(query))
+			}
+			ret[i] = cosine(query, v)
+		}
+		return ret, nil
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, doc := range docs {
+		ret[i] = r.scoreGetter(doc)
+		if !finite(ret[i]) {
+			return nil, fmt.Errorf("score of document [%s] is not finite: %v", doc.ID, ret[i])
+		}
+		lo = min(lo, ret[i])
+		hi = max(hi, ret[i])
+	}
+	for i := range ret {
+		if hi == lo {
+			ret[i] = 1
+		} else {
+			ret[i] = (ret[i] - lo) / (hi - lo)
+		}
+	}
+	return ret, nil
+}
+
+func (r *reranker) GetType() string {
+	return "MMRReranker"
+}
+
+func cosine(a, b []float64) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		if i >= len(b) {
+			break
+		}
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func finite(vs ...float64) bool {
+	for _, v := range vs {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func TestMMR(t *testing.T) {
+	ctx := context.Background()
+	docs := []*schema.Document{
+		(&schema.Document{ID: "a"}).WithDenseVector([]float64{1, 0, 0}).WithScore(0.9),
+		(&schema.Document{ID: "a'"}).WithDenseVector([]float64{1, 0.05, 0}).WithScore(0.85),
+		(&schema.Document{ID: "b"}).WithDenseVector([]float64{0, 1, 0}).WithScore(0.6),
+		(&schema.Document{ID: "c"}).WithDenseVector([]float64{0.6, 0, 0.8}).WithScore(0.5),
+	}
+	ids := func(docs []*schema.Document) []string {
+		ret := make([]string, len(docs))
+		for i, doc := range docs {
+			ret[i] = doc.ID
+		}
+		return ret
+	}
+
+	r, err := NewReranker(ctx, &Config{TopN: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Transform(ctx, docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the near duplicate a' is pushed down by diversity
+	if !reflect.DeepEqual(ids(got), []string{"a", "b"}) {
+		t.Errorf("got %v", ids(got))
+	}
+
+	lambda := 1.0
+	r, err = NewReranker(ctx, &Config{Lambda: &lambda})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = r.Transform(ctx, docs, WithQueryVector([]float64{0, 0, 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(got), []string{"c", "a", "a'", "b"}) {
+		t.Errorf("got %v", ids(got))
+	}
+
+	_, err = r.Transform(ctx, []*schema.Document{{ID: "x"}})
+	if err == nil {
+		t.Error("expect error without dense vector")
+	}
+}
+
+func TestMMRNotFinite(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewReranker(ctx, &Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Transform(ctx, []*schema.Document{
+		(&schema.Document{ID: "a"}).WithDenseVector([]float64{1, 0}).WithScore(math.NaN()),
+		(&schema.Document{ID: "b"}).WithDenseVector([]float64{0, 1}).WithScore(0.5),
+	})
+	if err == nil {
+		t.Error("expect error of NaN score")
+	}
+	_, err = r.Transform(ctx, []*schema.Document{
+		(&schema.Document{ID: "a"}).WithDenseVector([]float64{math.NaN(), 0}),
+	})
+	if err == nil {
+		t.Error("expect error of NaN vector")
+	}
+
+	// the similarities of huge vectors overflow to NaN
+	huge := []float64{1e200, 1e200}
+	got, err := r.Transform(ctx, []*schema.Document{
+		(&schema.Document{ID: "a"}).WithDenseVector(huge),
+		(&schema.Document{ID: "b"}).WithDenseVector(huge),
+	}, WithQueryVector(huge))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("got %d documents", len(got))
+	}
+}
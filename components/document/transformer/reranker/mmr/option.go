@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mmr
+
+import (
+	"github.com/cloudwego/eino/components/document"
+)
+
+type options struct {
+	queryVector []float64
+}
+
+// WithQueryVector sets the vector of the query, the relevance of documents is their cosine similarity with it.
+func WithQueryVector(vector []float64) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.queryVector = vector
+	})
+}
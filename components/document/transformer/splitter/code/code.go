@@ -0,0 +1,412 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package code provides a splitter of source code, which splits code by declarations and blocks,
+// and records the symbols of chunks in their metadata.
+package code
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
+)
+
+const (
+	// MetaKeyLanguage is the Language of the chunk.
+	MetaKeyLanguage = "_code_language"
+	// MetaKeyPackage is the package or namespace of the source file, if the language declares one.
+	MetaKeyPackage = "_code_package"
+	// MetaKeySymbol is the name of the declaration the chunk belongs to, e.g. a function or a type name.
+	MetaKeySymbol = "_code_symbol"
+	// MetaKeyKind is the kind of the declaration the chunk belongs to, e.g. KindFunction.
+	MetaKeyKind = "_code_kind"
+	// MetaKeyReceiver is the receiver type of a method, or the class a member is declared in.
+	MetaKeyReceiver = "_code_receiver"
+	// MetaKeyStartLine is the line where the chunk starts, starting from 1.
+	MetaKeyStartLine = "_code_start_line"
+	// MetaKeyEndLine is the line where the chunk ends (inclusive).
+	MetaKeyEndLine = "_code_end_line"
+)
+
+// Kinds of declarations.
+const (
+	KindFunction = "function"
+	KindMethod   = "method"
+	KindType     = "type"
+	KindClass    = "class"
+	KindVar      = "var"
+	KindConst    = "const"
+)
+
+// extensionMetaKey is the metadata key of file extensions set by the file loader.
+const extensionMetaKey = "_extension"
+
+// Language is a programming language, e.g. LanguageGo.
+type Language string
+
+// IDGenerator generates new IDs for split chunks
+type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
+
+// defaultIDGenerator keeps the original ID
+func defaultIDGenerator(ctx context.Context, originalID string, _ int) string {
+	return originalID
+}
+
+type Config struct {
+	// ChunkSize is the max length of chunks measured by LenFunc, required.
+	// Top level declarations are split into separate chunks, and those longer than ChunkSize are split further
+	// by their nested blocks and at last by lines. Adjacent chunks of the same declaration, or of no declaration
+	// such as imports, are merged as long as they fit in ChunkSize.
+	ChunkSize int
+	// LenFunc calculates the length of chunks, builtin len() by default.
+	LenFunc func(string) int
+	// Language is the language of the documents. If empty, it is detected from the "_extension" metadata
+	// set by the file loader. Go source is split by its syntax tree, other languages by their Grammar,
+	// and unknown languages by brackets without recognizing symbols.
+	Language Language
+	// Grammars adds or overrides the grammars of languages, see the preset grammars such as PythonGrammar.
+	Grammars map[Language]*Grammar
+	// IDGenerator is an optional function to generate new IDs for split chunks.
+	// If nil, the original document ID will be used for all splits.
+	IDGenerator IDGenerator
+	// RecordProvenance specifies whether to record where each chunk comes from in its metadata, see package provenance.
+	RecordProvenance bool
+}
+
+// NewSplitter creates a code splitter.
+func NewSplitter(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config.ChunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be greater than zero")
+	}
+	for lang, g := range config.Grammars {
+		if g == nil {
+			return nil, fmt.Errorf("grammar of language [%s] is nil", lang)
+		}
+	}
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
+	idGenerator := config.IDGenerator
+	if idGenerator == nil {
+		idGenerator = defaultIDGenerator
+	}
+	return &splitter{
+		chunkSize:        config.ChunkSize,
+		lenFunc:          lenFunc,
+		language:         config.Language,
+		grammars:         config.Grammars,
+		idGenerator:      idGenerator,
+		recordProvenance: config.RecordProvenance,
+	}, nil
+}
+
+type splitter struct {
+	chunkSize        int
+	lenFunc          func(string) int
+	language         Language
+	grammars         map[Language]*Grammar
+	idGenerator      IDGenerator
+	recordProvenance bool
+}
+
+func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var ret []*schema.Document
+	for _, doc := range docs {
+		lang := s.language
+		if lang == "" {
+			ext, _ := doc.MetaData[extensionMetaKey].(string)
+			lang = LanguageOf(ext)
+		}
+		src := newSource(doc.Content, s.grammar(lang))
+		var (
+			pieces []piece
+			pkg    string
+		)
+		if lang == LanguageGo {
+			pieces, pkg = s.splitGo(src)
+		} else {
+			pieces, pkg = s.splitCode(src), src.packageName()
+		}
+
+		var provs []*provenance.Provenance
+		if s.recordProvenance {
+			spans := make([]provenance.Span, len(pieces))
+			for i, p := range pieces {
+				spans[i] = provenance.Span{Start: src.lineStarts[p.start], End: src.lineEnd(p.end - 1)}
+			}
+			provs = provenance.Build(doc.ID, doc.Content, spans)
+		}
+		for i, p := range pieces {
+			meta := make(map[string]any, len(doc.MetaData)+7)
+			for k, v := range doc.MetaData {
+				meta[k] = v
+			}
+			if lang != "" {
+				meta[MetaKeyLanguage] = string(lang)
+			}
+			if pkg != "" {
+				meta[MetaKeyPackage] = pkg
+			}
+			if p.sym.name != "" {
+				meta[MetaKeySymbol] = p.sym.name
+				meta[MetaKeyKind] = p.sym.kind
+			}
+			if p.sym.receiver != "" {
+				meta[MetaKeyReceiver] = p.sym.receiver
+			}
+			meta[MetaKeyStartLine] = p.start + 1
+			meta[MetaKeyEndLine] = p.end
+			if provs != nil {
+				provenance.Set(meta, provs[i])
+			}
+			ret = append(ret, &schema.Document{
+				ID:       s.idGenerator(ctx, doc.ID, i),
+				Content:  src.text(p.start, p.end),
+				MetaData: meta,
+			})
+		}
+	}
+	return ret, nil
+}
+
+func (s *splitter) GetType() string {
+	return "CodeSplitter"
+}
+
+func (s *splitter) grammar(lang Language) *Grammar {
+	if g, ok := s.grammars[lang]; ok {
+		return g
+	}
+	if g, ok := grammars[lang]; ok {
+		return g
+	}
+	return defaultGrammar
+}
+
+// source is the content of a document split into lines.
+type source struct {
+	content    string
+	grammar    *Grammar
+	lines      []string
+	lineStarts []int
+	infos      []lineInfo
+}
+
+func newSource(content string, g *Grammar) *source {
+	lines := strings.Split(content, "\n")
+	lineStarts := make([]int, len(lines))
+	offset := 0
+	for i, line := range lines {
+		lineStarts[i] = offset
+		offset += len(line) + 1
+	}
+	return &source{
+		content:    content,
+		grammar:    g,
+		lines:      lines,
+		lineStarts: lineStarts,
+		infos:      g.analyze(lines),
+	}
+}
+
+func (src *source) lineEnd(i int) int {
+	return src.lineStarts[i] + len(src.lines[i])
+}
+
+// text returns the content of lines [start, end).
+func (src *source) text(start, end int) string {
+	return src.content[src.lineStarts[start]:src.lineEnd(end-1)]
+}
+
+// trim excludes the blank lines at both ends of lines [start, end).
+func (src *source) trim(start, end int) (int, int) {
+	for start < end && src.infos[start].blank {
+		start++
+	}
+	for end > start && src.infos[end-1].blank {
+		end--
+	}
+	return start, end
+}
+
+func (src *source) packageName() string {
+	if src.grammar.Package == nil {
+		return ""
+	}
+	for i, line := range src.lines {
+		if src.infos[i].blank || src.infos[i].comment {
+			continue
+		}
+		if m := src.grammar.Package.FindStringSubmatch(strings.TrimSpace(line)); len(m) > 1 {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// isHead reports whether line i can start a declaration at level.
+func (src *source) isHead(i, level int) bool {
+	info := src.infos[i]
+	return !info.blank && !info.comment && !info.attached && !info.cont && info.level == level
+}
+
+// nextLevel returns the lowest level greater than level of the lines which can start a declaration, or -1 if none.
+func (src *source) nextLevel(start, end, level int) int {
+	next := -1
+	for i := start; i < end; i++ {
+		info := src.infos[i]
+		if info.blank || info.comment || info.attached || info.cont || info.level <= level {
+			continue
+		}
+		if next < 0 || info.level < next {
+			next = info.level
+		}
+	}
+	return next
+}
+
+// unit is a range of lines starting with comments and a head line at some level.
+type unit struct {
+	start, end int
+	// head is the first code line of the unit at the level, -1 if none.
+	head int
+}
+
+// units splits lines [start, end) before every head line at level, the comments and attached lines right before
+// a head line belong to its unit. Code lines before the first head line, e.g. the header of a class, form a unit
+// without head.
+func (src *source) units(start, end, level int) []unit {
+	var ret []unit
+	cur := unit{start: start, head: -1}
+	pending := -1
+	code := false
+	for i := start; i < end; i++ {
+		info := src.infos[i]
+		switch {
+		case info.blank:
+		case (info.comment || info.attached) && !info.cont && info.level == level:
+			if pending < 0 {
+				pending = i
+			}
+		case !src.isHead(i, level):
+			pending = -1
+			code = true
+		default:
+			if code {
+				cut := i
+				if pending >= 0 {
+					cut = pending
+				}
+				cur.end = cut
+				ret = append(ret, cur)
+				cur = unit{start: cut, head: -1}
+			}
+			cur.head = i
+			pending = -1
+			code = true
+		}
+	}
+	cur.end = end
+	return append(ret, cur)
+}
+
+// piece is a chunk of lines [start, end) of a declaration.
+type piece struct {
+	start, end int
+	sym        symbol
+}
+
+// splitCode splits the source by the grammar.
+func (s *splitter) splitCode(src *source) []piece {
+	var pieces []piece
+	level := src.nextLevel(0, len(src.lines), -1)
+	if level < 0 {
+		s.splitRange(src, 0, len(src.lines), 0, symbol{}, &pieces)
+	} else {
+		s.splitUnits(src, 0, len(src.lines), level, symbol{}, &pieces)
+	}
+	return s.merge(src, pieces)
+}
+
+// splitUnits splits lines [start, end) into units at level, the symbols of units are recognized by their head lines.
+func (s *splitter) splitUnits(src *source, start, end, level int, parent symbol, pieces *[]piece) {
+	for _, u := range src.units(start, end, level) {
+		sym := parent
+		if u.head >= 0 && (parent.name == "" || parent.kind == KindClass || parent.kind == KindType) {
+			if m, ok := src.grammar.match(strings.TrimSpace(src.lines[u.head])); ok {
+				sym = m
+				if parent.name != "" {
+					if sym.kind == KindFunction {
+						sym.kind = KindMethod
+					}
+					sym.receiver = parent.name
+				}
+			}
+		}
+		s.splitRange(src, u.start, u.end, level, sym, pieces)
+	}
+}
+
+// splitRange splits lines [start, end) of a declaration into pieces no longer than the chunk size,
+// by its nested blocks if possible, otherwise by lines.
+func (s *splitter) splitRange(src *source, start, end, level int, sym symbol, pieces *[]piece) {
+	start, end = src.trim(start, end)
+	if start >= end {
+		return
+	}
+	if s.lenFunc(src.text(start, end)) <= s.chunkSize {
+		*pieces = append(*pieces, piece{start: start, end: end, sym: sym})
+		return
+	}
+	for next := src.nextLevel(start, end, level); next >= 0; next = src.nextLevel(start, end, next) {
+		if len(src.units(start, end, next)) > 1 {
+			s.splitUnits(src, start, end, next, sym, pieces)
+			return
+		}
+	}
+	// no nested blocks, pack lines
+	cur := start
+	for i := start + 1; i < end; i++ {
+		if s.lenFunc(src.text(cur, i+1)) > s.chunkSize {
+			if a, b := src.trim(cur, i); a < b {
+				*pieces = append(*pieces, piece{start: a, end: b, sym: sym})
+			}
+			cur = i
+		}
+	}
+	if a, b := src.trim(cur, end); a < b {
+		*pieces = append(*pieces, piece{start: a, end: b, sym: sym})
+	}
+}
+
+// merge merges adjacent pieces of the same symbol as long as they fit in the chunk size.
+func (s *splitter) merge(src *source, pieces []piece) []piece {
+	var ret []piece
+	for _, p := range pieces {
+		if n := len(ret); n > 0 && ret[n-1].sym == p.sym && s.lenFunc(src.text(ret[n-1].start, p.end)) <= s.chunkSize {
+			ret[n-1].end = p.end
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
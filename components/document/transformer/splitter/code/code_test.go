@@ -0,0 +1,251 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
+)
+
+type chunk struct {
+	content  string
+	symbol   any
+	kind     any
+	receiver any
+	lines    [2]any
+}
+
+func chunksOf(docs []*schema.Document) []chunk {
+	var ret []chunk
+	for _, doc := range docs {
+		ret = append(ret, chunk{
+			content:  doc.Content,
+			symbol:   doc.MetaData[MetaKeySymbol],
+			kind:     doc.MetaData[MetaKeyKind],
+			receiver: doc.MetaData[MetaKeyReceiver],
+			lines:    [2]any{doc.MetaData[MetaKeyStartLine], doc.MetaData[MetaKeyEndLine]},
+		})
+	}
+	return ret
+}
+
+const goSource = `// Package shape has shapes.
+package shape
+
+import (
+	"fmt"
+	"math"
+)
+
+// Circle is a circle.
+type Circle struct {
+	R float64
+}
+
+// Area returns the area.
+func (c *Circle) Area() float64 {
+	return math.Pi * c.R * c.R
+}
+
+// free comment
+
+func Describe(c *Circle) string {
+	s := "{"
+	return fmt.Sprint(s, c.Area())
+}
+
+const (
+	A = iota
+	B
+)
+`
+
+func TestGoSplitter(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewSplitter(ctx, &Config{ChunkSize: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := splitter.Transform(ctx, []*schema.Document{{
+		ID:       "doc",
+		Content:  goSource,
+		MetaData: map[string]any{"_extension": ".go"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []chunk{
+		{content: "// Package shape has shapes.\npackage shape\n\nimport (\n\t\"fmt\"\n\t\"math\"\n)", lines: [2]any{1, 7}},
+		{content: "// Circle is a circle.\ntype Circle struct {\n\tR float64\n}", symbol: "Circle", kind: KindType, lines: [2]any{9, 12}},
+		{content: "// Area returns the area.\nfunc (c *Circle) Area() float64 {\n\treturn math.Pi * c.R * c.R\n}", symbol: "Area", kind: KindMethod, receiver: "Circle", lines: [2]any{14, 17}},
+		{content: "// free comment\n\nfunc Describe(c *Circle) string {\n\ts := \"{\"\n\treturn fmt.Sprint(s, c.Area())\n}", symbol: "Describe", kind: KindFunction, lines: [2]any{19, 24}},
+		{content: "const (\n\tA = iota\n\tB\n)", symbol: "A", kind: KindConst, lines: [2]any{26, 29}},
+	}
+	if got := chunksOf(docs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for _, doc := range docs {
+		if doc.MetaData[MetaKeyPackage] != "shape" || doc.MetaData[MetaKeyLanguage] != "go" {
+			t.Errorf("unexpected meta %v", doc.MetaData)
+		}
+	}
+}
+
+func TestGoSplitterWithLargeDeclaration(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewSplitter(ctx, &Config{ChunkSize: 60, Language: LanguageGo, RecordProvenance: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "package main\n\nfunc main() {\n\tfor i := 0; i < 3; i++ {\n\t\tprintln(i)\n\t}\n\tif true {\n\t\tprintln(\"a long line of output\")\n\t}\n}\n"
+	docs, err := splitter.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []chunk{
+		{content: "package main", lines: [2]any{1, 1}},
+		{content: "func main() {\n\tfor i := 0; i < 3; i++ {\n\t\tprintln(i)\n\t}", symbol: "main", kind: KindFunction, lines: [2]any{3, 6}},
+		{content: "\tif true {\n\t\tprintln(\"a long line of output\")\n\t}\n}", symbol: "main", kind: KindFunction, lines: [2]any{7, 10}},
+	}
+	if got := chunksOf(docs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, doc := range docs {
+		p, _ := provenance.Get(doc.MetaData)
+		if content[p.Start:p.End] != doc.Content {
+			t.Errorf("offsets point to %q", content[p.Start:p.End])
+		}
+	}
+}
+
+func TestGoSplitterWithSyntaxError(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewSplitter(ctx, &Config{ChunkSize: 1000, Language: LanguageGo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "package main\n\nfunc (s *Server) Start() {\n\treturn 1 +\n}\n\nfunc stop() {}\n"
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []chunk{
+		{content: "package main", lines: [2]any{1, 1}},
+		{content: "func (s *Server) Start() {\n\treturn 1 +\n}", symbol: "Start", kind: KindMethod, receiver: "Server", lines: [2]any{3, 5}},
+		{content: "func stop() {}", symbol: "stop", kind: KindFunction, lines: [2]any{7, 7}},
+	}
+	if got := chunksOf(docs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if docs[0].MetaData[MetaKeyPackage] != "main" {
+		t.Errorf("unexpected meta %v", docs[0].MetaData)
+	}
+}
+
+func TestPythonSplitter(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewSplitter(ctx, &Config{ChunkSize: 80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Join([]string{
+		"import os",
+		"import sys",
+		"",
+		"class Greeter:",
+		`    """Greets people.`,
+		"",
+		`def not_a_function(): """`,
+		"",
+		"    @staticmethod",
+		"    def hello(name):",
+		"        return 'hello, ' + name",
+		"",
+		"    def bye(self, name):",
+		"        return ('bye, '",
+		"    + name)",
+		"",
+		"def main():",
+		"    print(Greeter.hello('world'))",
+	}, "\n")
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: content, MetaData: map[string]any{"_extension": ".py"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []chunk{
+		{content: "import os\nimport sys", lines: [2]any{1, 2}},
+		{content: "class Greeter:\n    \"\"\"Greets people.\n\ndef not_a_function(): \"\"\"", symbol: "Greeter", kind: KindClass, lines: [2]any{4, 7}},
+		{content: "    @staticmethod\n    def hello(name):\n        return 'hello, ' + name", symbol: "hello", kind: KindMethod, receiver: "Greeter", lines: [2]any{9, 11}},
+		{content: "    def bye(self, name):\n        return ('bye, '\n    + name)", symbol: "bye", kind: KindMethod, receiver: "Greeter", lines: [2]any{13, 15}},
+		{content: "def main():\n    print(Greeter.hello('world'))", symbol: "main", kind: KindFunction, lines: [2]any{17, 18}},
+	}
+	if got := chunksOf(docs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if docs[0].MetaData[MetaKeyLanguage] != "python" {
+		t.Errorf("unexpected meta %v", docs[0].MetaData)
+	}
+}
+
+func TestBraceSplitter(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewSplitter(ctx, &Config{ChunkSize: 110, Language: LanguageJava})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Join([]string{
+		"package com.example;",
+		"",
+		"/**",
+		" * A counter { with braces in comments.",
+		" */",
+		"public class Counter {",
+		"    private int count = 0;",
+		"",
+		"    @Override",
+		"    public String toString() {",
+		"        return \"}\" + count;",
+		"    }",
+		"",
+		"    public void inc() {",
+		"        count++;",
+		"    }",
+		"}",
+	}, "\n")
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []chunk{
+		{content: "package com.example;", lines: [2]any{1, 1}},
+		{content: "/**\n * A counter { with braces in comments.\n */\npublic class Counter {\n    private int count = 0;", symbol: "Counter", kind: KindClass, lines: [2]any{3, 7}},
+		{content: "    @Override\n    public String toString() {\n        return \"}\" + count;\n    }", symbol: "toString", kind: KindMethod, receiver: "Counter", lines: [2]any{9, 12}},
+		{content: "    public void inc() {\n        count++;\n    }\n}", symbol: "inc", kind: KindMethod, receiver: "Counter", lines: [2]any{14, 17}},
+	}
+	if got := chunksOf(docs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if docs[0].MetaData[MetaKeyPackage] != "com.example" {
+		t.Errorf("unexpected meta %v", docs[0].MetaData)
+	}
+}
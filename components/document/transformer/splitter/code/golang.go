@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// splitGo splits Go source by its top level declarations, the package clause and imports form the first chunk.
+// Free comments between declarations belong to the following declaration.
+// The source is split by GoGrammar if it can not be parsed.
+func (s *splitter) splitGo(src *source) ([]piece, string) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src.content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return s.splitCode(src), src.packageName()
+	}
+
+	var pieces []piece
+	// lines before start have been split
+	start := 0
+	header := fset.Position(file.Name.End()).Line
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			header = fset.Position(gen.End()).Line
+			continue
+		}
+		break
+	}
+	s.splitRange(src, start, header, 0, symbol{}, &pieces)
+	start = header
+
+	for _, decl := range file.Decls {
+		end := fset.Position(decl.End()).Line
+		if end <= start {
+			// declared on the same line as the previous one, or an import
+			continue
+		}
+		s.splitRange(src, start, end, 0, declSymbol(decl), &pieces)
+		start = end
+	}
+	// trailing comments belong to the last declaration
+	if start < len(src.lines) {
+		if n := len(pieces); n > 0 {
+			if a, b := src.trim(start, len(src.lines)); a < b {
+				pieces = append(pieces, piece{start: a, end: b, sym: pieces[n-1].sym})
+			}
+		} else {
+			s.splitRange(src, start, len(src.lines), 0, symbol{}, &pieces)
+		}
+	}
+	return s.merge(src, pieces), file.Name.Name
+}
+
+func declSymbol(decl ast.Decl) symbol {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil || len(d.Recv.List) == 0 {
+			return symbol{name: d.Name.Name, kind: KindFunction}
+		}
+		return symbol{name: d.Name.Name, kind: KindMethod, receiver: receiverName(d.Recv.List[0].Type)}
+	case *ast.GenDecl:
+		var kind string
+		switch d.Tok {
+		case token.TYPE:
+			kind = KindType
+		case token.VAR:
+			kind = KindVar
+		case token.CONST:
+			kind = KindConst
+		default:
+			return symbol{}
+		}
+		if len(d.Specs) == 0 {
+			return symbol{}
+		}
+		switch spec := d.Specs[0].(type) {
+		case *ast.TypeSpec:
+			return symbol{name: spec.Name.Name, kind: kind}
+		case *ast.ValueSpec:
+			if len(spec.Names) > 0 {
+				return symbol{name: spec.Names[0].Name, kind: kind}
+			}
+		}
+	}
+	return symbol{}
+}
+
+// receiverName returns the type name of a receiver, e.g. "T" of "*T[K]".
+func receiverName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
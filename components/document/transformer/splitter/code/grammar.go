@@ -0,0 +1,383 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Grammar describes the lexical structure of a language, which is enough to find the blocks and declarations of
+// source code without a full parser.
+type Grammar struct {
+	// Indentation specifies that blocks are delimited by indentation like Python, otherwise by brackets.
+	Indentation bool
+	// LineComments are the prefixes of line comments, e.g. "//" and "#".
+	LineComments []string
+	// BlockComment is the start and end of block comments, e.g. "/*" and "*/". Empty if not supported.
+	BlockComment [2]string
+	// Quotes are the quote characters of single line strings, e.g. `"'`.
+	Quotes string
+	// MultiLineQuotes are the delimiters of strings that can span lines, e.g. "`" and `"""`.
+	MultiLineQuotes []string
+	// Attached are the prefixes of lines attached to the following declaration like comments, e.g. "@" of decorators.
+	Attached []string
+	// Symbols recognize declarations by their first line, the first matched pattern wins.
+	Symbols []SymbolPattern
+	// Package matches the package declaration of a file, with the package name in the first group.
+	Package *regexp.Regexp
+}
+
+// SymbolPattern recognizes a kind of declarations.
+type SymbolPattern struct {
+	// Kind is the kind of the declarations, e.g. KindFunction.
+	Kind string
+	// Pattern matches the trimmed first line of a declaration, with the symbol name in the group named "name",
+	// and optionally the receiver in the group named "receiver".
+	Pattern *regexp.Regexp
+}
+
+// symbol is the declaration a chunk belongs to.
+type symbol struct {
+	name     string
+	kind     string
+	receiver string
+}
+
+// keywords are never symbol names, they are matched by loose patterns of function declarations, e.g. "if (x) {".
+var keywords = map[string]bool{
+	"if": true, "else": true, "elif": true, "for": true, "while": true, "switch": true, "catch": true,
+	"return": true, "new": true, "sizeof": true, "with": true, "match": true,
+}
+
+// match returns the symbol declared by the line.
+func (g *Grammar) match(line string) (symbol, bool) {
+	for _, p := range g.Symbols {
+		m := p.Pattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		sym := symbol{kind: p.Kind}
+		for i, name := range p.Pattern.SubexpNames() {
+			switch name {
+			case "name":
+				sym.name = m[i]
+			case "receiver":
+				sym.receiver = m[i]
+			}
+		}
+		if sym.name == "" || keywords[sym.name] {
+			continue
+		}
+		if sym.receiver != "" && sym.kind == KindFunction {
+			sym.kind = KindMethod
+		}
+		return sym, true
+	}
+	return symbol{}, false
+}
+
+// lineInfo is the structure of a source line.
+type lineInfo struct {
+	// level is the bracket depth at the start of the line, or its indentation width for indentation grammars.
+	level int
+	blank bool
+	// comment means the line only contains comments.
+	comment bool
+	// attached means the line is attached to the following declaration, e.g. a decorator.
+	attached bool
+	// cont means the line continues the previous one and can not start a declaration,
+	// e.g. a closing bracket, or a line in a multi-line string.
+	cont bool
+}
+
+const continuationSuffixes = "=,+-*/&|?(."
+
+// analyze scans lines and returns their structure, brackets in strings and comments are ignored.
+func (g *Grammar) analyze(lines []string) []lineInfo {
+	infos := make([]lineInfo, len(lines))
+	var (
+		depth    int
+		inBlock  bool
+		inQuote  string
+		prevCode string
+	)
+	for i, line := range lines {
+		info := &infos[i]
+		trimmed := strings.TrimSpace(line)
+		startInQuote := inQuote != ""
+		if trimmed == "" && !startInQuote {
+			info.blank = true
+			continue
+		}
+
+		if g.Indentation {
+			info.level = len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+			info.cont = startInQuote || depth > 0 || strings.HasSuffix(prevCode, "\\")
+		} else {
+			info.level = max(depth, 0)
+			info.cont = startInQuote || strings.HasPrefix(trimmed, ".") ||
+				strings.IndexByte(")]}", trimmed[0]) >= 0 ||
+				(prevCode != "" && strings.IndexByte(continuationSuffixes, prevCode[len(prevCode)-1]) >= 0)
+		}
+		if !startInQuote && !inBlock {
+			for _, prefix := range g.Attached {
+				if strings.HasPrefix(trimmed, prefix) {
+					info.attached = true
+					break
+				}
+			}
+		}
+
+		code := startInQuote
+	scan:
+		for j := 0; j < len(line); {
+			if inBlock {
+				k := strings.Index(line[j:], g.BlockComment[1])
+				if k < 0 {
+					break
+				}
+				j += k + len(g.BlockComment[1])
+				inBlock = false
+				continue
+			}
+			if inQuote != "" {
+				k := closingQuote(line[j:], inQuote)
+				if k < 0 {
+					break
+				}
+				j += k + len(inQuote)
+				inQuote = ""
+				continue
+			}
+			rest := line[j:]
+			for _, prefix := range g.LineComments {
+				if strings.HasPrefix(rest, prefix) {
+					break scan
+				}
+			}
+			if g.BlockComment[0] != "" && strings.HasPrefix(rest, g.BlockComment[0]) {
+				inBlock = true
+				j += len(g.BlockComment[0])
+				continue
+			}
+			code = true
+			if q := g.multiLineQuote(rest); q != "" {
+				inQuote = q
+				j += len(q)
+				continue
+			}
+			c := line[j]
+			if strings.IndexByte(g.Quotes, c) >= 0 {
+				// an unclosed quote is not a string, e.g. a lifetime of Rust
+				if k := closingQuote(line[j+1:], string(c)); k >= 0 {
+					j += k + 2
+					continue
+				}
+			}
+			switch c {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				depth--
+			}
+			j++
+		}
+		info.comment = !code
+		if code {
+			prevCode = trimmed
+		}
+	}
+	return infos
+}
+
+func (g *Grammar) multiLineQuote(s string) string {
+	for _, q := range g.MultiLineQuotes {
+		if strings.HasPrefix(s, q) {
+			return q
+		}
+	}
+	return ""
+}
+
+// closingQuote returns the index of the quote closing a string in s, skipping escaped characters, or -1 if not found.
+func closingQuote(s, quote string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], quote) {
+			return i
+		}
+	}
+	return -1
+}
+
+const (
+	// LanguageGo is split by its syntax tree, and by GoGrammar if the source can not be parsed.
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+	LanguageTypeScript Language = "typescript"
+	LanguageJava       Language = "java"
+	LanguageC          Language = "c"
+	LanguageCPP        Language = "cpp"
+	LanguageRust       Language = "rust"
+)
+
+var extensions = map[string]Language{
+	".go":   LanguageGo,
+	".py":   LanguagePython,
+	".js":   LanguageJavaScript,
+	".jsx":  LanguageJavaScript,
+	".mjs":  LanguageJavaScript,
+	".cjs":  LanguageJavaScript,
+	".ts":   LanguageTypeScript,
+	".tsx":  LanguageTypeScript,
+	".java": LanguageJava,
+	".c":    LanguageC,
+	".h":    LanguageC,
+	".cc":   LanguageCPP,
+	".cpp":  LanguageCPP,
+	".cxx":  LanguageCPP,
+	".hh":   LanguageCPP,
+	".hpp":  LanguageCPP,
+	".rs":   LanguageRust,
+}
+
+// LanguageOf returns the language of a file extension such as ".go", or an empty Language if unknown.
+func LanguageOf(ext string) Language {
+	return extensions[strings.ToLower(ext)]
+}
+
+var (
+	// GoGrammar is the grammar of Go.
+	GoGrammar = &Grammar{
+		LineComments:    []string{"//"},
+		BlockComment:    [2]string{"/*", "*/"},
+		Quotes:          `"'`,
+		MultiLineQuotes: []string{"`"},
+		Symbols: []SymbolPattern{
+			{Kind: KindFunction, Pattern: regexp.MustCompile(`^func\s*\(\s*(?:\w+\s+)?\*?\s*(?P<receiver>\w+)[^)]*\)\s*(?P<name>\w+)`)},
+			{Kind: KindFunction, Pattern: regexp.MustCompile(`^func\s+(?P<name>\w+)`)},
+			{Kind: KindType, Pattern: regexp.MustCompile(`^type\s+(?P<name>\w+)`)},
+			{Kind: KindVar, Pattern: regexp.MustCompile(`^var\s+(?:\(\s*)?(?P<name>\w+)`)},
+			{Kind: KindConst, Pattern: regexp.MustCompile(`^const\s+(?:\(\s*)?(?P<name>\w+)`)},
+		},
+		Package: regexp.MustCompile(`^package\s+(\w+)`),
+	}
+	// PythonGrammar is the grammar of Python.
+	PythonGrammar = &Grammar{
+		Indentation:     true,
+		LineComments:    []string{"#"},
+		Quotes:          `"'`,
+		MultiLineQuotes: []string{`"""`, `'''`},
+		Attached:        []string{"@"},
+		Symbols: []SymbolPattern{
+			{Kind: KindFunction, Pattern: regexp.MustCompile(`^(?:async\s+)?def\s+(?P<name>\w+)`)},
+			{Kind: KindClass, Pattern: regexp.MustCompile(`^class\s+(?P<name>\w+)`)},
+			{Kind: KindVar, Pattern: regexp.MustCompile(`^(?P<name>[A-Za-z_]\w*)\s*(?::[^=]+)?=[^=]`)},
+		},
+	}
+	// JavaScriptGrammar is the grammar of JavaScript.
+	JavaScriptGrammar = &Grammar{
+		LineComments:    []string{"//"},
+		BlockComment:    [2]string{"/*", "*/"},
+		Quotes:          `"'`,
+		MultiLineQuotes: []string{"`"},
+		Attached:        []string{"@"},
+		Symbols:         scriptSymbols,
+	}
+	// TypeScriptGrammar is the grammar of TypeScript.
+	TypeScriptGrammar = &Grammar{
+		LineComments:    []string{"//"},
+		BlockComment:    [2]string{"/*", "*/"},
+		Quotes:          `"'`,
+		MultiLineQuotes: []string{"`"},
+		Attached:        []string{"@"},
+		Symbols: append([]SymbolPattern{
+			{Kind: KindType, Pattern: regexp.MustCompile(`^(?:export\s+)?(?:declare\s+)?(?:interface|type|enum)\s+(?P<name>\w+)`)},
+		}, scriptSymbols...),
+	}
+	// JavaGrammar is the grammar of Java.
+	JavaGrammar = &Grammar{
+		LineComments: []string{"//"},
+		BlockComment: [2]string{"/*", "*/"},
+		Quotes:       `"'`,
+		Attached:     []string{"@"},
+		Symbols: []SymbolPattern{
+			{Kind: KindClass, Pattern: regexp.MustCompile(`^(?:(?:public|protected|private|abstract|static|final|sealed|non-sealed)\s+)*(?:class|interface|enum|record|@interface)\s+(?P<name>\w+)`)},
+			{Kind: KindFunction, Pattern: regexp.MustCompile(`^(?:(?:public|protected|private|abstract|static|final|synchronized|native|default)\s+)*(?:<[^>]*>\s+)?(?:[\w.<>\[\]?, ]+\s+)?(?P<name>\w+)\s*\(`)},
+		},
+		Package: regexp.MustCompile(`^package\s+([\w.]+)`),
+	}
+	// CGrammar is the grammar of C and C++.
+	CGrammar = &Grammar{
+		LineComments: []string{"//"},
+		BlockComment: [2]string{"/*", "*/"},
+		Quotes:       `"'`,
+		Symbols: []SymbolPattern{
+			{Kind: KindClass, Pattern: regexp.MustCompile(`^(?:template\s*<.*>\s*)?(?:class|struct)\s+(?P<name>\w+)[^;]*$`)},
+			{Kind: KindType, Pattern: regexp.MustCompile(`^(?:typedef\s+)?(?:union|enum(?:\s+class)?)\s+(?P<name>\w+)`)},
+			{Kind: KindFunction, Pattern: regexp.MustCompile(`^(?:[\w:<>,*&]+\s+)*[*&]*(?:(?P<receiver>\w+)::)?(?P<name>~?\w+)\s*\([^;]*$`)},
+		},
+		Package: regexp.MustCompile(`^namespace\s+([\w:]+)`),
+	}
+	// RustGrammar is the grammar of Rust.
+	RustGrammar = &Grammar{
+		LineComments: []string{"//"},
+		BlockComment: [2]string{"/*", "*/"},
+		Quotes:       `"`,
+		Attached:     []string{"#["},
+		Symbols: []SymbolPattern{
+			{Kind: KindFunction, Pattern: regexp.MustCompile(`^(?:pub(?:\([^)]*\))?\s+)?(?:(?:const|async|unsafe|extern(?:\s+"\w+")?)\s+)*fn\s+(?P<name>\w+)`)},
+			{Kind: KindType, Pattern: regexp.MustCompile(`^(?:pub(?:\([^)]*\))?\s+)?(?:struct|enum|union|type)\s+(?P<name>\w+)`)},
+			{Kind: KindClass, Pattern: regexp.MustCompile(`^(?:pub(?:\([^)]*\))?\s+)?(?:unsafe\s+)?trait\s+(?P<name>\w+)`)},
+			{Kind: KindClass, Pattern: regexp.MustCompile(`^(?:unsafe\s+)?impl(?:<[^>]*>)?\s+(?:[\w:<>, ]+\s+for\s+)?(?P<name>\w+)`)},
+			{Kind: KindConst, Pattern: regexp.MustCompile(`^(?:pub(?:\([^)]*\))?\s+)?(?:const|static(?:\s+mut)?)\s+(?P<name>\w+)`)},
+			{Kind: KindType, Pattern: regexp.MustCompile(`^(?:pub(?:\([^)]*\))?\s+)?mod\s+(?P<name>\w+)`)},
+		},
+	}
+	// defaultGrammar splits unknown languages by brackets, without recognizing symbols.
+	defaultGrammar = &Grammar{
+		LineComments:    []string{"//", "#"},
+		BlockComment:    [2]string{"/*", "*/"},
+		Quotes:          `"'`,
+		MultiLineQuotes: []string{"`"},
+	}
+)
+
+var scriptSymbols = []SymbolPattern{
+	{Kind: KindFunction, Pattern: regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*(?P<name>\w+)`)},
+	{Kind: KindClass, Pattern: regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+(?P<name>\w+)`)},
+	{Kind: KindFunction, Pattern: regexp.MustCompile(`^(?:export\s+)?(?:const|let|var)\s+(?P<name>\w+)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*(?::[^=]+)?=>|\w+\s*=>)`)},
+	{Kind: KindVar, Pattern: regexp.MustCompile(`^(?:export\s+)?(?:const|let|var)\s+(?P<name>\w+)`)},
+	{Kind: KindFunction, Pattern: regexp.MustCompile(`^(?:(?:public|private|protected|static|async|readonly|override|get|set)\s+)*\*?(?P<name>\w+)\s*(?:<[^>]*>)?\s*\([^)]*\)\s*(?::[^{]+)?\{`)},
+}
+
+var grammars = map[Language]*Grammar{
+	LanguageGo:         GoGrammar,
+	LanguagePython:     PythonGrammar,
+	LanguageJavaScript: JavaScriptGrammar,
+	LanguageTypeScript: TypeScriptGrammar,
+	LanguageJava:       JavaGrammar,
+	LanguageC:          CGrammar,
+	LanguageCPP:        CGrammar,
+	LanguageRust:       RustGrammar,
+}
@@ -0,0 +1,14 @@
+module github.com/cloudwego/eino-ext/components/document/transformer/splitter/common
+
+go 1.23.0
+
+require (
+	github.com/dlclark/regexp2 v1.11.4
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provenance records where a chunk comes from in the metadata of split documents,
+// so that retrieved chunks can be highlighted in the source document and their neighbours can be fetched.
+//
+// Splitters with a RecordProvenance option record the parent ID, the chunk index and total, the character offsets
+// of the chunk in the parent content and its overlap with the previous chunk, under the MetaKey* keys below.
+// Offsets count characters (runes) rather than bytes, and are -1 if the chunk can not be located in the parent,
+// e.g. when the splitter rewrites the chunk content.
+package provenance
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MetaKeyParentID is the ID of the document the chunk is split from.
+	MetaKeyParentID = "_parent_id"
+	// MetaKeyChunkIndex is the index of the chunk among the chunks of its parent, starting from 0.
+	MetaKeyChunkIndex = "_chunk_index"
+	// MetaKeyChunkTotal is the number of chunks split from the parent.
+	MetaKeyChunkTotal = "_chunk_total"
+	// MetaKeyStartOffset is the character offset in the parent content where the chunk starts, -1 if unknown.
+	MetaKeyStartOffset = "_start_offset"
+	// MetaKeyEndOffset is the character offset in the parent content where the chunk ends (exclusive), -1 if unknown.
+	MetaKeyEndOffset = "_end_offset"
+	// MetaKeyOverlap is the number of characters the chunk shares with the previous chunk.
+	MetaKeyOverlap = "_overlap"
+)
+
+// Provenance is where a chunk comes from, offsets are in characters.
+type Provenance struct {
+	ParentID string
+	Index    int
+	Total    int
+	Start    int
+	End      int
+	Overlap  int
+}
+
+// Span is the byte range of a chunk in its parent content, Start and End are -1 if unknown.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Known reports whether the span is located in the parent content.
+func (s Span) Known() bool {
+	return s.Start >= 0 && s.End >= s.Start
+}
+
+// Set records p in meta.
+func Set(meta map[string]any, p *Provenance) {
+	meta[MetaKeyParentID] = p.ParentID
+	meta[MetaKeyChunkIndex] = p.Index
+	meta[MetaKeyChunkTotal] = p.Total
+	meta[MetaKeyStartOffset] = p.Start
+	meta[MetaKeyEndOffset] = p.End
+	meta[MetaKeyOverlap] = p.Overlap
+}
+
+// Get returns the provenance recorded in meta, the bool return value is false if there is none.
+func Get(meta map[string]any) (*Provenance, bool) {
+	index, ok := meta[MetaKeyChunkIndex].(int)
+	if !ok {
+		return nil, false
+	}
+	p := &Provenance{Index: index}
+	p.ParentID, _ = meta[MetaKeyParentID].(string)
+	p.Total, _ = meta[MetaKeyChunkTotal].(int)
+	p.Start, _ = meta[MetaKeyStartOffset].(int)
+	p.End, _ = meta[MetaKeyEndOffset].(int)
+	p.Overlap, _ = meta[MetaKeyOverlap].(int)
+	return p, true
+}
+
+// Build returns the provenance of every chunk split from the parent, spans are the byte ranges of the chunks in content in order.
+// The offsets are converted to characters, and the overlap of a chunk is computed from its span and the span of the previous chunk.
+func Build(parentID, content string, spans []Span) []*Provenance {
+	var (
+		ret   = make([]*Provenance, len(spans))
+		runes = make([]Span, len(spans))
+		conv  = &runeCounter{content: content}
+	)
+	for i, span := range spans {
+		runes[i] = Span{Start: -1, End: -1}
+		if span.Known() && span.End <= len(content) {
+			runes[i] = Span{Start: conv.count(span.Start), End: conv.count(span.End)}
+		}
+	}
+	for i, span := range runes {
+		p := &Provenance{
+			ParentID: parentID,
+			Index:    i,
+			Total:    len(spans),
+			Start:    span.Start,
+			End:      span.End,
+		}
+		if span.Known() && i > 0 && runes[i-1].Known() && runes[i-1].End > span.Start {
+			p.Overlap = min(runes[i-1].End, span.End) - span.Start
+		}
+		ret[i] = p
+	}
+	return ret
+}
+
+// runeCounter counts the runes before byte offsets of content, resuming from the last offset when they increase.
+type runeCounter struct {
+	content string
+	offset  int
+	runes   int
+}
+
+func (c *runeCounter) count(offset int) int {
+	if offset < c.offset {
+		c.offset, c.runes = 0, 0
+	}
+	c.runes += utf8.RuneCountInString(c.content[c.offset:offset])
+	c.offset = offset
+	return c.runes
+}
+
+// Locate finds chunks in content in order, for splitters whose chunks are substrings of the content.
+// Chunks are searched forward from the start of the previous chunk, so overlapping chunks are located too.
+// The span of a chunk which is not found is unknown.
+func Locate(content string, chunks []string) []Span {
+	spans := make([]Span, len(chunks))
+	cursor := 0
+	for i, chunk := range chunks {
+		idx := -1
+		if cursor <= len(content) {
+			if idx = strings.Index(content[cursor:], chunk); idx >= 0 {
+				idx += cursor
+			}
+		}
+		if idx < 0 {
+			spans[i] = Span{Start: -1, End: -1}
+			continue
+		}
+		spans[i] = Span{Start: idx, End: idx + len(chunk)}
+		cursor = idx + 1
+	}
+	return spans
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provenance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocateAndBuild(t *testing.T) {
+	content := "one two three two three four"
+	spans := Locate(content, []string{"one two three", "two three", "three four", "missing"})
+	assert.Equal(t, []Span{{0, 13}, {4, 13}, {18, 28}, {-1, -1}}, spans)
+
+	// repeated text is located after the previous chunk
+	spans = Locate(content, []string{"two three", "two three four"})
+	assert.Equal(t, []Span{{4, 13}, {14, 28}}, spans)
+
+	provs := Build("doc", content, []Span{{0, 13}, {8, 20}, {-1, -1}, {20, 28}})
+	assert.Equal(t, &Provenance{ParentID: "doc", Index: 0, Total: 4, Start: 0, End: 13}, provs[0])
+	assert.Equal(t, &Provenance{ParentID: "doc", Index: 1, Total: 4, Start: 8, End: 20, Overlap: 5}, provs[1])
+	assert.Equal(t, &Provenance{ParentID: "doc", Index: 2, Total: 4, Start: -1, End: -1}, provs[2])
+	assert.Equal(t, 0, provs[3].Overlap)
+}
+
+func TestSetGet(t *testing.T) {
+	meta := map[string]any{}
+	_, ok := Get(meta)
+	assert.False(t, ok)
+
+	p := &Provenance{ParentID: "doc", Index: 1, Total: 3, Start: 10, End: 20, Overlap: 2}
+	Set(meta, p)
+	got, ok := Get(meta)
+	assert.True(t, ok)
+	assert.Equal(t, p, got)
+}
+
+func TestBuildRuneOffsets(t *testing.T) {
+	content := "你好, world, 你好"
+	spans := Locate(content, []string{"你好, world", "world, 你好"})
+	assert.Equal(t, []Span{{0, 13}, {8, 21}}, spans)
+
+	provs := Build("doc", content, append(spans, Span{Start: 8, End: 100}))
+	assert.Equal(t, &Provenance{ParentID: "doc", Index: 0, Total: 3, Start: 0, End: 9}, provs[0])
+	assert.Equal(t, &Provenance{ParentID: "doc", Index: 1, Total: 3, Start: 4, End: 13, Overlap: 5}, provs[1])
+	assert.Equal(t, &Provenance{ParentID: "doc", Index: 2, Total: 3, Start: -1, End: -1}, provs[2])
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package separator provides separator presets of splitters for different languages.
+package separator
+
+import "fmt"
+
+// Preset is a named list of separators, ordered from the coarsest to the finest.
+type Preset string
+
+const (
+	// English splits by lines and the end of sentences, it is the default of splitters.
+	English Preset = "english"
+	// Chinese splits by paragraphs, lines and Chinese and English sentence punctuation.
+	Chinese Preset = "chinese"
+	// Go splits Go source code by top level declarations, then by blocks, lines and words.
+	Go Preset = "go"
+	// Python splits Python source code by classes and functions, then by lines and words.
+	Python Preset = "python"
+	// JavaScript splits JavaScript and TypeScript source code by functions, classes and statements,
+	// then by lines and words.
+	JavaScript Preset = "javascript"
+	// Markdown splits Markdown text by headings, code blocks and paragraphs, then by lines and sentences.
+	Markdown Preset = "markdown"
+)
+
+var presets = map[Preset][]string{
+	English: {"\n", ".", "?", "!"},
+	Chinese: {"\n\n", "\n", "。", "！", "？", "；", ".", "!", "?", ";"},
+	Go: {
+		"\nfunc ", "\nvar ", "\nconst ", "\ntype ",
+		"\n\tif ", "\n\tfor ", "\n\tswitch ", "\n\tcase ",
+		"\n\n", "\n", " ",
+	},
+	Python: {"\nclass ", "\ndef ", "\n\tdef ", "\n    def ", "\n\n", "\n", " "},
+	JavaScript: {
+		"\nfunction ", "\nexport ", "\nclass ", "\nconst ", "\nlet ", "\nvar ",
+		"\nif ", "\nfor ", "\nwhile ", "\nswitch ", "\ncase ", "\ndefault ",
+		"\n\n", "\n", " ",
+	},
+	Markdown: {"\n# ", "\n## ", "\n### ", "\n#### ", "\n```", "\n\n", "\n", ".", "?", "!"},
+}
+
+// Separators returns a copy of the separators of preset.
+// Code presets keep the keywords in the separators, so splitters should keep separators in the chunks,
+// e.g. recursive.KeepTypeStart.
+func Separators(preset Preset) ([]string, error) {
+	seps, ok := presets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown separator preset: %s", preset)
+	}
+	return append([]string(nil), seps...), nil
+}
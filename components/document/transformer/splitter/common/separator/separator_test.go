@@ -0,0 +1,41 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package separator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeparators(t *testing.T) {
+	seps, err := Separators(Chinese)
+	assert.NoError(t, err)
+	assert.Contains(t, seps, "。")
+
+	seps[0] = "x"
+	seps, err = Separators(Chinese)
+	assert.NoError(t, err)
+	assert.Equal(t, "\n\n", seps[0])
+
+	seps, err = Separators(English)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"\n", ".", "?", "!"}, seps)
+
+	_, err = Separators("cobol")
+	assert.ErrorContains(t, err, "unknown separator preset")
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+)
+
+// Encoding is a byte pair encoding, compatible with the tiktoken vocabularies.
+// Special tokens are not recognized, they are encoded as ordinary text.
+type Encoding struct {
+	name    string
+	pattern *regexp2.Regexp
+	ranks   map[string]int
+	tokens  map[int]string
+}
+
+// NewEncoding creates an Encoding from a vocabulary in tiktoken format, which has a base64 encoded token and its rank
+// on each line, pattern is the regular expression splitting text into words before merging byte pairs.
+func NewEncoding(name, pattern string, vocab io.Reader) (*Encoding, error) {
+	re, err := regexp2.Compile(pattern, regexp2.None)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	enc := &Encoding{
+		name:    name,
+		pattern: re,
+		ranks:   make(map[string]int),
+		tokens:  make(map[int]string),
+	}
+	scanner := bufio.NewScanner(vocab)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		token, rank, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid vocabulary at line %d", line)
+		}
+		b, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token at line %d: %w", line, err)
+		}
+		r, err := strconv.Atoi(rank)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank at line %d: %w", line, err)
+		}
+		enc.ranks[string(b)] = r
+		enc.tokens[r] = string(b)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(enc.ranks) == 0 {
+		return nil, fmt.Errorf("vocabulary is empty")
+	}
+	return enc, nil
+}
+
+// Name returns the name of the encoding.
+func (e *Encoding) Name() string {
+	return e.name
+}
+
+// Encode returns the tokens of text.
+func (e *Encoding) Encode(text string) []int {
+	var tokens []int
+	e.words(text, func(word string) {
+		if rank, ok := e.ranks[word]; ok {
+			tokens = append(tokens, rank)
+			return
+		}
+		tokens = append(tokens, e.merge(word)...)
+	})
+	return tokens
+}
+
+// Count returns the number of tokens of text, it can be used as the LenFunc of splitters.
+func (e *Encoding) Count(text string) int {
+	count := 0
+	e.words(text, func(word string) {
+		if _, ok := e.ranks[word]; ok {
+			count++
+			return
+		}
+		count += len(e.merge(word))
+	})
+	return count
+}
+
+// Decode returns the text of tokens, unknown tokens are ignored.
+func (e *Encoding) Decode(tokens []int) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteString(e.tokens[token])
+	}
+	return sb.String()
+}
+
+func (e *Encoding) words(text string, fn func(word string)) {
+	m, err := e.pattern.FindStringMatch(text)
+	for err == nil && m != nil {
+		fn(m.String())
+		m, err = e.pattern.FindNextMatch(m)
+	}
+}
+
+// merge merges the bytes of word by the rank of adjacent pairs, the pair with the lowest rank is merged first.
+func (e *Encoding) merge(word string) []int {
+	// parts are the start offsets of the current tokens, with the end of word at last
+	parts := make([]int, len(word)+1)
+	for i := range parts {
+		parts[i] = i
+	}
+
+	rank := func(i int) int {
+		if i+2 >= len(parts) {
+			return math.MaxInt
+		}
+		if r, ok := e.ranks[word[parts[i]:parts[i+2]]]; ok {
+			return r
+		}
+		return math.MaxInt
+	}
+	ranks := make([]int, len(parts))
+	for i := range ranks {
+		ranks[i] = rank(i)
+	}
+
+	for len(parts) > 2 {
+		minIdx, minRank := -1, math.MaxInt
+		for i := 0; i < len(ranks)-2; i++ {
+			if ranks[i] < minRank {
+				minIdx, minRank = i, ranks[i]
+			}
+		}
+		if minIdx < 0 {
+			break
+		}
+		parts = append(parts[:minIdx+1], parts[minIdx+2:]...)
+		ranks = append(ranks[:minIdx+1], ranks[minIdx+2:]...)
+		ranks[minIdx] = rank(minIdx)
+		if minIdx > 0 {
+			ranks[minIdx-1] = rank(minIdx - 1)
+		}
+	}
+
+	tokens := make([]int, 0, len(parts)-1)
+	for i := 0; i < len(parts)-1; i++ {
+		if r, ok := e.ranks[word[parts[i]:parts[i+1]]]; ok {
+			tokens = append(tokens, r)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// fetchvocab downloads the vocabularies of the builtin encodings and verifies their sha256.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+var vocabularies = []struct {
+	name   string
+	url    string
+	sha256 string
+}{
+	{
+		name:   "cl100k_base.tiktoken",
+		url:    "https://openaipublic.blob.core.windows.net/encodings/cl100k_base.tiktoken",
+		sha256: "223921b76ee99bde995b7ff738513eef100fb51d18c93597a113bcffe865b2a7",
+	},
+	{
+		name:   "o200k_base.tiktoken",
+		url:    "https://openaipublic.blob.core.windows.net/encodings/o200k_base.tiktoken",
+		sha256: "446a9538cb6c348e3516120d7c08b09f57c36495e2acfffe59a5bf8b0cfb1a2d",
+	},
+}
+
+func main() {
+	dir := flag.String("dir", "vocab", "directory to save the vocabularies")
+	force := flag.Bool("force", false, "download even if the file exists")
+	flag.Parse()
+
+	for _, v := range vocabularies {
+		path := filepath.Join(*dir, v.name)
+		if !*force {
+			if data, err := os.ReadFile(path); err == nil && checksum(data) == v.sha256 {
+				continue
+			}
+		}
+		if err := download(v.url, path, v.sha256); err != nil {
+			log.Fatalf("download %s fail: %v", v.name, err)
+		}
+		log.Printf("downloaded %s", path)
+	}
+}
+
+func download(url, path, sum string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if got := checksum(data); got != sum {
+		return fmt.Errorf("sha256 mismatch, expected %s, got %s", sum, got)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
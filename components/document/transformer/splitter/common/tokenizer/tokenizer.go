@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tokenizer provides length functions for splitters, which count the tokens of a text with the
+// BPE vocabularies of OpenAI models, or count the runes of a text.
+package tokenizer
+
+//go:generate go run ./internal/fetchvocab -dir vocab
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"unicode/utf8"
+)
+
+// Names of the builtin length functions, see LenFunc.
+const (
+	// Cl100kBase is the encoding of gpt-4, gpt-3.5-turbo and text-embedding-3 models.
+	Cl100kBase = "cl100k_base"
+	// O200kBase is the encoding of gpt-4o and o-series models.
+	O200kBase = "o200k_base"
+	// Runes counts the unicode code points, which is a better estimate than bytes for CJK text.
+	Runes = "runes"
+	// Bytes counts the bytes, the same as the builtin len().
+	Bytes = "bytes"
+)
+
+// ErrVocabularyNotFound is returned when the vocabulary file of a builtin encoding is not embedded.
+var ErrVocabularyNotFound = errors.New("vocabulary not found")
+
+// vocabFS embeds the vocabulary files committed under vocab, so that the builtin encodings work offline.
+// Maintainers refresh them with go generate, which verifies every file with its sha256.
+//
+//go:embed vocab
+var vocabFS embed.FS
+
+// specs are the pre-tokenization patterns of the builtin encodings.
+var specs = map[string]string{
+	Cl100kBase: `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+`,
+	O200kBase: `[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?|` +
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?|` +
+		`\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+(?!\S)|\s+`,
+}
+
+var (
+	mu        sync.Mutex
+	encodings = map[string]*Encoding{}
+)
+
+// RegisterEncoding makes enc available by its name in GetEncoding and LenFunc,
+// e.g. an encoding loaded by NewEncoding from a vocabulary file of another model.
+func RegisterEncoding(enc *Encoding) {
+	mu.Lock()
+	defer mu.Unlock()
+	encodings[enc.Name()] = enc
+}
+
+// GetEncoding returns the registered or builtin encoding of name, builtin encodings are loaded on first use.
+func GetEncoding(name string) (*Encoding, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if enc, ok := encodings[name]; ok {
+		return enc, nil
+	}
+
+	pattern, ok := specs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding: %s", name)
+	}
+	f, err := vocabFS.Open("vocab/" + name + ".tiktoken")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s is not embedded in this build", ErrVocabularyNotFound, name)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	enc, err := NewEncoding(name, pattern, f)
+	if err != nil {
+		return nil, fmt.Errorf("load encoding %s fail: %w", name, err)
+	}
+	encodings[name] = enc
+	return enc, nil
+}
+
+// LenFunc returns the length function of name, which is one of Cl100kBase, O200kBase, Runes, Bytes,
+// or the name of a registered encoding.
+func LenFunc(name string) (func(string) int, error) {
+	switch name {
+	case Runes:
+		return RuneCount, nil
+	case Bytes:
+		return func(s string) int { return len(s) }, nil
+	}
+	enc, err := GetEncoding(name)
+	if err != nil {
+		return nil, err
+	}
+	return enc.Count, nil
+}
+
+// RuneCount returns the number of runes in s.
+func RuneCount(s string) int {
+	return utf8.RuneCountInString(s)
+}
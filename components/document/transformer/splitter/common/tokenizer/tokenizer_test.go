@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testVocab() string {
+	var sb strings.Builder
+	for i := 0; i < 256; i++ {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), i))
+	}
+	for i, token := range []string{"ab", "bc", "abc"} {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), 256+i))
+	}
+	return sb.String()
+}
+
+func TestEncoding(t *testing.T) {
+	enc, err := NewEncoding("test", specs[Cl100kBase], strings.NewReader(testVocab()))
+	assert.NoError(t, err)
+	assert.Equal(t, "test", enc.Name())
+
+	tokens := enc.Encode("abc abd")
+	assert.Equal(t, []int{258, ' ', 256, 'd'}, tokens)
+	assert.Equal(t, 4, enc.Count("abc abd"))
+	assert.Equal(t, "abc abd", enc.Decode(tokens))
+
+	text := "你好, world!\n\n  it's 12345"
+	assert.Equal(t, text, enc.Decode(enc.Encode(text)))
+	assert.Equal(t, len(enc.Encode(text)), enc.Count(text))
+
+	_, err = NewEncoding("test", specs[Cl100kBase], strings.NewReader("YQ== x\n"))
+	assert.ErrorContains(t, err, "invalid rank at line 1")
+	_, err = NewEncoding("test", specs[Cl100kBase], strings.NewReader(""))
+	assert.ErrorContains(t, err, "empty")
+
+	RegisterEncoding(enc)
+	lenFunc, err := LenFunc("test")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lenFunc("abc"))
+}
+
+func TestLenFunc(t *testing.T) {
+	lenFunc, err := LenFunc(Runes)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, lenFunc("你好a"))
+
+	lenFunc, err = LenFunc(Bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, lenFunc("你好a"))
+
+	_, err = LenFunc("unknown")
+	assert.ErrorContains(t, err, "unknown encoding")
+
+	for _, tt := range []struct {
+		name  string
+		text  string
+		count int
+	}{
+		{name: Cl100kBase, text: "hello world", count: 2},
+		{name: Cl100kBase, text: "tiktoken is great!", count: 6},
+		{name: O200kBase, text: "hello world", count: 2},
+		{name: O200kBase, text: "tiktoken is great!", count: 6},
+	} {
+		lenFunc, err = LenFunc(tt.name)
+		if !assert.NoError(t, err, "the vocabulary files must be committed under vocab") {
+			continue
+		}
+		assert.Equal(t, tt.count, lenFunc(tt.text), "%s: %q", tt.name, tt.text)
+	}
+
+	enc, err := GetEncoding(Cl100kBase)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{83, 1609, 5963, 374, 2294, 0}, enc.Encode("tiktoken is great!"))
+	}
+}
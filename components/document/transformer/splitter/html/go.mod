@@ -2,8 +2,11 @@ module github.com/cloudwego/eino-ext/components/document/transformer/splitter/ht
 
 go 1.23.0
 
+replace github.com/cloudwego/eino-ext/components/document/transformer/splitter/common => ../common
+
 require (
 	github.com/cloudwego/eino v0.6.0
+	github.com/cloudwego/eino-ext/components/document/transformer/splitter/common v0.0.0-00010101000000-000000000000
 	golang.org/x/net v0.41.0
 )
 
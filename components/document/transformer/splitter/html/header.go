@@ -23,9 +23,12 @@ import (
 	"strings"
 
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
 )
 
 // IDGenerator generates new IDs for split chunks
@@ -45,8 +48,32 @@ type HeaderConfig struct {
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
+	// RecordProvenance specifies whether to record where each chunk comes from in its metadata, see package provenance.
+	// The offsets cover the source of the text nodes of the chunk, they are unknown if a text node contains
+	// character references and cannot be found in the source as is.
+	RecordProvenance bool
+	// KeepTables specifies whether to render <table> elements as Markdown tables instead of flattening their text,
+	// so that cells stay aligned with their columns.
+	KeepTables bool
+	// MaxTableSize is the max length of a rendered table measured by LenFunc, only valid if KeepTables is set.
+	// A longer table is split by rows into separate chunks, each of which repeats the header row.
+	// 0 means tables are never split.
+	MaxTableSize int
+	// LenFunc calculates the length of rendered tables for MaxTableSize, builtin len() by default.
+	LenFunc func(string) int
+	// KeepPre specifies whether to keep the text of <pre> elements intact as Markdown fenced code blocks,
+	// including the whitespace which is dropped elsewhere.
+	KeepPre bool
+	// SplitSections specifies whether to split the content at the boundaries of <section> and <article> elements
+	// besides headers, so that every section is emitted as its own documents.
+	// The headings of each chunk are recorded in its metadata with MetaKeyHeadingPath.
+	SplitSections bool
 }
 
+// MetaKeyHeadingPath is the metadata key of the texts of the headers above a chunk, from the outermost to the innermost,
+// in []string. It is only set if HeaderConfig.SplitSections is set.
+const MetaKeyHeadingPath = "_heading_path"
+
 // NewHeaderSplitter creates a transformer that splits HTML content based on header tags.
 // It tracks header hierarchy and attaches header text as metadata to the resulting chunks.
 //
@@ -77,15 +104,34 @@ func NewHeaderSplitter(ctx context.Context, config *HeaderConfig) (document.Tran
 	if idGenerator == nil {
 		idGenerator = defaultIDGenerator
 	}
+	if config.MaxTableSize < 0 {
+		return nil, fmt.Errorf("max table size must be non-negative: %d", config.MaxTableSize)
+	}
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
 	return &headerSplitter{
-		headers:     config.Headers,
-		idGenerator: idGenerator,
+		headers:          config.Headers,
+		idGenerator:      idGenerator,
+		recordProvenance: config.RecordProvenance,
+		keepTables:       config.KeepTables,
+		maxTableSize:     config.MaxTableSize,
+		lenFunc:          lenFunc,
+		keepPre:          config.KeepPre,
+		splitSections:    config.SplitSections,
 	}, nil
 }
 
 type headerSplitter struct {
-	headers     map[string]string
-	idGenerator IDGenerator
+	headers          map[string]string
+	idGenerator      IDGenerator
+	recordProvenance bool
+	keepTables       bool
+	maxTableSize     int
+	lenFunc          func(string) int
+	keepPre          bool
+	splitSections    bool
 }
 
 func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
@@ -95,6 +141,14 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 		if err != nil {
 			return nil, err
 		}
+		var provs []*provenance.Provenance
+		if h.recordProvenance {
+			spans := make([]provenance.Span, len(result))
+			for i := range result {
+				spans[i] = result[i].span
+			}
+			provs = provenance.Build(doc.ID, doc.Content, spans)
+		}
 		for i := range result {
 			nDoc := &schema.Document{
 				ID:       h.idGenerator(ctx, doc.ID, i),
@@ -107,6 +161,12 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 			for k, v := range result[i].meta {
 				nDoc.MetaData[k] = v
 			}
+			if h.splitSections {
+				nDoc.MetaData[MetaKeyHeadingPath] = result[i].path
+			}
+			if provs != nil {
+				provenance.Set(nDoc.MetaData, provs[i])
+			}
 			ret = append(ret, nDoc)
 		}
 	}
@@ -120,6 +180,91 @@ func (h *headerSplitter) GetType() string {
 type splitResult struct {
 	chunk string
 	meta  map[string]string
+	path  []string
+	span  provenance.Span
+}
+
+// chunkBuilder builds the content of the current chunk.
+type chunkBuilder struct {
+	strings.Builder
+	// pendingBreak is set after a block, so that the following text starts in a new line
+	pendingBreak bool
+}
+
+func (b *chunkBuilder) writeText(s string) {
+	if b.pendingBreak {
+		b.WriteString("\n")
+		b.pendingBreak = false
+	}
+	b.WriteString(s)
+}
+
+// writeBlock writes s in its own lines.
+func (b *chunkBuilder) writeBlock(s string) {
+	if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+		b.WriteString("\n")
+	}
+	b.pendingBreak = false
+	b.WriteString(s)
+	b.pendingBreak = true
+}
+
+func (b *chunkBuilder) Reset() {
+	b.Builder.Reset()
+	b.pendingBreak = false
+}
+
+// spanTracker locates the text nodes of the current chunk in the source html.
+type spanTracker struct {
+	source string
+	cursor int
+	span   provenance.Span
+	// lost is set if a text node of the current chunk is not found
+	lost bool
+}
+
+func newSpanTracker(source string) *spanTracker {
+	return &spanTracker{source: source, span: provenance.Span{Start: -1, End: -1}}
+}
+
+// locate finds text after the cursor and moves the cursor to its end.
+func (t *spanTracker) locate(text string) (int, bool) {
+	idx := strings.Index(t.source[t.cursor:], text)
+	if idx < 0 {
+		return 0, false
+	}
+	idx += t.cursor
+	t.cursor = idx + len(text)
+	return idx, true
+}
+
+// add extends the span of the current chunk with a text node.
+func (t *spanTracker) add(text string) {
+	idx, ok := t.locate(text)
+	if !ok {
+		t.lost = true
+		return
+	}
+	if t.span.Start < 0 {
+		t.span.Start = idx
+	}
+	t.span.End = idx + len(text)
+}
+
+// skip moves the cursor over a text node which is not a part of any chunk, e.g. a header.
+func (t *spanTracker) skip(text string) {
+	_, _ = t.locate(text)
+}
+
+// take returns the span of the current chunk and starts a new chunk.
+func (t *spanTracker) take() provenance.Span {
+	span := t.span
+	if t.lost {
+		span = provenance.Span{Start: -1, End: -1}
+	}
+	t.span = provenance.Span{Start: -1, End: -1}
+	t.lost = false
+	return span
 }
 
 type metaRecord struct {
@@ -131,7 +276,7 @@ type metaRecord struct {
 func (h *headerSplitter) splitText(ctx context.Context, text string) ([]splitResult, error) {
 	var recordedMetaList []metaRecord
 	recordedMetaMap := make(map[string]string)
-	currentText := &strings.Builder{}
+	currentText := &chunkBuilder{}
 	var ret []splitResult
 
 	tree, err := html.Parse(strings.NewReader(text))
@@ -139,7 +284,8 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) ([]splitRes
 		return nil, err
 	}
 
-	err = h.dfs(tree, recordedMetaList, recordedMetaMap, currentText, &ret)
+	tracker := newSpanTracker(text)
+	err = h.dfs(tree, recordedMetaList, recordedMetaMap, currentText, tracker, &ret)
 	if err != nil {
 		return nil, err
 	}
@@ -147,24 +293,37 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) ([]splitRes
 		ret = append(ret, splitResult{
 			chunk: currentText.String(),
 			meta:  map[string]string{},
+			span:  tracker.take(),
 		})
 	}
 	return ret, nil
 }
 
-func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, recordedMetaMap map[string]string, currentText *strings.Builder, ret *[]splitResult) error {
+// emit appends the current chunk to ret if it is not empty.
+func emit(recordedMetaList []metaRecord, recordedMetaMap map[string]string, currentText *chunkBuilder, tracker *spanTracker, ret *[]splitResult) {
+	if currentText.Len() == 0 {
+		return
+	}
+	path := make([]string, len(recordedMetaList))
+	for i, record := range recordedMetaList {
+		path[i] = record.data
+	}
+	*ret = append(*ret, splitResult{
+		chunk: currentText.String(),
+		meta:  deepCopyMap(recordedMetaMap),
+		path:  path,
+		span:  tracker.take(),
+	})
+	currentText.Reset()
+}
+
+func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, recordedMetaMap map[string]string, currentText *chunkBuilder, tracker *spanTracker, ret *[]splitResult) error {
 	hasHeader := false
 	for ; node != nil; node = node.NextSibling {
 		if _, ok := h.headers[node.Data]; ok && node.Type == html.ElementNode {
 			hasHeader = true
 
-			if currentText.Len() > 0 {
-				*ret = append(*ret, splitResult{
-					chunk: currentText.String(),
-					meta:  deepCopyMap(recordedMetaMap),
-				})
-				currentText.Reset()
-			}
+			emit(recordedMetaList, recordedMetaMap, currentText, tracker, ret)
 
 			newLevel, success := calHLevel(node.Data)
 			if !success {
@@ -183,6 +342,7 @@ func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, rec
 			if err != nil {
 				return err
 			}
+			tracker.skip(data)
 			record := metaRecord{
 				name:  h.headers[node.Data],
 				level: newLevel,
@@ -195,21 +355,36 @@ func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, rec
 			recordedMetaMap[record.name] = record.data
 			continue
 		}
+		if node.Type == html.ElementNode {
+			switch {
+			case h.keepTables && node.DataAtom == atom.Table:
+				h.writeTable(node, recordedMetaList, recordedMetaMap, currentText, tracker, ret)
+				continue
+			case h.keepPre && node.DataAtom == atom.Pre:
+				writePre(node, currentText, tracker)
+				continue
+			case h.splitSections && (node.DataAtom == atom.Section || node.DataAtom == atom.Article):
+				emit(recordedMetaList, recordedMetaMap, currentText, tracker, ret)
+				err := h.dfs(node.FirstChild, deepCopySlice(recordedMetaList), deepCopyMap(recordedMetaMap), currentText, tracker, ret)
+				if err != nil {
+					return err
+				}
+				emit(recordedMetaList, recordedMetaMap, currentText, tracker, ret)
+				continue
+			}
+		}
 		if node.Type == html.TextNode && len(strings.TrimSpace(node.Data)) != 0 {
-			currentText.WriteString(node.Data)
+			currentText.writeText(node.Data)
+			tracker.add(node.Data)
 		}
 
-		err := h.dfs(node.FirstChild, deepCopySlice(recordedMetaList), deepCopyMap(recordedMetaMap), currentText, ret)
+		err := h.dfs(node.FirstChild, deepCopySlice(recordedMetaList), deepCopyMap(recordedMetaMap), currentText, tracker, ret)
 		if err != nil {
 			return err
 		}
 	}
-	if hasHeader && currentText.Len() > 0 {
-		*ret = append(*ret, splitResult{
-			chunk: currentText.String(),
-			meta:  deepCopyMap(recordedMetaMap),
-		})
-		currentText.Reset()
+	if hasHeader {
+		emit(recordedMetaList, recordedMetaMap, currentText, tracker, ret)
 	}
 	return nil
 }
@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
 )
 
 var commonSuccessHTML = `<!DOCTYPE html>
@@ -165,3 +167,148 @@ func TestHTMLHeaderSplitter(t *testing.T) {
 		})
 	}
 }
+
+func TestHTMLHeaderSplitterWithProvenance(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:          map[string]string{"h1": "h1"},
+		RecordProvenance: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "<html><body><p>intro</p><h1>A</h1><p>first</p><p>second</p><h1>B</h1><p>x &amp; y</p></body></html>"
+	docs, err := splitter.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []provenance.Provenance
+	for _, doc := range docs {
+		p, ok := provenance.Get(doc.MetaData)
+		if !ok {
+			t.Fatal("provenance not found")
+		}
+		got = append(got, *p)
+	}
+	want := []provenance.Provenance{
+		{ParentID: "doc", Index: 0, Total: 3, Start: 15, End: 20},
+		{ParentID: "doc", Index: 1, Total: 3, Start: 37, End: 55},
+		// character references cannot be located
+		{ParentID: "doc", Index: 2, Total: 3, Start: -1, End: -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestHTMLHeaderSplitterWithTablesAndPre(t *testing.T) {
+	ctx := context.Background()
+	content := `<html><body>
+<h1>Prices</h1>
+<p>Plans:</p>
+<table>
+  <thead><tr><th>Plan</th><th>Price</th></tr></thead>
+  <tbody>
+    <tr><td>Free</td><td>0</td></tr>
+    <tr><td>Pro | Team</td><td>10</td></tr>
+    <tr><td>Enterprise</td></tr>
+  </tbody>
+</table>
+<p>Install:</p>
+<pre><code class="language-sh">go get example.com/x
+
+go run .</code></pre>
+<p>Done.</p>
+</body></html>`
+
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:    map[string]string{"h1": "h1"},
+		KeepTables: true,
+		KeepPre:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Plans:\n" +
+		"| Plan | Price |\n| --- | --- |\n| Free | 0 |\n| Pro \\| Team | 10 |\n| Enterprise |  |\n" +
+		"Install:\n" +
+		"```sh\ngo get example.com/x\n\ngo run .\n```\n" +
+		"Done."
+	if len(docs) != 1 || docs[0].Content != want {
+		t.Fatalf("got %v, want %q", docs, want)
+	}
+
+	splitter, err = NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:          map[string]string{"h1": "h1"},
+		KeepTables:       true,
+		MaxTableSize:     70,
+		RecordProvenance: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err = splitter.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, doc := range docs {
+		got = append(got, doc.Content)
+		if doc.MetaData["h1"] != "Prices" {
+			t.Errorf("unexpected meta %v", doc.MetaData)
+		}
+	}
+	wantChunks := []string{
+		"Plans:",
+		"| Plan | Price |\n| --- | --- |\n| Free | 0 |\n| Pro \\| Team | 10 |",
+		"| Plan | Price |\n| --- | --- |\n| Enterprise |  |",
+		"Install:go get example.com/x\n\ngo run .Done.",
+	}
+	if !reflect.DeepEqual(got, wantChunks) {
+		t.Fatalf("got %q, want %q", got, wantChunks)
+	}
+	p, _ := provenance.Get(docs[2].MetaData)
+	if content[p.Start:p.End] != "Enterprise" {
+		t.Errorf("offsets point to %q", content[p.Start:p.End])
+	}
+}
+
+func TestHTMLHeaderSplitterWithSections(t *testing.T) {
+	ctx := context.Background()
+	content := `<html><body>
+<h1>Guide</h1>
+<p>Intro</p>
+<article>
+  <section><h2>Install</h2><p>Install text</p></section>
+  <section><p>Untitled text</p></section>
+</article>
+<p>Outro</p>
+</body></html>`
+
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:       map[string]string{"h1": "h1", "h2": "h2"},
+		SplitSections: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*schema.Document{
+		{Content: "Intro", MetaData: map[string]any{"h1": "Guide", MetaKeyHeadingPath: []string{"Guide"}}},
+		{Content: "Install text", MetaData: map[string]any{"h1": "Guide", "h2": "Install", MetaKeyHeadingPath: []string{"Guide", "Install"}}},
+		{Content: "Untitled text", MetaData: map[string]any{"h1": "Guide", MetaKeyHeadingPath: []string{"Guide"}}},
+		{Content: "Outro", MetaData: map[string]any{"h1": "Guide", MetaKeyHeadingPath: []string{"Guide"}}},
+	}
+	if !reflect.DeepEqual(docs, want) {
+		t.Errorf("got %v, want %v", docs, want)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// tableRow is a row of a table, texts are the raw text nodes of the row for the span tracker.
+type tableRow struct {
+	cells []string
+	texts []string
+}
+
+// writeTable renders a table as Markdown. A table longer than maxTableSize is split by rows into separate chunks,
+// the caption and the header row are repeated in each of them.
+func (h *headerSplitter) writeTable(node *html.Node, recordedMetaList []metaRecord, recordedMetaMap map[string]string, currentText *chunkBuilder, tracker *spanTracker, ret *[]splitResult) {
+	var caption tableRow
+	var rows []tableRow
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.DataAtom {
+			case atom.Caption:
+				text, texts := cellText(c)
+				caption = tableRow{cells: []string{text}, texts: texts}
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(c)
+			case atom.Tr:
+				if row := readRow(c); len(row.cells) > 0 {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	walk(node)
+	if len(rows) == 0 {
+		return
+	}
+
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row.cells))
+	}
+	// the first row is used as the header row, since a Markdown table always has one
+	head := renderRow(rows[0].cells, cols) + "\n" + renderSeparator(cols)
+	if len(caption.cells) > 0 && caption.cells[0] != "" {
+		head = caption.cells[0] + "\n" + head
+	}
+	lines := make([]string, len(rows)-1)
+	for i, row := range rows[1:] {
+		lines[i] = renderRow(row.cells, cols)
+	}
+
+	table := strings.Join(append([]string{head}, lines...), "\n")
+	if h.maxTableSize == 0 || len(lines) <= 1 || h.lenFunc(table) <= h.maxTableSize {
+		addTexts(tracker, caption.texts)
+		for _, row := range rows {
+			addTexts(tracker, row.texts)
+		}
+		currentText.writeBlock(table)
+		return
+	}
+
+	// a split table starts its own chunks
+	emit(recordedMetaList, recordedMetaMap, currentText, tracker, ret)
+	addTexts(tracker, caption.texts)
+	addTexts(tracker, rows[0].texts)
+	group := head
+	for i, l := range lines {
+		if group != head && h.lenFunc(group+"\n"+l) > h.maxTableSize {
+			currentText.writeBlock(group)
+			emit(recordedMetaList, recordedMetaMap, currentText, tracker, ret)
+			group = head
+		}
+		addTexts(tracker, rows[i+1].texts)
+		group += "\n" + l
+	}
+	currentText.writeBlock(group)
+	emit(recordedMetaList, recordedMetaMap, currentText, tracker, ret)
+}
+
+func readRow(tr *html.Node) tableRow {
+	var row tableRow
+	for c := tr.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || (c.DataAtom != atom.Td && c.DataAtom != atom.Th) {
+			continue
+		}
+		text, texts := cellText(c)
+		row.cells = append(row.cells, strings.ReplaceAll(text, "|", "\\|"))
+		row.texts = append(row.texts, texts...)
+	}
+	return row
+}
+
+// cellText returns the text of node with whitespace collapsed, and the raw text nodes of it.
+func cellText(node *html.Node) (string, []string) {
+	var parts, texts []string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode {
+				parts = append(parts, c.Data)
+				if len(strings.TrimSpace(c.Data)) != 0 {
+					texts = append(texts, c.Data)
+				}
+			}
+			walk(c)
+		}
+	}
+	walk(node)
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " "), texts
+}
+
+func renderRow(cells []string, cols int) string {
+	sb := strings.Builder{}
+	sb.WriteString("|")
+	for i := 0; i < cols; i++ {
+		sb.WriteString(" ")
+		if i < len(cells) {
+			sb.WriteString(cells[i])
+		}
+		sb.WriteString(" |")
+	}
+	return sb.String()
+}
+
+func renderSeparator(cols int) string {
+	return "|" + strings.Repeat(" --- |", cols)
+}
+
+func addTexts(tracker *spanTracker, texts []string) {
+	for _, text := range texts {
+		tracker.add(text)
+	}
+}
+
+// writePre writes the text of a <pre> element as a fenced code block, with the language from its class if any.
+func writePre(node *html.Node, currentText *chunkBuilder, tracker *spanTracker) {
+	sb := strings.Builder{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case c.Type == html.TextNode:
+				sb.WriteString(c.Data)
+				if len(strings.TrimSpace(c.Data)) != 0 {
+					tracker.add(c.Data)
+				}
+			case c.Type == html.ElementNode && c.DataAtom == atom.Br:
+				sb.WriteString("\n")
+			}
+			walk(c)
+		}
+	}
+	walk(node)
+
+	code := strings.TrimSuffix(strings.TrimPrefix(sb.String(), "\n"), "\n")
+	if len(strings.TrimSpace(code)) == 0 {
+		return
+	}
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	currentText.writeBlock(fence + codeLanguage(node) + "\n" + code + "\n" + fence)
+}
+
+// codeLanguage returns the language in the class of a <pre> element or its <code> child,
+// e.g. "go" for class "language-go" or "lang-go".
+func codeLanguage(pre *html.Node) string {
+	nodes := []*html.Node{pre}
+	for c := pre.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == atom.Code {
+			nodes = append(nodes, c)
+		}
+	}
+	for _, n := range nodes {
+		for _, attr := range n.Attr {
+			if attr.Key != "class" {
+				continue
+			}
+			for _, class := range strings.Fields(attr.Val) {
+				if lang, ok := strings.CutPrefix(class, "language-"); ok {
+					return lang
+				}
+				if lang, ok := strings.CutPrefix(class, "lang-"); ok {
+					return lang
+				}
+			}
+		}
+	}
+	return ""
+}
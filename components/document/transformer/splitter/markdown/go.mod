@@ -2,7 +2,12 @@ module github.com/cloudwego/eino-ext/components/document/transformer/splitter/ma
 
 go 1.23.0
 
-require github.com/cloudwego/eino v0.6.0
+replace github.com/cloudwego/eino-ext/components/document/transformer/splitter/common => ../common
+
+require (
+	github.com/cloudwego/eino v0.6.0
+	github.com/cloudwego/eino-ext/components/document/transformer/splitter/common v0.0.0-00010101000000-000000000000
+)
 
 require (
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
@@ -20,9 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
 )
 
 // IDGenerator generates new IDs for split chunks
@@ -62,6 +65,25 @@ type HeaderConfig struct {
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
+	// RecordProvenance specifies whether to record where each chunk comes from in its metadata, see package provenance.
+	// The offsets cover the lines of the chunk in the parent content, including the blank lines and indents removed
+	// from the chunk.
+	RecordProvenance bool
+	// SetextHeadings specifies whether to recognize setext headings, i.e. text lines underlined by '=' or '-',
+	// which are level 1 and level 2 headings, named by the "#" and "##" entries of Headers respectively.
+	SetextHeadings bool
+	// MaxChunkSize is the max length of chunks measured by LenFunc. Sections longer than it are split further
+	// on paragraphs, list items, sentences and at last words. Fenced code blocks and tables are never split,
+	// so a chunk containing a long one can still exceed MaxChunkSize.
+	// 0 means sections are not split further.
+	MaxChunkSize int
+	// LenFunc calculates the length of chunks for MaxChunkSize, builtin len() by default.
+	LenFunc func(string) int
+	// Breadcrumb specifies whether to prefix the content of each chunk with the path of its headings,
+	// e.g. "Guide > Install > Linux" followed by a blank line.
+	Breadcrumb bool
+	// BreadcrumbSeparator joins the headings of the breadcrumb, " > " by default.
+	BreadcrumbSeparator string
 }
 
 func NewHeaderSplitter(ctx context.Context, config *HeaderConfig) (document.Transformer, error) {
@@ -75,32 +97,66 @@ func NewHeaderSplitter(ctx context.Context, config *HeaderConfig) (document.Tran
 			}
 		}
 	}
+	if config.MaxChunkSize < 0 {
+		return nil, fmt.Errorf("max chunk size must be non-negative: %d", config.MaxChunkSize)
+	}
 	idGenerator := config.IDGenerator
 	if idGenerator == nil {
 		idGenerator = defaultIDGenerator
 	}
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
+	breadcrumbSep := config.BreadcrumbSeparator
+	if breadcrumbSep == "" {
+		breadcrumbSep = defaultBreadcrumbSeparator
+	}
 	return &headerSplitter{
-		headers:     config.Headers,
-		trimHeaders: config.TrimHeaders,
-		idGenerator: idGenerator,
+		headers:          config.Headers,
+		trimHeaders:      config.TrimHeaders,
+		idGenerator:      idGenerator,
+		recordProvenance: config.RecordProvenance,
+		setextHeadings:   config.SetextHeadings,
+		maxChunkSize:     config.MaxChunkSize,
+		lenFunc:          lenFunc,
+		breadcrumb:       config.Breadcrumb,
+		breadcrumbSep:    breadcrumbSep,
 	}, nil
 }
 
+const defaultBreadcrumbSeparator = " > "
+
 type headerSplitter struct {
-	headers     map[string]string
-	trimHeaders bool
-	idGenerator IDGenerator
+	headers          map[string]string
+	trimHeaders      bool
+	idGenerator      IDGenerator
+	recordProvenance bool
+	setextHeadings   bool
+	maxChunkSize     int
+	lenFunc          func(string) int
+	breadcrumb       bool
+	breadcrumbSep    string
 }
 
 type splitResult struct {
 	chunk string
 	meta  map[string]string
+	span  provenance.Span
 }
 
 func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
 	var ret []*schema.Document
 	for _, doc := range docs {
 		result := h.splitText(ctx, doc.Content)
+		var provs []*provenance.Provenance
+		if h.recordProvenance {
+			spans := make([]provenance.Span, len(result))
+			for i := range result {
+				spans[i] = result[i].span
+			}
+			provs = provenance.Build(doc.ID, doc.Content, spans)
+		}
 		for i := range result {
 			nDoc := &schema.Document{
 				ID:       h.idGenerator(ctx, doc.ID, i),
@@ -113,6 +169,9 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 			for k, v := range result[i].meta {
 				nDoc.MetaData[k] = v
 			}
+			if provs != nil {
+				provenance.Set(nDoc.MetaData, provs[i])
+			}
 			ret = append(ret, nDoc)
 		}
 	}
@@ -137,83 +196,136 @@ type metaRecord struct {
 func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResult {
 	var recordedMetaList []metaRecord
 	recordedMetaMap := make(map[string]string)
-	var currentLines []string
+	var currentLines []line
 	var bInCodeBlock bool
 	var openingFence string
 	var ret []splitResult
-	lines := strings.Split(text, "\n")
-	for _, line := range lines {
-		if len(line) == 0 {
+	flush := func() {
+		ret = append(ret, h.splitSection(currentLines, recordedMetaMap, recordedMetaList)...)
+		currentLines = nil
+	}
+	startHeader := func(level int, name, data string, headerLines ...line) {
+		if len(currentLines) > 0 {
+			flush()
+		}
+
+		if !h.trimHeaders {
+			currentLines = append(currentLines, headerLines...)
+		}
+
+		for i := len(recordedMetaList) - 1; i >= 0; i-- {
+			if recordedMetaList[i].level >= level {
+				delete(recordedMetaMap, recordedMetaList[i].name)
+				recordedMetaList = recordedMetaList[:i]
+			} else {
+				break
+			}
+		}
+
+		recordedMetaList = append(recordedMetaList, metaRecord{
+			name:  name,
+			level: level,
+			data:  data,
+		})
+		recordedMetaMap[name] = data
+	}
+	// paragraphLines is the number of paragraph lines at the end of currentLines, which can be the text of a setext heading
+	var paragraphLines int
+	var blank bool
+	offset := 0
+	for _, raw := range strings.Split(text, "\n") {
+		lineStart := offset
+		offset += len(raw) + 1
+		if len(raw) == 0 {
+			blank = true
+			paragraphLines = 0
 			continue
 		}
-		line = strings.TrimSpace(line)
+		l := line{
+			text:        strings.TrimSpace(raw),
+			start:       lineStart + len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace)),
+			blankBefore: blank,
+		}
+		blank = len(l.text) == 0
 		if !bInCodeBlock {
-			if strings.HasPrefix(line, codeSep1) && strings.Count(line, codeSep1) == 1 {
+			if strings.HasPrefix(l.text, codeSep1) && strings.Count(l.text, codeSep1) == 1 {
 				bInCodeBlock = true
 				openingFence = codeSep1
-			} else if strings.HasPrefix(line, codeSep2) {
+			} else if strings.HasPrefix(l.text, codeSep2) {
 				bInCodeBlock = true
 				openingFence = codeSep2
 			}
 		} else {
-			if strings.HasPrefix(line, openingFence) {
+			if strings.HasPrefix(l.text, openingFence) {
 				bInCodeBlock = false
 				openingFence = ""
+				l.code = true
 			}
 		}
-		if bInCodeBlock {
-			currentLines = append(currentLines, line)
+		if bInCodeBlock || l.code {
+			l.code = true
+			currentLines = append(currentLines, l)
+			paragraphLines = 0
 			continue
 		}
+		if h.setextHeadings && paragraphLines > 0 {
+			if level := setextLevel(l.text); level > 0 {
+				if name, ok := h.headers[strings.Repeat("#", level)]; ok {
+					headerLines := append(currentLines[len(currentLines)-paragraphLines:len(currentLines):len(currentLines)], l)
+					currentLines = currentLines[:len(currentLines)-paragraphLines]
+					texts := make([]string, 0, paragraphLines)
+					for _, hl := range headerLines[:paragraphLines] {
+						texts = append(texts, hl.text)
+					}
+					for i := range headerLines {
+						headerLines[i].heading = true
+					}
+					startHeader(level, name, strings.Join(texts, " "), headerLines...)
+					paragraphLines = 0
+					continue
+				}
+			}
+		}
 		// check if the line starts with headers
 		bNewHeader := false
 		for header, name := range h.headers {
-			if strings.HasPrefix(line, header) && (len(line) == len(header) || line[len(header)] == ' ') {
-				if len(currentLines) > 0 {
-					ret = append(ret, splitResult{
-						chunk: strings.Join(currentLines, "\n"),
-						meta:  deepCopyMap(recordedMetaMap),
-					})
-					currentLines = currentLines[:0]
-				}
-
-				if !h.trimHeaders {
-					currentLines = append(currentLines, line)
-				}
-
-				newLevel := len(header)
-				for i := len(recordedMetaList) - 1; i >= 0; i-- {
-					if recordedMetaList[i].level >= newLevel {
-						delete(recordedMetaMap, recordedMetaList[i].name)
-						recordedMetaList = recordedMetaList[:i]
-					} else {
-						break
-					}
-				}
-
-				data := strings.TrimSpace(line[len(header):])
-				recordedMetaList = append(recordedMetaList, metaRecord{
-					name:  name,
-					level: newLevel,
-					data:  data,
-				})
-				recordedMetaMap[name] = data
-
+			if strings.HasPrefix(l.text, header) && (len(l.text) == len(header) || l.text[len(header)] == ' ') {
+				l.heading = true
+				startHeader(len(header), name, strings.TrimSpace(l.text[len(header):]), l)
 				bNewHeader = true
 				break
 			}
 		}
-		if !bNewHeader {
-			currentLines = append(currentLines, line)
+		if bNewHeader {
+			paragraphLines = 0
+			continue
+		}
+		currentLines = append(currentLines, l)
+		if len(l.text) > 0 && !isTableRow(l.text) && !isListItem(l.text) && !strings.HasPrefix(l.text, ">") {
+			paragraphLines++
+		} else {
+			paragraphLines = 0
 		}
 	}
-	ret = append(ret, splitResult{
-		chunk: strings.Join(currentLines, "\n"),
-		meta:  deepCopyMap(recordedMetaMap),
-	})
+	flush()
 	return ret
 }
 
+// setextLevel returns the level of the setext heading underlined by s, or 0 if s is not a setext underline.
+func setextLevel(s string) int {
+	if len(s) == 0 {
+		return 0
+	}
+	c := s[0]
+	if (c != '=' && c != '-') || strings.Trim(s, string(c)) != "" {
+		return 0
+	}
+	if c == '=' {
+		return 1
+	}
+	return 2
+}
+
 func deepCopyMap(m map[string]string) map[string]string {
 	if m == nil {
 		return nil
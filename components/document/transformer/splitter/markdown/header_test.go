@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
 )
 
 func TestMarkdownHeaderSplitter(t *testing.T) {
@@ -98,3 +100,157 @@ func TestMarkdownHeaderSplitter(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkdownHeaderSplitterWithProvenance(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:          map[string]string{"#": "h1"},
+		TrimHeaders:      true,
+		RecordProvenance: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "intro\n\n# A\n  content a\n\n# B\ncontent b\n"
+	docs, err := splitter.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []provenance.Provenance
+	for _, doc := range docs {
+		p, ok := provenance.Get(doc.MetaData)
+		if !ok {
+			t.Fatal("provenance not found")
+		}
+		got = append(got, *p)
+	}
+	want := []provenance.Provenance{
+		{ParentID: "doc", Index: 0, Total: 3, Start: 0, End: 5},
+		{ParentID: "doc", Index: 1, Total: 3, Start: 13, End: 22},
+		{ParentID: "doc", Index: 2, Total: 3, Start: 28, End: 37},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if content[13:22] != docs[1].Content {
+		t.Errorf("offsets point to %q", content[13:22])
+	}
+}
+
+func TestMarkdownHeaderSplitterWithSetextHeadings(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:        map[string]string{"#": "h1", "##": "h2"},
+		SetextHeadings: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "Title\n=====\nintro\n\nSub title\n---\ncontent\n\n- item\n---\n| a |\n---\n```\nx\n---\n```"
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*schema.Document{
+		{Content: "Title\n=====\nintro", MetaData: map[string]any{"h1": "Title"}},
+		{Content: "Sub title\n---\ncontent\n- item\n---\n| a |\n---\n```\nx\n---\n```", MetaData: map[string]any{"h1": "Title", "h2": "Sub title"}},
+	}
+	if !reflect.DeepEqual(docs, want) {
+		t.Errorf("got %v, want %v", docs, want)
+	}
+}
+
+func TestMarkdownHeaderSplitterWithMaxChunkSize(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:          map[string]string{"#": "h1"},
+		TrimHeaders:      true,
+		MaxChunkSize:     40,
+		RecordProvenance: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "# Guide\n" +
+		"First sentence here. Second sentence here.\n" +
+		"\n" +
+		"- item one\n" +
+		"- item two\n" +
+		"\n" +
+		"```go\n" +
+		"func main() {\n" +
+		"\n" +
+		"\tprintln(\"a long line of code\")\n" +
+		"}\n" +
+		"```\n" +
+		"| a | b |\n" +
+		"| - | - |\n" +
+		"short tail"
+	docs, err := splitter.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"First sentence here.",
+		"Second sentence here.\n- item one",
+		"- item two",
+		"```go\nfunc main() {\nprintln(\"a long line of code\")\n}\n```",
+		"| a | b |\n| - | - |\nshort tail",
+	}
+	var got []string
+	for _, doc := range docs {
+		got = append(got, doc.Content)
+		if doc.MetaData["h1"] != "Guide" {
+			t.Errorf("unexpected meta %v", doc.MetaData)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	p, _ := provenance.Get(docs[0].MetaData)
+	if content[p.Start:p.End] != "First sentence here." {
+		t.Errorf("offsets point to %q", content[p.Start:p.End])
+	}
+	p, _ = provenance.Get(docs[1].MetaData)
+	if content[p.Start:p.End] != "Second sentence here.\n\n- item one" {
+		t.Errorf("offsets point to %q", content[p.Start:p.End])
+	}
+}
+
+func TestMarkdownHeaderSplitterWithBreadcrumb(t *testing.T) {
+	ctx := context.Background()
+	splitter, err := NewHeaderSplitter(ctx, &HeaderConfig{
+		Headers:      map[string]string{"#": "h1", "##": "h2"},
+		TrimHeaders:  true,
+		Breadcrumb:   true,
+		MaxChunkSize: 40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "preface\n# Guide\n## Install\nRun the installer. Then restart."
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"preface",
+		"Guide > Install\n\nRun the installer.",
+		"Guide > Install\n\nThen restart.",
+	}
+	var got []string
+	for _, doc := range docs {
+		got = append(got, doc.Content)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
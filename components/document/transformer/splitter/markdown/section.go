@@ -0,0 +1,302 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
+)
+
+// line is a line of the content with surrounding spaces trimmed.
+type line struct {
+	text string
+	// start is the byte offset of text in the content
+	start int
+	// blankBefore is set if the line follows an empty line
+	blankBefore bool
+	// code is set for the lines of fenced code blocks, fences included
+	code    bool
+	heading bool
+}
+
+func (l line) end() int {
+	return l.start + len(l.text)
+}
+
+type blockKind uint8
+
+const (
+	blockParagraph blockKind = iota
+	blockListItem
+	blockCode
+	blockTable
+	blockHeading
+)
+
+// block is a unit of a section when it is split further.
+type block struct {
+	kind  blockKind
+	lines []line
+	// fence is the opening fence of a code block, the block is closed when the fence appears again
+	fence  string
+	closed bool
+}
+
+// piece is a part of a section, adjacent pieces are packed into chunks.
+type piece struct {
+	text string
+	// sep joins the piece to the previous one in the same chunk
+	sep  string
+	span provenance.Span
+}
+
+// splitSection turns the lines under the same headings into one or more results.
+func (h *headerSplitter) splitSection(lines []line, meta map[string]string, records []metaRecord) []splitResult {
+	var prefix string
+	if h.breadcrumb && len(records) > 0 {
+		headings := make([]string, len(records))
+		for i, r := range records {
+			headings[i] = r.data
+		}
+		prefix = strings.Join(headings, h.breadcrumbSep) + "\n\n"
+	}
+	if len(lines) == 0 {
+		return []splitResult{{meta: deepCopyMap(meta), span: provenance.Span{Start: -1, End: -1}}}
+	}
+
+	pieces := []piece{joinLines(lines)}
+	if h.maxChunkSize > 0 && h.lenFunc(prefix+pieces[0].text) > h.maxChunkSize {
+		// the breadcrumb is counted in the chunk size unless it takes all the room
+		budget := h.maxChunkSize - h.lenFunc(prefix)
+		if budget <= 0 {
+			budget = h.maxChunkSize
+		}
+		pieces = h.pack(h.blockPieces(buildBlocks(lines), budget), budget)
+	}
+
+	ret := make([]splitResult, 0, len(pieces))
+	for _, p := range pieces {
+		ret = append(ret, splitResult{
+			chunk: prefix + p.text,
+			meta:  deepCopyMap(meta),
+			span:  p.span,
+		})
+	}
+	return ret
+}
+
+func joinLines(lines []line) piece {
+	texts := make([]string, len(lines))
+	for i, l := range lines {
+		texts[i] = l.text
+	}
+	return piece{
+		text: strings.Join(texts, "\n"),
+		sep:  "\n",
+		span: provenance.Span{Start: lines[0].start, End: lines[len(lines)-1].end()},
+	}
+}
+
+// buildBlocks groups lines into paragraphs, list items, fenced code blocks, tables and headings.
+func buildBlocks(lines []line) []*block {
+	var blocks []*block
+	var cur *block
+	for _, l := range lines {
+		switch {
+		case l.code:
+			if cur != nil && cur.kind == blockCode && !cur.closed {
+				cur.lines = append(cur.lines, l)
+				cur.closed = strings.HasPrefix(l.text, cur.fence)
+				continue
+			}
+			cur = &block{kind: blockCode, fence: codeSep1}
+			if strings.HasPrefix(l.text, codeSep2) {
+				cur.fence = codeSep2
+			}
+		case l.heading:
+			if cur != nil && cur.kind == blockHeading {
+				cur.lines = append(cur.lines, l)
+				continue
+			}
+			cur = &block{kind: blockHeading}
+		case len(l.text) == 0:
+			cur = nil
+			continue
+		case isTableRow(l.text):
+			if cur != nil && cur.kind == blockTable && !l.blankBefore {
+				cur.lines = append(cur.lines, l)
+				continue
+			}
+			cur = &block{kind: blockTable}
+		case isListItem(l.text):
+			cur = &block{kind: blockListItem}
+		default:
+			if cur != nil && (cur.kind == blockParagraph || cur.kind == blockListItem) && !l.blankBefore {
+				cur.lines = append(cur.lines, l)
+				continue
+			}
+			cur = &block{kind: blockParagraph}
+		}
+		cur.lines = append(cur.lines, l)
+		blocks = append(blocks, cur)
+	}
+	return blocks
+}
+
+// blockPieces splits the blocks longer than budget into sentences, and sentences still longer than budget into words.
+// Code blocks, tables and headings are kept intact.
+func (h *headerSplitter) blockPieces(blocks []*block, budget int) []piece {
+	var ret []piece
+	for _, b := range blocks {
+		p := joinLines(b.lines)
+		if b.kind == blockCode || b.kind == blockTable || b.kind == blockHeading || h.lenFunc(p.text) <= budget {
+			ret = append(ret, p)
+			continue
+		}
+		ret = append(ret, h.splitBlock(b, p.text, budget)...)
+	}
+	return ret
+}
+
+func (h *headerSplitter) splitBlock(b *block, text string, budget int) []piece {
+	// lineOffsets are the offsets of the lines in text
+	lineOffsets := make([]int, len(b.lines))
+	offset := 0
+	for i, l := range b.lines {
+		lineOffsets[i] = offset
+		offset += len(l.text) + 1
+	}
+	toContentOffset := func(i int) int {
+		k := sort.Search(len(lineOffsets), func(j int) bool { return lineOffsets[j] > i }) - 1
+		return b.lines[k].start + i - lineOffsets[k]
+	}
+
+	var ret []piece
+	prevEnd := -1
+	emit := func(from, to int) {
+		for from < to && isSpace(text[from]) {
+			from++
+		}
+		for to > from && isSpace(text[to-1]) {
+			to--
+		}
+		if from == to {
+			return
+		}
+		sep := "\n"
+		if prevEnd >= 0 {
+			sep = text[prevEnd:from]
+		}
+		ret = append(ret, piece{
+			text: text[from:to],
+			sep:  sep,
+			span: provenance.Span{Start: toContentOffset(from), End: toContentOffset(to-1) + 1},
+		})
+		prevEnd = to
+	}
+	for _, s := range sentenceBounds(text) {
+		if h.lenFunc(strings.TrimSpace(text[s[0]:s[1]])) <= budget {
+			emit(s[0], s[1])
+			continue
+		}
+		for _, w := range wordBounds(text, s[0], s[1]) {
+			emit(w[0], w[1])
+		}
+	}
+	return ret
+}
+
+// pack merges adjacent pieces as long as the merged length does not exceed budget.
+func (h *headerSplitter) pack(pieces []piece, budget int) []piece {
+	var ret []piece
+	for _, p := range pieces {
+		if n := len(ret); n > 0 && h.lenFunc(ret[n-1].text+p.sep+p.text) <= budget {
+			ret[n-1].text += p.sep + p.text
+			ret[n-1].span.End = p.span.End
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+// sentenceBounds splits text after sentence terminators, the spaces following a terminator belong to its sentence.
+func sentenceBounds(text string) [][2]int {
+	var ret [][2]int
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		switch r {
+		case '。', '！', '？', '；':
+		case '.', '!', '?':
+			if i < len(text) && !isSpace(text[i]) {
+				continue
+			}
+		default:
+			continue
+		}
+		for i < len(text) && isSpace(text[i]) {
+			i++
+		}
+		ret = append(ret, [2]int{start, i})
+		start = i
+	}
+	if start < len(text) {
+		ret = append(ret, [2]int{start, len(text)})
+	}
+	return ret
+}
+
+// wordBounds splits text[from:to] after spaces.
+func wordBounds(text string, from, to int) [][2]int {
+	var ret [][2]int
+	start := from
+	for i := from; i < to; i++ {
+		if isSpace(text[i]) && (i+1 == to || !isSpace(text[i+1])) {
+			ret = append(ret, [2]int{start, i + 1})
+			start = i + 1
+		}
+	}
+	if start < to {
+		ret = append(ret, [2]int{start, to})
+	}
+	return ret
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isTableRow(s string) bool {
+	return strings.HasPrefix(s, "|")
+}
+
+// isListItem reports whether s starts with a bullet or ordered list marker.
+func isListItem(s string) bool {
+	if len(s) >= 2 && (s[0] == '-' || s[0] == '*' || s[0] == '+') && (s[1] == ' ' || s[1] == '\t') {
+		return true
+	}
+	i := 0
+	for i < len(s) && i < 9 && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i > 0 && i+1 < len(s) && (s[i] == '.' || s[i] == ')') && (s[i+1] == ' ' || s[i+1] == '\t')
+}
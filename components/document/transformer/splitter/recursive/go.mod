@@ -2,7 +2,12 @@ module github.com/cloudwego/eino-ext/components/document/transformer/splitter/re
 
 go 1.23.0
 
-require github.com/cloudwego/eino v0.6.0
+replace github.com/cloudwego/eino-ext/components/document/transformer/splitter/common => ../common
+
+require (
+	github.com/cloudwego/eino v0.6.0
+	github.com/cloudwego/eino-ext/components/document/transformer/splitter/common v0.0.0-00010101000000-000000000000
+)
 
 require (
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
@@ -11,6 +16,7 @@ require (
 	github.com/bytedance/sonic v1.14.1 // indirect
 	github.com/bytedance/sonic/loader v0.3.0 // indirect
 	github.com/cloudwego/base64x v0.1.6 // indirect
+	github.com/dlclark/regexp2 v1.11.4 // indirect
 	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/eino-contrib/jsonschema v1.0.2 // indirect
 	github.com/goph/emperror v0.17.2 // indirect
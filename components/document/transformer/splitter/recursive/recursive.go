@@ -23,6 +23,10 @@ import (
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/separator"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/tokenizer"
 )
 
 type KeepType uint8
@@ -52,13 +56,21 @@ type Config struct {
 	// When the current separator cannot split the text into a size smaller than ChunkSize, the next separator will be used to attempt to split until the chunk size is smaller than ChunkSize or there are no separator available.
 	// ["\n", ".", "?", "!"] by default.
 	Separators []string
+	// SeparatorPreset selects the default Separators by language when Separators is empty,
+	// e.g. separator.Chinese or separator.Go, see package separator for details.
+	SeparatorPreset separator.Preset
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
 	LenFunc func(string) int
+	// Tokenizer selects a builtin LenFunc by name when LenFunc is nil, e.g. tokenizer.Cl100kBase and tokenizer.O200kBase
+	// count tokens of OpenAI models, tokenizer.Runes counts runes. See package tokenizer for details.
+	Tokenizer string
 	// KeepType specifies if separator will be kept in split chunks. Discard separator by default.
 	KeepType KeepType
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
+	// RecordProvenance specifies whether to record where each chunk comes from in its metadata, see package provenance.
+	RecordProvenance bool
 }
 
 // NewSplitter create a recursive splitter.
@@ -71,10 +83,22 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	}
 
 	lenFunc := config.LenFunc
+	if lenFunc == nil && config.Tokenizer != "" {
+		var err error
+		if lenFunc, err = tokenizer.LenFunc(config.Tokenizer); err != nil {
+			return nil, fmt.Errorf("get len func of tokenizer [%s] fail: %w", config.Tokenizer, err)
+		}
+	}
 	if lenFunc == nil {
 		lenFunc = func(s string) int { return len(s) }
 	}
 	seps := config.Separators
+	if len(seps) == 0 && config.SeparatorPreset != "" {
+		var err error
+		if seps, err = separator.Separators(config.SeparatorPreset); err != nil {
+			return nil, err
+		}
+	}
 	if len(seps) == 0 {
 		seps = []string{"\n", ".", "?", "!"}
 	}
@@ -83,33 +107,47 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 		idGenerator = defaultIDGenerator
 	}
 	return &splitter{
-		lenFunc:     lenFunc,
-		chunkSize:   config.ChunkSize,
-		overlap:     config.OverlapSize,
-		separators:  seps,
-		keepType:    config.KeepType,
-		idGenerator: idGenerator,
+		lenFunc:          lenFunc,
+		chunkSize:        config.ChunkSize,
+		overlap:          config.OverlapSize,
+		separators:       seps,
+		keepType:         config.KeepType,
+		idGenerator:      idGenerator,
+		recordProvenance: config.RecordProvenance,
 	}, nil
 }
 
 type splitter struct {
-	lenFunc     func(string) int
-	chunkSize   int
-	overlap     int
-	separators  []string
-	keepType    KeepType
-	idGenerator IDGenerator
+	lenFunc          func(string) int
+	chunkSize        int
+	overlap          int
+	separators       []string
+	keepType         KeepType
+	idGenerator      IDGenerator
+	recordProvenance bool
 }
 
 func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
 	ret := make([]*schema.Document, 0, len(docs))
 	for _, doc := range docs {
 		splits := s.splitText(ctx, doc.Content, s.separators)
+		var provs []*provenance.Provenance
+		if s.recordProvenance {
+			// chunks are trimmed substrings of the content
+			provs = provenance.Build(doc.ID, doc.Content, provenance.Locate(doc.Content, splits))
+		}
 		for i, split := range splits {
+			meta := deepCopyMap(doc.MetaData)
+			if provs != nil {
+				if meta == nil {
+					meta = make(map[string]interface{})
+				}
+				provenance.Set(meta, provs[i])
+			}
 			ret = append(ret, &schema.Document{
 				ID:       s.idGenerator(ctx, doc.ID, i),
 				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				MetaData: meta,
 			})
 		}
 	}
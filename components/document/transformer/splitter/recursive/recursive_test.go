@@ -23,6 +23,10 @@ import (
 	"testing"
 
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/separator"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/tokenizer"
 )
 
 func TestRecursiveSplitter(t *testing.T) {
@@ -127,3 +131,75 @@ func TestRecursiveSplitter(t *testing.T) {
 		})
 	}
 }
+
+func TestRecursiveSplitterWithPreset(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewSplitter(ctx, &Config{
+		ChunkSize:       6,
+		Tokenizer:       tokenizer.Runes,
+		SeparatorPreset: separator.Chinese,
+		KeepType:        KeepTypeEnd,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := s.Transform(ctx, []*schema.Document{{Content: "第一句话。第二句话！第三句。"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, doc := range docs {
+		got = append(got, doc.Content)
+	}
+	if want := []string{"第一句话。", "第二句话！", "第三句。"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err = NewSplitter(ctx, &Config{ChunkSize: 6, Tokenizer: "unknown"}); err == nil {
+		t.Error("expect error of unknown tokenizer")
+	}
+	if _, err = NewSplitter(ctx, &Config{ChunkSize: 6, SeparatorPreset: "unknown"}); err == nil {
+		t.Error("expect error of unknown separator preset")
+	}
+}
+
+func TestRecursiveSplitterWithProvenance(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewSplitter(ctx, &Config{
+		ChunkSize:        10,
+		OverlapSize:      5,
+		Separators:       []string{" "},
+		RecordProvenance: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "aaaa bbbb cccc dddd"
+	docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 3 {
+		t.Fatalf("expect 3 chunks, got %d", len(docs))
+	}
+	for i, doc := range docs {
+		p, ok := provenance.Get(doc.MetaData)
+		if !ok {
+			t.Fatalf("provenance of chunk %d not found", i)
+		}
+		if p.ParentID != "doc" || p.Index != i || p.Total != 3 {
+			t.Errorf("unexpected provenance of chunk %d: %+v", i, p)
+		}
+		if content[p.Start:p.End] != doc.Content {
+			t.Errorf("chunk %d is %q, but offsets point to %q", i, doc.Content, content[p.Start:p.End])
+		}
+	}
+	// "bbbb" is shared by the first and the second chunk
+	if p, _ := provenance.Get(docs[1].MetaData); p.Overlap != 4 {
+		t.Errorf("expect overlap 4, got %d", p.Overlap)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semantic
+
+import (
+	"math"
+	"sort"
+)
+
+// BreakpointStrategy specifies how the distance threshold of splitting is calculated.
+type BreakpointStrategy string
+
+const (
+	// BreakpointPercentile splits according to Config.Percentile.
+	BreakpointPercentile BreakpointStrategy = "percentile"
+	// BreakpointStandardDeviation splits where the distance is greater than the mean plus
+	// BreakpointAmount standard deviations.
+	BreakpointStandardDeviation BreakpointStrategy = "standard_deviation"
+	// BreakpointInterquartile splits where the distance is greater than the mean plus
+	// BreakpointAmount times the interquartile range.
+	BreakpointInterquartile BreakpointStrategy = "interquartile"
+	// BreakpointGradient splits at the peak of distances following a gradient greater than its BreakpointAmount
+	// percentile, which works better for texts whose sentences are all closely related, e.g. legal or medical documents.
+	BreakpointGradient BreakpointStrategy = "gradient"
+)
+
+var defaultBreakpointAmounts = map[BreakpointStrategy]float64{
+	BreakpointPercentile:        0,
+	BreakpointStandardDeviation: 3,
+	BreakpointInterquartile:     1.5,
+	BreakpointGradient:          0.95,
+}
+
+// breakpoints returns the indexes of texts where new chunks start, distances[i] is the distance between text i-1 and i.
+func (s *splitter) breakpoints(distances []float64) []int {
+	var ret []int
+	if s.strategy == BreakpointPercentile {
+		threshold := calThreshold(distances, s.percentile)
+		for i := 1; i < len(distances); i++ {
+			if distances[i] <= threshold {
+				ret = append(ret, i)
+			}
+		}
+		return ret
+	}
+
+	values := distances[1:]
+	if s.strategy == BreakpointGradient {
+		values = gradient(values)
+	}
+	var threshold float64
+	switch s.strategy {
+	case BreakpointStandardDeviation:
+		mean, std := meanStd(values)
+		threshold = mean + s.amount*std
+	case BreakpointInterquartile:
+		mean, _ := meanStd(values)
+		sorted := sortedCopy(values)
+		threshold = mean + s.amount*(quantile(sorted, 0.75)-quantile(sorted, 0.25))
+	case BreakpointGradient:
+		threshold = quantile(sortedCopy(values), s.amount)
+	}
+	for i, v := range values {
+		if v <= threshold {
+			continue
+		}
+		if s.strategy == BreakpointGradient {
+			// the central difference rises before the distance peaks, split at the peak itself
+			i = climb(distances[1:], i)
+			if len(ret) > 0 && ret[len(ret)-1] == i+1 {
+				continue
+			}
+		}
+		ret = append(ret, i+1)
+	}
+	return ret
+}
+
+// climb returns the index of the local maximum of values reached from i by going forward while values do not decrease.
+func climb(values []float64, i int) int {
+	for i+1 < len(values) && values[i+1] >= values[i] {
+		i++
+	}
+	return i
+}
+
+func meanStd(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+func sortedCopy(values []float64) []float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// quantile returns the q quantile of sorted values with linear interpolation.
+func quantile(sorted []float64, q float64) float64 {
+	q = math.Max(0, math.Min(1, q))
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// gradient returns the gradient of values with central differences in the interior
+// and one-sided differences at the boundaries.
+func gradient(values []float64) []float64 {
+	n := len(values)
+	ret := make([]float64, n)
+	if n < 2 {
+		return ret
+	}
+	ret[0] = values[1] - values[0]
+	ret[n-1] = values[n-1] - values[n-2]
+	for i := 1; i < n-1; i++ {
+		ret[i] = (values[i+1] - values[i-1]) / 2
+	}
+	return ret
+}
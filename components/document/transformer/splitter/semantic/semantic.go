@@ -22,10 +22,16 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/separator"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/tokenizer"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
 )
 
 // IDGenerator generates new IDs for split chunks
@@ -45,13 +51,39 @@ type Config struct {
 	MinChunkSize int
 	// Separators are sequentially used to split text. ["\n", ".", "?", "!"] by default.
 	Separators []string
+	// SeparatorPreset selects the default Separators by language when Separators is empty,
+	// e.g. separator.Chinese or separator.Go, see package separator for details.
+	SeparatorPreset separator.Preset
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
 	LenFunc func(s string) int
+	// Tokenizer selects a builtin LenFunc by name when LenFunc is nil, e.g. tokenizer.Cl100kBase and tokenizer.O200kBase
+	// count tokens of OpenAI models, tokenizer.Runes counts runes. See package tokenizer for details.
+	Tokenizer string
 	// Percentile specifies the number of splitting. If the difference between two chunks is greater than X percentile, these two chunks will be split.
+	// Only used by BreakpointPercentile.
 	Percentile float64
+	// BreakpointStrategy specifies how the distance threshold of splitting is calculated, BreakpointPercentile by default.
+	BreakpointStrategy BreakpointStrategy
+	// BreakpointAmount is the parameter of BreakpointStrategy, ignored by BreakpointPercentile which uses Percentile.
+	// It is the number of standard deviations for BreakpointStandardDeviation (3 by default), the multiple of the
+	// interquartile range for BreakpointInterquartile (1.5 by default), and the percentile of distance gradients
+	// for BreakpointGradient (0.95 by default).
+	BreakpointAmount float64
+	// MaxChunkSize is the hard limit of the chunk size measured by LenFunc. Chunks longer than it are split further
+	// by a recursive splitter with the same Separators and LenFunc. 0 means no limit.
+	MaxChunkSize int
+	// BatchSize is the max number of texts sent in one EmbedStrings call. 0 means all texts of a document are sent
+	// in one call. Wrap Embedding with the cache embedder of components/embedding/cache to reuse the vectors
+	// of unchanged texts across runs.
+	BatchSize int
+	// Concurrency is the max number of EmbedStrings calls running at the same time, documents and batches of
+	// a document are processed concurrently within the limit. 1 by default.
+	Concurrency int
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
+	// RecordProvenance specifies whether to record where each chunk comes from in its metadata, see package provenance.
+	RecordProvenance bool
 }
 
 func NewSplitter(ctx context.Context, config *Config) (document.Transformer, error) {
@@ -59,10 +91,22 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 		return nil, fmt.Errorf("embedding should not be nil")
 	}
 	lenFunc := config.LenFunc
+	if lenFunc == nil && config.Tokenizer != "" {
+		var err error
+		if lenFunc, err = tokenizer.LenFunc(config.Tokenizer); err != nil {
+			return nil, fmt.Errorf("get len func of tokenizer [%s] fail: %w", config.Tokenizer, err)
+		}
+	}
 	if lenFunc == nil {
 		lenFunc = func(s string) int { return len(s) }
 	}
 	seps := config.Separators
+	if len(seps) == 0 && config.SeparatorPreset != "" {
+		var err error
+		if seps, err = separator.Separators(config.SeparatorPreset); err != nil {
+			return nil, err
+		}
+	}
 	if len(seps) == 0 {
 		seps = []string{"\n", ".", "?", "!"}
 	}
@@ -70,50 +114,160 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	if percentile == 0 {
 		percentile = 0.9
 	}
+	strategy := config.BreakpointStrategy
+	if strategy == "" {
+		strategy = BreakpointPercentile
+	}
+	amount := config.BreakpointAmount
+	if amount == 0 {
+		amount = defaultBreakpointAmounts[strategy]
+	}
+	if _, ok := defaultBreakpointAmounts[strategy]; !ok {
+		return nil, fmt.Errorf("unknown breakpoint strategy: %s", strategy)
+	}
+	if config.BatchSize < 0 {
+		return nil, fmt.Errorf("batch size must be greater than or equal to zero")
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var fallback document.Transformer
+	if config.MaxChunkSize > 0 {
+		var err error
+		fallback, err = recursive.NewSplitter(ctx, &recursive.Config{
+			ChunkSize:  config.MaxChunkSize,
+			Separators: seps,
+			LenFunc:    lenFunc,
+			KeepType:   recursive.KeepTypeEnd,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("new recursive splitter fail: %w", err)
+		}
+	}
 	idGenerator := config.IDGenerator
 	if idGenerator == nil {
 		idGenerator = defaultIDGenerator
 	}
 	return &splitter{
-		embedding:    config.Embedding,
-		bufferSize:   config.BufferSize,
-		minChunkSize: config.MinChunkSize,
-		separators:   seps,
-		lenFunc:      lenFunc,
-		percentile:   percentile,
-		idGenerator:  idGenerator,
+		embedding:        config.Embedding,
+		bufferSize:       config.BufferSize,
+		minChunkSize:     config.MinChunkSize,
+		maxChunkSize:     config.MaxChunkSize,
+		separators:       seps,
+		lenFunc:          lenFunc,
+		percentile:       percentile,
+		strategy:         strategy,
+		amount:           amount,
+		batchSize:        config.BatchSize,
+		concurrency:      concurrency,
+		fallback:         fallback,
+		idGenerator:      idGenerator,
+		recordProvenance: config.RecordProvenance,
 	}, nil
 }
 
 type splitter struct {
-	embedding    embedding.Embedder
-	bufferSize   int
-	minChunkSize int
-	separators   []string
-	lenFunc      func(s string) int
-	percentile   float64
-	idGenerator  IDGenerator
+	embedding        embedding.Embedder
+	bufferSize       int
+	minChunkSize     int
+	maxChunkSize     int
+	separators       []string
+	lenFunc          func(s string) int
+	percentile       float64
+	strategy         BreakpointStrategy
+	amount           float64
+	batchSize        int
+	concurrency      int
+	fallback         document.Transformer
+	idGenerator      IDGenerator
+	recordProvenance bool
 }
 
 func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	results, err := s.splitDocs(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
 	ret := make([]*schema.Document, 0, len(docs))
-	for _, doc := range docs {
-		splits, err := s.splitText(ctx, doc.Content, s.separators)
-		if err != nil {
-			return nil, fmt.Errorf("split document[%s] fail: %w", doc.ID, err)
+	for docIdx, doc := range docs {
+		splits := results[docIdx]
+		var provs []*provenance.Provenance
+		if s.recordProvenance {
+			// chunks are concatenations of consecutive sentences of the content
+			provs = provenance.Build(doc.ID, doc.Content, provenance.Locate(doc.Content, splits))
 		}
 		for i, split := range splits {
+			meta := deepCopyMap(doc.MetaData)
+			if provs != nil {
+				if meta == nil {
+					meta = make(map[string]interface{})
+				}
+				provenance.Set(meta, provs[i])
+			}
 			ret = append(ret, &schema.Document{
 				ID:       s.idGenerator(ctx, doc.ID, i),
 				Content:  split,
-				MetaData: deepCopyMap(doc.MetaData),
+				MetaData: meta,
 			})
 		}
 	}
 	return ret, nil
 }
 
-func (s *splitter) splitText(ctx context.Context, text string, separators []string) ([]string, error) {
+// splitDocs splits docs with at most s.concurrency documents in progress.
+func (s *splitter) splitDocs(ctx context.Context, docs []*schema.Document) ([][]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results  = make([][]string, len(docs))
+		sem      = make(chan struct{}, s.concurrency)
+		indexes  = make(chan int)
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for w := 0; w < min(s.concurrency, len(docs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				splits, err := s.splitText(ctx, sem, docs[i].Content, s.separators)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("split document[%s] fail: %w", docs[i].ID, err)
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				results[i] = splits
+			}
+		}()
+	}
+feed:
+	for i := range docs {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *splitter) splitText(ctx context.Context, sem chan struct{}, text string, separators []string) ([]string, error) {
 	texts := []string{text}
 	// split
 	for i := range s.separators {
@@ -143,14 +297,10 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 	}
 
 	// embedding
-	var vectors [][]float64
-	v, err := s.embedding.EmbedStrings(ctx, combinedSentences)
+	vectors, err := s.embed(ctx, sem, combinedSentences)
 	if err != nil {
 		return nil, err
 	}
-	for i := range v {
-		vectors = append(vectors, v[i])
-	}
 
 	// cosine distances
 	distances := make([]float64, len(texts))
@@ -158,13 +308,7 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 		distances[i] = 1 - cosine(vectors[i-1], vectors[i])
 	}
 
-	threshold := calThreshold(distances, s.percentile)
-	var splitIndexes []int
-	for i := 1; i < len(distances); i++ {
-		if distances[i] <= threshold {
-			splitIndexes = append(splitIndexes, i)
-		}
-	}
+	splitIndexes := s.breakpoints(distances)
 	var ret []string
 	var startIndex int
 	for i := range splitIndexes {
@@ -176,6 +320,90 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 		startIndex = splitIndexes[i]
 	}
 	ret = append(ret, strings.Join(texts[startIndex:], ""))
+	return s.limitChunkSize(ctx, ret)
+}
+
+// embed embeds texts in batches of s.batchSize.
+// Batches run concurrently, and every EmbedStrings call holds a slot of sem.
+func (s *splitter) embed(ctx context.Context, sem chan struct{}, texts []string) ([][]float64, error) {
+	batchSize := s.batchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		vectors  = make([][]float64, len(texts))
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+batches:
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break batches
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					fail(fmt.Errorf("embed strings panic: %v", r))
+				}
+				<-sem
+				wg.Done()
+			}()
+			v, err := s.embedding.EmbedStrings(ctx, texts[start:end])
+			if err != nil {
+				fail(err)
+				return
+			}
+			if len(v) != end-start {
+				fail(fmt.Errorf("embedding returns %d vectors for %d texts", len(v), end-start))
+				return
+			}
+			copy(vectors[start:end], v)
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
+
+// limitChunkSize splits the chunks longer than maxChunkSize with the fallback recursive splitter.
+func (s *splitter) limitChunkSize(ctx context.Context, chunks []string) ([]string, error) {
+	if s.fallback == nil {
+		return chunks, nil
+	}
+	ret := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		if s.lenFunc(chunk) <= s.maxChunkSize {
+			ret = append(ret, chunk)
+			continue
+		}
+		docs, err := s.fallback.Transform(ctx, []*schema.Document{{Content: chunk}})
+		if err != nil {
+			return nil, fmt.Errorf("split chunk exceeding max chunk size fail: %w", err)
+		}
+		for _, doc := range docs {
+			ret = append(ret, doc.Content)
+		}
+	}
 	return ret, nil
 }
 
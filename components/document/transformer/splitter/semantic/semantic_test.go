@@ -19,12 +19,16 @@ package semantic
 import (
 	"context"
 	"fmt"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/common/provenance"
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
 	"math/rand/v2"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type randomEmbedding struct {
@@ -138,3 +142,177 @@ func TestSemanticSplitter(t *testing.T) {
 		})
 	}
 }
+
+// topicEmbedding embeds texts containing 'b' and other texts to orthogonal vectors,
+// and records the sizes of calls and the max number of concurrent calls.
+type topicEmbedding struct {
+	mu       sync.Mutex
+	calls    []int
+	inFlight int
+	maxIn    int
+}
+
+func (e *topicEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	e.mu.Lock()
+	e.calls = append(e.calls, len(texts))
+	e.inFlight++
+	e.maxIn = max(e.maxIn, e.inFlight)
+	e.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	defer func() {
+		e.mu.Lock()
+		e.inFlight--
+		e.mu.Unlock()
+	}()
+
+	ret := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		if strings.Contains(text, "b") {
+			ret = append(ret, []float64{0, 1})
+		} else {
+			ret = append(ret, []float64{1, 0})
+		}
+	}
+	return ret, nil
+}
+
+func TestSemanticSplitterBreakpointStrategies(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		config *Config
+		want   []string
+	}{
+		{
+			name:   "standard deviation",
+			config: &Config{BreakpointStrategy: BreakpointStandardDeviation, BreakpointAmount: 1},
+			want:   []string{"a1.a2.a3.", "b1.b2.b3"},
+		},
+		{
+			name:   "interquartile",
+			config: &Config{BreakpointStrategy: BreakpointInterquartile},
+			want:   []string{"a1.a2.a3.", "b1.b2.b3"},
+		},
+		{
+			name:   "gradient",
+			config: &Config{BreakpointStrategy: BreakpointGradient},
+			want:   []string{"a1.a2.a3.", "b1.b2.b3"},
+		},
+		{
+			name:   "max chunk size",
+			config: &Config{BreakpointStrategy: BreakpointStandardDeviation, BreakpointAmount: 1, MaxChunkSize: 6},
+			want:   []string{"a1.a2.", "a3.", "b1.b2.", "b3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Embedding = &topicEmbedding{}
+			tt.config.Separators = []string{"."}
+			s, err := NewSplitter(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			docs, err := s.Transform(ctx, []*schema.Document{{Content: "a1.a2.a3.b1.b2.b3"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, doc := range docs {
+				got = append(got, doc.Content)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	_, err := NewSplitter(ctx, &Config{Embedding: &topicEmbedding{}, BreakpointStrategy: "unknown"})
+	if err == nil {
+		t.Error("expect error of unknown strategy")
+	}
+}
+
+func TestSemanticSplitterBatchAndConcurrency(t *testing.T) {
+	ctx := context.Background()
+	emb := &topicEmbedding{}
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:          emb,
+		Separators:         []string{"."},
+		BreakpointStrategy: BreakpointStandardDeviation,
+		BreakpointAmount:   1,
+		BatchSize:          2,
+		Concurrency:        2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := s.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "a1.a2.a3.b1.b2.b3"},
+		{ID: "2", Content: "a1.a1.a1.b1.b1"},
+		{ID: "3", Content: "a1.a2.a3.b1.b2.b3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, doc := range docs {
+		got = append(got, doc.ID+":"+doc.Content)
+	}
+	want := []string{"1:a1.a2.a3.", "1:b1.b2.b3", "2:a1.a1.a1.", "2:b1.b1", "3:a1.a2.a3.", "3:b1.b2.b3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	total := 0
+	for _, n := range emb.calls {
+		if n > 2 {
+			t.Errorf("batch size %d exceeds the limit", n)
+		}
+		total += n
+	}
+	if total != 6+5+6 {
+		t.Errorf("embedded %d texts", total)
+	}
+	if emb.maxIn > 2 {
+		t.Errorf("%d calls run concurrently", emb.maxIn)
+	}
+}
+
+func TestSemanticSplitterWithProvenance(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:          &topicEmbedding{},
+		Separators:         []string{"。"},
+		BreakpointStrategy: BreakpointStandardDeviation,
+		BreakpointAmount:   1,
+		RecordProvenance:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "a1。a2。a3。b1。b2。b3"
+	docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: content}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []provenance.Provenance
+	for i, doc := range docs {
+		p, ok := provenance.Get(doc.MetaData)
+		if !ok {
+			t.Fatalf("provenance of chunk %d not found", i)
+		}
+		if text := string([]rune(content)[p.Start:p.End]); text != doc.Content {
+			t.Errorf("chunk %d is %q, but offsets point to %q", i, doc.Content, text)
+		}
+		got = append(got, *p)
+	}
+	want := []provenance.Provenance{
+		{ParentID: "doc", Index: 0, Total: 2, Start: 0, End: 9},
+		{ParentID: "doc", Index: 1, Total: 2, Start: 9, End: 17},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package batch wraps an embedding.Embedder to split inputs into provider-sized batches, run them with
+// bounded parallelism, retry failed batches, and stay within rate limits.
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+var (
+	ErrEmbedderRequired = errors.New("embedding/batch: embedder is required")
+	// ErrEmbeddingCount is reported when the embedder returns a different number of embeddings than texts.
+	ErrEmbeddingCount = errors.New("embedding/batch: embedding count mismatch")
+)
+
+// Config is the config of Embedder.
+type Config struct {
+	// Embedder is the wrapped embedder, required.
+	Embedder embedding.Embedder
+
+	// MaxBatchSize is the max number of texts in one request of Embedder, 0 means no limit.
+	MaxBatchSize int
+	// MaxBatchTokens is the max total tokens of the texts in one request, counted by TokenCounter.
+	// A text with more tokens than it is sent alone. 0 means no limit.
+	MaxBatchTokens int
+	// TokenCounter counts the tokens of a text for MaxBatchTokens and TokensPerMinute.
+	// By default it is len(text)/4 rounded up, a rough estimation for BPE tokenizers.
+	TokenCounter func(text string) int
+
+	// MaxConcurrency is the max number of requests in flight for one EmbedStrings call, 1 by default.
+	MaxConcurrency int
+
+	// MaxRetries is the max number of retries of a failed request, 0 means no retry.
+	MaxRetries int
+	// RetryBaseDelay is the delay before the first retry, doubled for every retry. 500ms by default.
+	// The actual delay is randomized between half of it and it.
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the delay between retries, 30s by default.
+	RetryMaxDelay time.Duration
+	// IsRetriable reports whether a failed request should be retried.
+	// By default all errors are retried except the cancellation of the context.
+	IsRetriable func(err error) bool
+
+	// RequestsPerMinute limits the requests to Embedder, shared by all calls of the Embedder. 0 means no limit.
+	RequestsPerMinute int
+	// TokensPerMinute limits the tokens sent to Embedder, counted by TokenCounter and shared by all calls
+	// of the Embedder. 0 means no limit.
+	TokensPerMinute int
+}
+
+// Embedder splits the texts of EmbedStrings into batches, embeds them with bounded parallelism, retries
+// and rate limits, and returns the embeddings in the order of the texts.
+// If a batch fails after its retries, the other batches of the call are canceled and the error is returned.
+type Embedder struct {
+	embedder       embedding.Embedder
+	maxBatchSize   int
+	maxBatchTokens int
+	countTokens    func(string) int
+	maxConcurrency int
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	isRetriable    func(error) bool
+	requests       *limiter
+	tokens         *limiter
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// NewEmbedder creates a new [Embedder].
+func NewEmbedder(_ context.Context, config *Config) (*Embedder, error) {
+	if config == nil || config.Embedder == nil {
+		return nil, ErrEmbedderRequired
+	}
+	if config.MaxBatchSize < 0 || config.MaxBatchTokens < 0 || config.MaxConcurrency < 0 || config.MaxRetries < 0 ||
+		config.RequestsPerMinute < 0 || config.TokensPerMinute < 0 {
+		return nil, errors.New("embedding/batch: limits must be non-negative")
+	}
+
+	e := &Embedder{
+		embedder:       config.Embedder,
+		maxBatchSize:   config.MaxBatchSize,
+		maxBatchTokens: config.MaxBatchTokens,
+		countTokens:    config.TokenCounter,
+		maxConcurrency: config.MaxConcurrency,
+		maxRetries:     config.MaxRetries,
+		retryBaseDelay: config.RetryBaseDelay,
+		retryMaxDelay:  config.RetryMaxDelay,
+		isRetriable:    config.IsRetriable,
+		sleep:          sleep,
+	}
+	if e.countTokens == nil {
+		e.countTokens = estimateTokens
+	}
+	if e.maxConcurrency == 0 {
+		e.maxConcurrency = 1
+	}
+	if e.retryBaseDelay <= 0 {
+		e.retryBaseDelay = 500 * time.Millisecond
+	}
+	if e.retryMaxDelay <= 0 {
+		e.retryMaxDelay = 30 * time.Second
+	}
+	if e.isRetriable == nil {
+		e.isRetriable = defaultIsRetriable
+	}
+	if config.RequestsPerMinute > 0 {
+		e.requests = newLimiter(config.RequestsPerMinute)
+	}
+	if config.TokensPerMinute > 0 {
+		e.tokens = newLimiter(config.TokensPerMinute)
+	}
+	return e, nil
+}
+
+type batch struct {
+	start  int
+	texts  []string
+	tokens int
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	result := make([][]float64, len(texts))
+	if len(texts) == 0 {
+		return result, nil
+	}
+	batches := e.split(texts)
+	if len(batches) == 1 {
+		if err := e.embed(ctx, batches[0], result, opts); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, e.maxConcurrency)
+	)
+	for _, b := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(b batch) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := e.embed(ctx, b, result, opts); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(b)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// split groups the texts into batches in order, bounded by maxBatchSize and maxBatchTokens.
+func (e *Embedder) split(texts []string) []batch {
+	var (
+		batches []batch
+		current = batch{}
+	)
+	for i, text := range texts {
+		tokens := 0
+		if e.maxBatchTokens > 0 || e.tokens != nil {
+			tokens = e.countTokens(text)
+		}
+		full := (e.maxBatchSize > 0 && len(current.texts) >= e.maxBatchSize) ||
+			(e.maxBatchTokens > 0 && current.tokens+tokens > e.maxBatchTokens)
+		if full && len(current.texts) > 0 {
+			batches = append(batches, current)
+			current = batch{start: i}
+		}
+		current.texts = append(current.texts, text)
+		current.tokens += tokens
+	}
+	return append(batches, current)
+}
+
+// embed requests the embeddings of a batch with retries, and puts them into result.
+func (e *Embedder) embed(ctx context.Context, b batch, result [][]float64, opts []embedding.Option) error {
+	for attempt := 0; ; attempt++ {
+		embeddings, err := e.request(ctx, b, opts)
+		if err == nil {
+			copy(result[b.start:], embeddings)
+			return nil
+		}
+		if errors.Is(err, ErrEmbeddingCount) || attempt >= e.maxRetries || ctx.Err() != nil || !e.isRetriable(err) {
+			return err
+		}
+		if err = e.sleep(ctx, e.backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *Embedder) request(ctx context.Context, b batch, opts []embedding.Option) ([][]float64, error) {
+	if e.requests != nil {
+		if err := e.requests.wait(ctx, 1); err != nil {
+			return nil, err
+		}
+	}
+	if e.tokens != nil {
+		if err := e.tokens.wait(ctx, b.tokens); err != nil {
+			return nil, err
+		}
+	}
+	embeddings, err := e.embedder.EmbedStrings(ctx, b.texts, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) != len(b.texts) {
+		return nil, fmt.Errorf("%w: got %d embeddings for %d texts", ErrEmbeddingCount, len(embeddings), len(b.texts))
+	}
+	return embeddings, nil
+}
+
+// backoff returns the delay before the retry after attempt, with jitter.
+func (e *Embedder) backoff(attempt int) time.Duration {
+	delay := e.retryBaseDelay
+	for i := 0; i < attempt && delay < e.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, e.retryMaxDelay)
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+func (e *Embedder) GetType() string {
+	return "BatchEmbedder"
+}
+
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+func defaultIsRetriable(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmbedder embeds a text into a vector holding its length, failing according to fail.
+type fakeEmbedder struct {
+	mu       sync.Mutex
+	batches  [][]string
+	inflight int32
+	maxSeen  int32
+	delay    time.Duration
+	fail     func(call int, texts []string) error
+}
+
+func (f *fakeEmbedder) EmbedStrings(ctx context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	n := atomic.AddInt32(&f.inflight, 1)
+	defer atomic.AddInt32(&f.inflight, -1)
+	for {
+		seen := atomic.LoadInt32(&f.maxSeen)
+		if n <= seen || atomic.CompareAndSwapInt32(&f.maxSeen, seen, n) {
+			break
+		}
+	}
+
+	f.mu.Lock()
+	call := len(f.batches)
+	f.batches = append(f.batches, texts)
+	f.mu.Unlock()
+
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.fail != nil {
+		if err := f.fail(call, texts); err != nil {
+			return nil, err
+		}
+	}
+	ret := make([][]float64, len(texts))
+	for i, text := range texts {
+		ret[i] = []float64{float64(len(text))}
+	}
+	return ret, nil
+}
+
+func texts(n int) []string {
+	ret := make([]string, n)
+	for i := range ret {
+		ret[i] = fmt.Sprintf("%0*d", i+1, 0)
+	}
+	return ret
+}
+
+func TestEmbedder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("split by size and tokens", func(t *testing.T) {
+		fe := &fakeEmbedder{}
+		e, err := NewEmbedder(ctx, &Config{
+			Embedder:       fe,
+			MaxBatchSize:   3,
+			MaxBatchTokens: 10,
+			TokenCounter:   func(text string) int { return len(text) },
+		})
+		require.NoError(t, err)
+
+		result, err := e.EmbedStrings(ctx, []string{"a", "b", "c", "d", "eeeeeeeee", "ffffffffffff", "g"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}, {1}, {1}, {1}, {9}, {12}, {1}}, result)
+		assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "eeeeeeeee"}, {"ffffffffffff"}, {"g"}}, fe.batches)
+
+		result, err = e.EmbedStrings(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.Len(t, fe.batches, 4)
+	})
+
+	t.Run("bounded concurrency keeps order", func(t *testing.T) {
+		fe := &fakeEmbedder{delay: 10 * time.Millisecond}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxBatchSize: 2, MaxConcurrency: 3})
+		require.NoError(t, err)
+
+		result, err := e.EmbedStrings(ctx, texts(20))
+		assert.NoError(t, err)
+		for i := range result {
+			assert.Equal(t, []float64{float64(i + 1)}, result[i])
+		}
+		assert.Len(t, fe.batches, 10)
+		assert.LessOrEqual(t, fe.maxSeen, int32(3))
+		assert.Greater(t, fe.maxSeen, int32(1))
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		fe := &fakeEmbedder{fail: func(call int, _ []string) error {
+			if call < 2 {
+				return errors.New("429 too many requests")
+			}
+			return nil
+		}}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxRetries: 2, RetryBaseDelay: 100 * time.Millisecond})
+		require.NoError(t, err)
+		var delays []time.Duration
+		e.sleep = func(_ context.Context, d time.Duration) error {
+			delays = append(delays, d)
+			return nil
+		}
+
+		result, err := e.EmbedStrings(ctx, []string{"a"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}}, result)
+		assert.Len(t, fe.batches, 3)
+		require.Len(t, delays, 2)
+		assert.True(t, delays[0] >= 50*time.Millisecond && delays[0] <= 100*time.Millisecond, delays[0])
+		assert.True(t, delays[1] >= 100*time.Millisecond && delays[1] <= 200*time.Millisecond, delays[1])
+	})
+
+	t.Run("retries exhausted and not retriable", func(t *testing.T) {
+		permanent := errors.New("400 bad request")
+		fe := &fakeEmbedder{fail: func(call int, texts []string) error {
+			if texts[0] == "bad" {
+				return permanent
+			}
+			return errors.New("503 unavailable")
+		}}
+		e, err := NewEmbedder(ctx, &Config{
+			Embedder:    fe,
+			MaxRetries:  3,
+			IsRetriable: func(err error) bool { return !errors.Is(err, permanent) },
+		})
+		require.NoError(t, err)
+		e.sleep = func(context.Context, time.Duration) error { return nil }
+
+		_, err = e.EmbedStrings(ctx, []string{"bad"})
+		assert.ErrorIs(t, err, permanent)
+		assert.Len(t, fe.batches, 1)
+
+		_, err = e.EmbedStrings(ctx, []string{"a"})
+		assert.Error(t, err)
+		assert.Len(t, fe.batches, 5)
+	})
+
+	t.Run("failed batch cancels the others", func(t *testing.T) {
+		fe := &fakeEmbedder{delay: 5 * time.Millisecond, fail: func(_ int, texts []string) error {
+			if texts[0] == "0" {
+				return errors.New("boom")
+			}
+			return nil
+		}}
+		e, err := NewEmbedder(ctx, &Config{Embedder: fe, MaxBatchSize: 1, MaxConcurrency: 2})
+		require.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, texts(50))
+		assert.EqualError(t, err, "boom")
+		assert.Less(t, len(fe.batches), 50)
+	})
+
+	t.Run("embedding count mismatch", func(t *testing.T) {
+		e, err := NewEmbedder(ctx, &Config{Embedder: embedderFunc(func(texts []string) [][]float64 {
+			return [][]float64{{1}}
+		}), MaxRetries: 3})
+		require.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, []string{"a", "b"})
+		assert.ErrorIs(t, err, ErrEmbeddingCount)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewEmbedder(ctx, nil)
+		assert.ErrorIs(t, err, ErrEmbedderRequired)
+		_, err = NewEmbedder(ctx, &Config{Embedder: &fakeEmbedder{}, MaxBatchSize: -1})
+		assert.Error(t, err)
+	})
+}
+
+type embedderFunc func(texts []string) [][]float64
+
+func (f embedderFunc) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	return f(texts), nil
+}
+
+func TestEmbedderRateLimit(t *testing.T) {
+	ctx := context.Background()
+	fe := &fakeEmbedder{}
+	e, err := NewEmbedder(ctx, &Config{
+		Embedder:          fe,
+		MaxBatchSize:      1,
+		RequestsPerMinute: 2,
+		TokensPerMinute:   60,
+		TokenCounter:      func(text string) int { return len(text) },
+	})
+	require.NoError(t, err)
+
+	// a fake clock advanced by the waits
+	now := time.Now()
+	var waits []time.Duration
+	for _, l := range []*limiter{e.requests, e.tokens} {
+		l.last = now
+		l.now = func() time.Time { return now }
+		l.sleep = func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			now = now.Add(d)
+			return nil
+		}
+	}
+
+	_, err = e.EmbedStrings(ctx, []string{"aaaaa", "bbbbb"})
+	assert.NoError(t, err)
+	assert.Empty(t, waits)
+
+	// the request budget is spent, the next request waits for half a minute
+	_, err = e.EmbedStrings(ctx, []string{"ccccc"})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{30 * time.Second}, waits)
+
+	// the token budget refills to 60 during the request wait, and is spent by the next request
+	waits = nil
+	_, err = e.EmbedStrings(ctx, []string{string(make([]byte, 60))})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{30 * time.Second}, waits)
+
+	// a request larger than the budget waits for a full bucket, 30 tokens are refilled during the request wait
+	waits = nil
+	_, err = e.EmbedStrings(ctx, []string{string(make([]byte, 90))})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{30 * time.Second, 30 * time.Second}, waits)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	e.requests.sleep = sleep
+	e.requests.tokens, e.requests.last = 0, now
+	_, err = e.EmbedStrings(canceled, []string{"d"})
+	assert.ErrorIs(t, err, context.Canceled)
+	// the reservation of the canceled request is given back
+	assert.Equal(t, 0.0, e.requests.tokens)
+}
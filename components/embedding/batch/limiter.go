@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket holding up to a minute of budget, refilled continuously.
+// Callers reserve their tokens at once and wait for the deficit, so the budget can go negative
+// and later callers queue behind earlier ones.
+type limiter struct {
+	mu       sync.Mutex
+	capacity float64
+	rate     float64 // tokens per second
+	tokens   float64
+	last     time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newLimiter(perMinute int) *limiter {
+	return &limiter{
+		capacity: float64(perMinute),
+		rate:     float64(perMinute) / 60,
+		tokens:   float64(perMinute),
+		last:     time.Now(),
+		now:      time.Now,
+		sleep:    sleep,
+	}
+}
+
+// wait reserves n tokens and waits until they are available. A request larger than the capacity
+// waits for a full bucket.
+func (l *limiter) wait(ctx context.Context, n int) error {
+	need := min(float64(n), l.capacity)
+
+	l.mu.Lock()
+	now := l.now()
+	l.tokens = min(l.capacity, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= need
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := l.sleep(ctx, delay); err != nil {
+		// give back the reservation
+		l.mu.Lock()
+		l.tokens += need
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
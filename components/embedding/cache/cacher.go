@@ -18,6 +18,8 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -31,3 +33,65 @@ type Cacher interface {
 	// If the value is not of type []float64, it returns an error.
 	Get(ctx context.Context, key string) ([]float64, bool, error)
 }
+
+// Entry is a cache entry stored by [BatchCacher.MSet].
+type Entry struct {
+	Key    string
+	Value  []float64
+	Expire time.Duration
+}
+
+// BatchCacher is an optional extension of [Cacher] which gets and sets multiple entries at once,
+// e.g. in one round trip to a remote cache. [Embedder] uses it instead of the per-key methods
+// if the [Cacher] implements it.
+type BatchCacher interface {
+	Cacher
+
+	// MGet retrieves the values of keys. The returned slices have the same length as keys,
+	// the value of a missing key is nil and its found flag is false.
+	MGet(ctx context.Context, keys []string) (values [][]float64, found []bool, err error)
+
+	// MSet stores the entries, each with its own expiration.
+	// Existing keys will be overwritten.
+	MSet(ctx context.Context, entries []Entry) error
+}
+
+// getMany gets the values of keys from cacher, with one MGet if it is a [BatchCacher].
+func getMany(ctx context.Context, cacher Cacher, keys []string) ([][]float64, []bool, error) {
+	if bc, ok := cacher.(BatchCacher); ok {
+		values, found, err := bc.MGet(ctx, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(values) != len(keys) || len(found) != len(keys) {
+			return nil, nil, fmt.Errorf("embedding/cache: cacher returned %d values for %d keys", len(values), len(keys))
+		}
+		return values, found, nil
+	}
+
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	for idx, key := range keys {
+		value, ok, err := cacher.Get(ctx, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[idx], found[idx] = value, ok
+	}
+	return values, found, nil
+}
+
+// setMany stores the entries into cacher, with one MSet if it is a [BatchCacher].
+// Otherwise every entry is tried, and the errors are joined.
+func setMany(ctx context.Context, cacher Cacher, entries []Entry) error {
+	if bc, ok := cacher.(BatchCacher); ok {
+		return bc.MSet(ctx, entries)
+	}
+	var errs []error
+	for _, entry := range entries {
+		if err := cacher.Set(ctx, entry.Key, entry.Value, entry.Expire); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
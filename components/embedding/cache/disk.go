@@ -0,0 +1,371 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// diskMagic starts every cache file, the last byte is the version of the format.
+var diskMagic = []byte("EINOVEC\x01")
+
+const (
+	// diskHeaderSize is the size of the fixed part of a record:
+	// crc32 (4), kind (1), expiresAt in unix nanoseconds (8), key length (4) and vector length (4).
+	diskHeaderSize = 21
+
+	diskKindFloat64 byte = 1
+	diskKindFloat32 byte = 2
+
+	diskMaxKeyLen = 1 << 20
+	diskMaxDim    = 1 << 24
+)
+
+// DiskConfig is the config of DiskCacher.
+type DiskConfig struct {
+	// Path is the path of the cache file, required. The file is created if it does not exist.
+	Path string
+	// Float32 stores vectors as float32, which halves the file size at the cost of precision.
+	// Entries written before keep their own precision.
+	Float32 bool
+}
+
+// DiskCacher is a persistent [Cacher] storing vectors in a local file in a compact binary format.
+// Entries are appended to the file, only their offsets are kept in memory and vectors are read from the file
+// on Get. The file is compacted when it is opened, dropping overwritten and expired entries, and can be
+// compacted again by Compact. A record truncated by a crash is dropped when the file is opened.
+// A DiskCacher must not be shared by multiple processes.
+type DiskCacher struct {
+	mu      sync.RWMutex
+	path    string
+	file    *os.File
+	size    int64
+	index   map[string]diskEntry
+	float32 bool
+	now     func() time.Time
+}
+
+type diskEntry struct {
+	offset    int64
+	kind      byte
+	keyLen    uint32
+	dim       uint32
+	expiresAt int64
+}
+
+func (e diskEntry) width() int {
+	if e.kind == diskKindFloat32 {
+		return 4
+	}
+	return 8
+}
+
+func (e diskEntry) recordSize() int64 {
+	return diskHeaderSize + int64(e.keyLen) + int64(e.dim)*int64(e.width())
+}
+
+var _ BatchCacher = (*DiskCacher)(nil)
+
+// NewDiskCacher opens the [DiskCacher] at config.Path, and compacts the file.
+func NewDiskCacher(config *DiskConfig) (*DiskCacher, error) {
+	if config == nil || config.Path == "" {
+		return nil, errors.New("embedding/cache: path of disk cacher is required")
+	}
+	f, err := os.OpenFile(config.Path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open cache file [%s] fail: %w", config.Path, err)
+	}
+	index, err := readDiskIndex(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("read cache file [%s] fail: %w", config.Path, err)
+	}
+
+	c := &DiskCacher{
+		path:    config.Path,
+		file:    f,
+		index:   index,
+		float32: config.Float32,
+		now:     time.Now,
+	}
+	if err = c.compact(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// readDiskIndex scans the records of the file, stopping at the first incomplete or corrupted one.
+func readDiskIndex(f *os.File) (map[string]diskEntry, error) {
+	index := make(map[string]diskEntry)
+	r := bufio.NewReader(f)
+	magic := make([]byte, len(diskMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		if errors.Is(err, io.EOF) {
+			return index, nil
+		}
+		return nil, err
+	}
+	if string(magic) != string(diskMagic) {
+		return nil, errors.New("not a cache file or unsupported version")
+	}
+
+	offset := int64(len(diskMagic))
+	header := make([]byte, diskHeaderSize)
+	var body []byte
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return index, nil
+			}
+			return nil, err
+		}
+		entry := diskEntry{
+			offset:    offset,
+			kind:      header[4],
+			expiresAt: int64(binary.LittleEndian.Uint64(header[5:13])),
+			keyLen:    binary.LittleEndian.Uint32(header[13:17]),
+			dim:       binary.LittleEndian.Uint32(header[17:21]),
+		}
+		if (entry.kind != diskKindFloat64 && entry.kind != diskKindFloat32) || entry.keyLen > diskMaxKeyLen || entry.dim > diskMaxDim {
+			return index, nil
+		}
+		size := entry.recordSize() - diskHeaderSize
+		if int64(cap(body)) < size {
+			body = make([]byte, size)
+		}
+		body = body[:size]
+		if _, err := io.ReadFull(r, body); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return index, nil
+			}
+			return nil, err
+		}
+		crc := crc32.NewIEEE()
+		_, _ = crc.Write(header[4:])
+		_, _ = crc.Write(body)
+		if crc.Sum32() != binary.LittleEndian.Uint32(header[:4]) {
+			return index, nil
+		}
+		index[string(body[:entry.keyLen])] = entry
+		offset += entry.recordSize()
+	}
+}
+
+func (c *DiskCacher) Set(ctx context.Context, key string, value []float64, expire time.Duration) error {
+	return c.MSet(ctx, []Entry{{Key: key, Value: value, Expire: expire}})
+}
+
+func (c *DiskCacher) Get(_ context.Context, key string) ([]float64, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.get(key)
+}
+
+func (c *DiskCacher) MGet(_ context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for i, key := range keys {
+		value, ok, err := c.get(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i], found[i] = value, ok
+	}
+	return values, found, nil
+}
+
+// MSet appends the entries to the file with one write.
+func (c *DiskCacher) MSet(_ context.Context, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	kind := diskKindFloat64
+	if c.float32 {
+		kind = diskKindFloat32
+	}
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var buf []byte
+	added := make(map[string]diskEntry, len(entries))
+	for _, e := range entries {
+		if len(e.Key) > diskMaxKeyLen || len(e.Value) > diskMaxDim {
+			return fmt.Errorf("embedding/cache: entry [%.32s] is too large for disk cacher", e.Key)
+		}
+		entry := diskEntry{
+			offset: c.size + int64(len(buf)),
+			kind:   kind,
+			keyLen: uint32(len(e.Key)),
+			dim:    uint32(len(e.Value)),
+		}
+		if e.Expire > 0 {
+			entry.expiresAt = now.Add(e.Expire).UnixNano()
+		}
+		buf = appendDiskRecord(buf, entry, e.Key, e.Value)
+		added[e.Key] = entry
+	}
+
+	if _, err := c.file.Write(buf); err != nil {
+		// drop the partially written records, so that later records stay readable
+		_ = c.file.Truncate(c.size)
+		return fmt.Errorf("write cache file [%s] fail: %w", c.path, err)
+	}
+	c.size += int64(len(buf))
+	for key, entry := range added {
+		c.index[key] = entry
+	}
+	return nil
+}
+
+// Len returns the number of cached entries, including the expired ones not compacted yet.
+func (c *DiskCacher) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.index)
+}
+
+// Compact rewrites the file with only the live entries.
+func (c *DiskCacher) Compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.compact()
+}
+
+// Close closes the underlying file.
+func (c *DiskCacher) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.file.Close()
+}
+
+func (c *DiskCacher) get(key string) ([]float64, bool, error) {
+	entry, ok := c.index[key]
+	if !ok || c.expired(entry) {
+		return nil, false, nil
+	}
+	data := make([]byte, int(entry.dim)*entry.width())
+	if _, err := c.file.ReadAt(data, entry.offset+diskHeaderSize+int64(entry.keyLen)); err != nil {
+		return nil, false, fmt.Errorf("read cache file [%s] fail: %w", c.path, err)
+	}
+	value := make([]float64, entry.dim)
+	for i := range value {
+		if entry.kind == diskKindFloat32 {
+			value[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:])))
+		} else {
+			value[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+		}
+	}
+	return value, true, nil
+}
+
+func (c *DiskCacher) expired(entry diskEntry) bool {
+	return entry.expiresAt != 0 && c.now().UnixNano() >= entry.expiresAt
+}
+
+// compact copies the live records into a temporary file, then replaces the original one.
+func (c *DiskCacher) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create cache file fail: %w", err)
+	}
+	w := bufio.NewWriter(tmp)
+	index := make(map[string]diskEntry, len(c.index))
+	offset := int64(len(diskMagic))
+	_, err = w.Write(diskMagic)
+	var record []byte
+	for key, entry := range c.index {
+		if err != nil {
+			break
+		}
+		if c.expired(entry) {
+			continue
+		}
+		size := entry.recordSize()
+		if int64(cap(record)) < size {
+			record = make([]byte, size)
+		}
+		record = record[:size]
+		if _, err = c.file.ReadAt(record, entry.offset); err != nil {
+			break
+		}
+		if _, err = w.Write(record); err != nil {
+			break
+		}
+		entry.offset = offset
+		index[key] = entry
+		offset += size
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("compact cache file [%s] fail: %w", c.path, err)
+	}
+
+	f, err := os.OpenFile(c.path, os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open cache file [%s] fail: %w", c.path, err)
+	}
+	_ = c.file.Close()
+	c.file = f
+	c.size = offset
+	c.index = index
+	return nil
+}
+
+func appendDiskRecord(buf []byte, entry diskEntry, key string, value []float64) []byte {
+	start := len(buf)
+	buf = append(buf, make([]byte, diskHeaderSize)...)
+	buf[start+4] = entry.kind
+	binary.LittleEndian.PutUint64(buf[start+5:], uint64(entry.expiresAt))
+	binary.LittleEndian.PutUint32(buf[start+13:], entry.keyLen)
+	binary.LittleEndian.PutUint32(buf[start+17:], entry.dim)
+	buf = append(buf, key...)
+	for _, f := range value {
+		if entry.kind == diskKindFloat32 {
+			buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f)))
+		} else {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(f))
+		}
+	}
+	binary.LittleEndian.PutUint32(buf[start:], crc32.ChecksumIEEE(buf[start+4:]))
+	return buf
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCacher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("persist and compact", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "embeddings.cache")
+		c, err := NewDiskCacher(&DiskConfig{Path: path})
+		require.NoError(t, err)
+
+		assert.NoError(t, c.Set(ctx, "a", []float64{1.1, 2.2}, 0))
+		assert.NoError(t, c.MSet(ctx, []Entry{
+			{Key: "b", Value: []float64{3.3}},
+			{Key: "a", Value: []float64{4.4, 5.5}},
+			{Key: "c", Value: []float64{6.6}, Expire: time.Hour},
+		}))
+		values, found, err := c.MGet(ctx, []string{"a", "b", "missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{4.4, 5.5}, {3.3}, nil}, values)
+		assert.Equal(t, []bool{true, true, false}, found)
+		assert.NoError(t, c.Close())
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		sizeBefore := info.Size()
+
+		c, err = NewDiskCacher(&DiskConfig{Path: path})
+		require.NoError(t, err)
+		defer c.Close()
+		assert.Equal(t, 3, c.Len())
+		info, err = os.Stat(path)
+		require.NoError(t, err)
+		assert.Less(t, info.Size(), sizeBefore)
+
+		value, ok, err := c.Get(ctx, "a")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{4.4, 5.5}, value)
+
+		now := time.Now().Add(time.Hour)
+		c.now = func() time.Time { return now }
+		_, ok, err = c.Get(ctx, "c")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.NoError(t, c.Compact())
+		assert.Equal(t, 2, c.Len())
+		value, ok, err = c.Get(ctx, "b")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{3.3}, value)
+	})
+
+	t.Run("float32", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "embeddings.cache")
+		c, err := NewDiskCacher(&DiskConfig{Path: path})
+		require.NoError(t, err)
+		assert.NoError(t, c.Set(ctx, "f64", []float64{1.1}, 0))
+		assert.NoError(t, c.Close())
+
+		c, err = NewDiskCacher(&DiskConfig{Path: path, Float32: true})
+		require.NoError(t, err)
+		defer c.Close()
+		assert.NoError(t, c.Set(ctx, "f32", []float64{1.1}, 0))
+
+		value, _, err := c.Get(ctx, "f64")
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{1.1}, value)
+		value, _, err = c.Get(ctx, "f32")
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.1, value[0], 1e-6)
+		assert.NotEqual(t, 1.1, value[0])
+	})
+
+	t.Run("truncated record", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "embeddings.cache")
+		c, err := NewDiskCacher(&DiskConfig{Path: path})
+		require.NoError(t, err)
+		assert.NoError(t, c.Set(ctx, "a", []float64{1}, 0))
+		assert.NoError(t, c.Set(ctx, "b", []float64{2}, 0))
+		assert.NoError(t, c.Close())
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, info.Size()-3))
+
+		c, err = NewDiskCacher(&DiskConfig{Path: path})
+		require.NoError(t, err)
+		defer c.Close()
+		values, found, err := c.MGet(ctx, []string{"a", "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}, nil}, values)
+		assert.Equal(t, []bool{true, false}, found)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		_, err := NewDiskCacher(nil)
+		assert.Error(t, err)
+
+		path := filepath.Join(t.TempDir(), "embeddings.cache")
+		require.NoError(t, os.WriteFile(path, []byte("not a cache file"), 0o644))
+		_, err = NewDiskCacher(&DiskConfig{Path: path})
+		assert.Error(t, err)
+	})
+}
@@ -19,6 +19,8 @@ package cache
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cloudwego/eino/components/embedding"
@@ -27,13 +29,33 @@ import (
 var (
 	ErrCacherRequired    = errors.New("embedding/cache: cacher is required")
 	ErrGeneratorRequired = errors.New("embedding/cache: generator is required")
+	ErrDimensionMismatch = errors.New("embedding/cache: dimension mismatch")
 )
 
+// Embedder caches the embeddings of an [embedding.Embedder].
+//
+// Repeated texts in one call are embedded once, and concurrent calls missing the cache for the same key
+// share one upstream request. Cached vectors whose dimension differs from the upstream model's, e.g. after
+// a model switch, are treated as misses and overwritten with new embeddings.
 type Embedder struct {
 	embedder   embedding.Embedder
 	cacher     Cacher
 	generator  Generator
 	expiration time.Duration
+	dimensions int
+
+	mu sync.Mutex
+	// inflight holds the upstream requests in progress by cache key.
+	inflight map[string]*flight
+	// learnedDims is the dimension of the latest upstream embeddings by model.
+	learnedDims map[string]int
+}
+
+// flight is an upstream request of a key, waited by the other calls missing the same key.
+type flight struct {
+	done  chan struct{}
+	value []float64
+	err   error
 }
 
 type Option interface {
@@ -67,13 +89,26 @@ func WithExpiration(expiration time.Duration) Option {
 	})
 }
 
+// WithDimensions returns an [Option] that sets the dimension of the upstream embeddings.
+// Upstream embeddings of another dimension fail with [ErrDimensionMismatch], and cached vectors of another
+// dimension are treated as misses.
+// If not set, the dimension is learned from the latest upstream embeddings of each model, so stale cached
+// vectors are only detected after the new model has been called once.
+func WithDimensions(dimensions int) Option {
+	return optionFunc(func(e *Embedder) {
+		e.dimensions = dimensions
+	})
+}
+
 var _ embedding.Embedder = (*Embedder)(nil)
 
 // NewEmbedder creates a new [Embedder] instance with cache support.
 func NewEmbedder(embedder embedding.Embedder, opts ...Option) (*Embedder, error) {
 	e := &Embedder{
-		embedder:   embedder,
-		expiration: time.Hour * 2,
+		embedder:    embedder,
+		expiration:  time.Hour * 2,
+		inflight:    make(map[string]*flight),
+		learnedDims: make(map[string]int),
 	}
 	for _, opt := range opts {
 		opt.apply(e)
@@ -92,10 +127,11 @@ func NewEmbedder(embedder embedding.Embedder, opts ...Option) (*Embedder, error)
 
 func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
 	var (
-		embeddingsByKey = make(map[int][]float64)
-		embeddingOpts   = embedding.GetCommonOptions(nil, opts...)
-		uncached        []int
-		uncachedTexts   []string
+		embeddingOpts = embedding.GetCommonOptions(nil, opts...)
+		keys          []string
+		keyTexts      []string
+		keyIndexes    = make([]int, len(texts))
+		seen          = make(map[string]int, len(texts))
 	)
 
 	// generate options for the generator
@@ -104,47 +140,181 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		generatorOpt.Model = *embeddingOpts.Model
 	}
 
-	// Get cached embeddings and find uncached texts
+	// Deduplicate the texts by their keys
 	for idx, text := range texts {
 		key := e.generator.Generate(ctx, text, generatorOpt)
-		emb, ok, err := e.cacher.Get(ctx, key)
-		if err != nil {
-			return nil, err
-		} else if ok {
-			embeddingsByKey[idx] = emb
+		i, ok := seen[key]
+		if !ok {
+			i = len(keys)
+			seen[key] = i
+			keys = append(keys, key)
+			keyTexts = append(keyTexts, text)
+		}
+		keyIndexes[idx] = i
+	}
+
+	// Get cached embeddings and find uncached keys
+	cached, found, err := getMany(ctx, e.cacher, keys)
+	if err != nil {
+		return nil, err
+	}
+	dims := e.dimension(generatorOpt.Model)
+	vectors := make([][]float64, len(keys))
+	var uncached []int
+	for i := range keys {
+		if found[i] && (dims == 0 || len(cached[i]) == dims) {
+			vectors[i] = cached[i]
 		} else {
-			// If the key is not found, we consider it as uncached
-			uncached = append(uncached, idx)
-			uncachedTexts = append(uncachedTexts, text)
+			// If the key is not found or the vector is stale, we consider it as uncached
+			uncached = append(uncached, i)
 		}
 	}
 
 	// Embed the uncached texts
-	if len(uncachedTexts) > 0 {
-		uncachedEmbeddings, err := e.embedder.EmbedStrings(ctx, uncachedTexts, opts...)
-		if err != nil {
+	if len(uncached) > 0 {
+		if err = e.embedUncached(ctx, keys, keyTexts, uncached, vectors, generatorOpt.Model, opts); err != nil {
 			return nil, err
 		}
-
-		// Cache the uncachedEmbeddings
-		for i, idx := range uncached {
-			key := e.generator.Generate(ctx, texts[idx], generatorOpt)
-			if err := e.cacher.Set(ctx, key, uncachedEmbeddings[i], e.expiration); err != nil {
-				_ = err // skip caching if there's an error
-			}
-			embeddingsByKey[idx] = uncachedEmbeddings[i]
-		}
 	}
 
-	// Convert the map to a slice
+	// Map the vectors back to the texts, repeated texts get their own copies
 	result := make([][]float64, len(texts))
-	for i := range texts {
-		if emb, ok := embeddingsByKey[i]; ok {
-			result[i] = emb
+	used := make([]bool, len(keys))
+	for idx, i := range keyIndexes {
+		if used[i] {
+			result[idx] = append([]float64(nil), vectors[i]...)
 		} else {
-			result[i] = nil // it seems that such a case should not happen
+			result[idx] = vectors[i]
+			used[i] = true
 		}
 	}
 
 	return result, nil
 }
+
+// embedUncached embeds the texts of the uncached keys. Keys being embedded by other calls are waited for
+// instead, and embedded again by this call if the other call fails.
+func (e *Embedder) embedUncached(ctx context.Context, keys, texts []string, uncached []int, vectors [][]float64,
+	model string, opts []embedding.Option) (err error) {
+
+	var (
+		owned, waiting []int
+		flights        = make(map[int]*flight, len(uncached))
+	)
+	e.mu.Lock()
+	for _, i := range uncached {
+		if f, ok := e.inflight[keys[i]]; ok {
+			waiting = append(waiting, i)
+			flights[i] = f
+			continue
+		}
+		f := &flight{done: make(chan struct{})}
+		e.inflight[keys[i]] = f
+		owned = append(owned, i)
+		flights[i] = f
+	}
+	e.mu.Unlock()
+
+	if len(owned) > 0 {
+		func() {
+			defer func() {
+				e.mu.Lock()
+				for _, i := range owned {
+					f := flights[i]
+					f.value, f.err = vectors[i], err
+					if f.value == nil && f.err == nil {
+						f.err = errors.New("embedding/cache: upstream request is aborted")
+					}
+					delete(e.inflight, keys[i])
+					close(f.done)
+				}
+				e.mu.Unlock()
+			}()
+			err = e.embed(ctx, texts, keys, owned, vectors, model, opts)
+		}()
+		if err != nil {
+			return err
+		}
+	}
+
+	var retry []int
+	for _, i := range waiting {
+		f := flights[i]
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if f.err != nil {
+			retry = append(retry, i)
+			continue
+		}
+		vectors[i] = append([]float64(nil), f.value...)
+	}
+	if len(retry) > 0 {
+		return e.embed(ctx, texts, keys, retry, vectors, model, opts)
+	}
+	return nil
+}
+
+// embed calls the upstream embedder for the texts of indexes, and caches the embeddings.
+func (e *Embedder) embed(ctx context.Context, texts, keys []string, indexes []int, vectors [][]float64,
+	model string, opts []embedding.Option) error {
+
+	batch := make([]string, len(indexes))
+	for j, i := range indexes {
+		batch[j] = texts[i]
+	}
+	embeddings, err := e.embedder.EmbedStrings(ctx, batch, opts...)
+	if err != nil {
+		return err
+	}
+	if len(embeddings) != len(batch) {
+		return fmt.Errorf("embedding/cache: embedder returned %d embeddings for %d texts", len(embeddings), len(batch))
+	}
+	if err = e.checkDimension(model, embeddings); err != nil {
+		return err
+	}
+
+	// Cache the embeddings
+	entries := make([]Entry, len(indexes))
+	for j, i := range indexes {
+		entries[j] = Entry{Key: keys[i], Value: embeddings[j], Expire: e.expiration}
+		vectors[i] = embeddings[j]
+	}
+	// skip caching if there's an error, the embeddings are simply computed again next time
+	_ = setMany(ctx, e.cacher, entries)
+	return nil
+}
+
+// dimension returns the expected dimension of the embeddings of model, 0 if unknown.
+func (e *Embedder) dimension(model string) int {
+	if e.dimensions > 0 {
+		return e.dimensions
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.learnedDims[model]
+}
+
+// checkDimension checks the upstream embeddings against WithDimensions, or learns their dimension.
+func (e *Embedder) checkDimension(model string, embeddings [][]float64) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	dims := e.dimensions
+	if dims == 0 {
+		dims = len(embeddings[0])
+	}
+	for _, emb := range embeddings {
+		if len(emb) != dims {
+			return fmt.Errorf("%w: expected %d, got %d", ErrDimensionMismatch, dims, len(emb))
+		}
+	}
+	if e.dimensions == 0 {
+		e.mu.Lock()
+		e.learnedDims[model] = dims
+		e.mu.Unlock()
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package cache
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -199,3 +200,260 @@ func TestEmbedder_EmbedStrings(t *testing.T) {
 		me.AssertExpectations(t)
 	})
 }
+
+type mockBatchCacher struct {
+	mockCacher
+}
+
+var _ BatchCacher = (*mockBatchCacher)(nil)
+
+func (m *mockBatchCacher) MGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([][]float64), args.Get(1).([]bool), args.Error(2)
+}
+
+func (m *mockBatchCacher) MSet(ctx context.Context, entries []Entry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func TestEmbedder_EmbedStringsWithBatchCacher(t *testing.T) {
+	ctx := context.Background()
+	texts := []string{"foo", "bar", "baz"}
+	embeddings := [][]float64{{1.1, 2.2}, {3.3, 4.4}, {5.5, 6.6}}
+	expiration := time.Minute
+
+	t.Run("partial cache hit", func(t *testing.T) {
+		mc := new(mockBatchCacher)
+		me := new(mockEmbedder)
+		e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()), WithExpiration(expiration))
+		require.NoError(t, err)
+
+		keys := []string{"foo-", "bar-", "baz-"}
+		mc.On("MGet", mock.Anything, keys).Return([][]float64{nil, embeddings[1], nil}, []bool{false, true, false}, nil)
+		me.On("EmbedStrings", mock.Anything, []string{"foo", "baz"}, mock.Anything).Return([][]float64{embeddings[0], embeddings[2]}, nil)
+		mc.On("MSet", mock.Anything, []Entry{
+			{Key: "foo-", Value: embeddings[0], Expire: expiration},
+			{Key: "baz-", Value: embeddings[2], Expire: expiration},
+		}).Return(errors.New("set error"))
+
+		result, err := e.EmbedStrings(ctx, texts)
+		assert.NoError(t, err)
+		assert.Equal(t, embeddings, result)
+		mc.AssertExpectations(t)
+		me.AssertExpectations(t)
+		mc.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+		mc.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("mget error", func(t *testing.T) {
+		mc := new(mockBatchCacher)
+		me := new(mockEmbedder)
+		e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		mc.On("MGet", mock.Anything, mock.Anything).Return(nil, nil, errors.New("cache error"))
+
+		_, err = e.EmbedStrings(ctx, texts)
+		assert.Error(t, err)
+		mc.AssertExpectations(t)
+		me.AssertExpectations(t)
+	})
+
+	t.Run("mget length mismatch", func(t *testing.T) {
+		mc := new(mockBatchCacher)
+		me := new(mockEmbedder)
+		e, err := NewEmbedder(me, WithCacher(mc), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		mc.On("MGet", mock.Anything, mock.Anything).Return([][]float64{embeddings[0]}, []bool{true}, nil)
+
+		_, err = e.EmbedStrings(ctx, texts)
+		assert.Error(t, err)
+		me.AssertExpectations(t)
+	})
+}
+
+// fakeEmbedder embeds a text into a vector of dims elements, all equal to the length of the text.
+type fakeEmbedder struct {
+	mu      sync.Mutex
+	calls   [][]string
+	dims    int
+	started chan struct{}
+	release chan error
+}
+
+func (f *fakeEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, texts)
+	f.mu.Unlock()
+	if f.started != nil {
+		f.started <- struct{}{}
+	}
+	if f.release != nil {
+		if err := <-f.release; err != nil {
+			return nil, err
+		}
+	}
+	ret := make([][]float64, len(texts))
+	for i, text := range texts {
+		ret[i] = make([]float64, f.dims)
+		for j := range ret[i] {
+			ret[i][j] = float64(len(text))
+		}
+	}
+	return ret, nil
+}
+
+func (f *fakeEmbedder) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func TestEmbedder_Deduplication(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("repeated texts", func(t *testing.T) {
+		fe := &fakeEmbedder{dims: 2}
+		cacher, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		e, err := NewEmbedder(fe, WithCacher(cacher), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		result, err := e.EmbedStrings(ctx, []string{"a", "bb", "a", "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1, 1}, {2, 2}, {1, 1}, {1, 1}}, result)
+		assert.Equal(t, [][]string{{"a", "bb"}}, fe.calls)
+
+		result[0][0] = 9
+		assert.Equal(t, 1.0, result[2][0])
+	})
+
+	t.Run("concurrent calls share one request", func(t *testing.T) {
+		fe := &fakeEmbedder{dims: 2, started: make(chan struct{}, 10), release: make(chan error, 10)}
+		cacher, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		e, err := NewEmbedder(fe, WithCacher(cacher), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		results := make([][][]float64, 5)
+		errs := make([]error, 5)
+		call := func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = e.EmbedStrings(ctx, []string{"hot"})
+		}
+		wg.Add(1)
+		go call(0)
+		<-fe.started
+		for i := 1; i < 5; i++ {
+			wg.Add(1)
+			go call(i)
+		}
+		time.Sleep(20 * time.Millisecond)
+		fe.release <- nil
+		wg.Wait()
+
+		assert.Equal(t, 1, fe.callCount())
+		for i := range results {
+			assert.NoError(t, errs[i])
+			assert.Equal(t, [][]float64{{3, 3}}, results[i])
+		}
+	})
+
+	t.Run("waiters retry after the request fails", func(t *testing.T) {
+		fe := &fakeEmbedder{dims: 2, started: make(chan struct{}, 10), release: make(chan error, 10)}
+		cacher, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		e, err := NewEmbedder(fe, WithCacher(cacher), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		var (
+			wg         sync.WaitGroup
+			firstErr   error
+			secondErr  error
+			secondVecs [][]float64
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, firstErr = e.EmbedStrings(ctx, []string{"hot"})
+		}()
+		<-fe.started
+		go func() {
+			defer wg.Done()
+			secondVecs, secondErr = e.EmbedStrings(ctx, []string{"hot"})
+		}()
+		time.Sleep(20 * time.Millisecond)
+		fe.release <- errors.New("rate limited")
+		<-fe.started
+		fe.release <- nil
+		wg.Wait()
+
+		assert.Error(t, firstErr)
+		assert.NoError(t, secondErr)
+		assert.Equal(t, [][]float64{{3, 3}}, secondVecs)
+		assert.Equal(t, 2, fe.callCount())
+	})
+}
+
+func TestEmbedder_Dimensions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("configured dimensions", func(t *testing.T) {
+		fe := &fakeEmbedder{dims: 2}
+		cacher, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		e, err := NewEmbedder(fe, WithCacher(cacher), WithGenerator(NewSimpleGenerator()), WithDimensions(2))
+		require.NoError(t, err)
+
+		// a stale vector of the old model is embedded again and overwritten
+		require.NoError(t, cacher.Set(ctx, "a-", []float64{7, 7, 7}, 0))
+		result, err := e.EmbedStrings(ctx, []string{"a"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1, 1}}, result)
+		value, _, _ := cacher.Get(ctx, "a-")
+		assert.Equal(t, []float64{1, 1}, value)
+
+		fe.dims = 3
+		_, err = e.EmbedStrings(ctx, []string{"bb"})
+		assert.ErrorIs(t, err, ErrDimensionMismatch)
+		_, ok, _ := cacher.Get(ctx, "bb-")
+		assert.False(t, ok)
+	})
+
+	t.Run("learned dimensions", func(t *testing.T) {
+		fe := &fakeEmbedder{dims: 2}
+		cacher, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		e, err := NewEmbedder(fe, WithCacher(cacher), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		require.NoError(t, cacher.Set(ctx, "bb-", []float64{7, 7, 7}, 0))
+		result, err := e.EmbedStrings(ctx, []string{"bb"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{7, 7, 7}}, result)
+
+		_, err = e.EmbedStrings(ctx, []string{"a"})
+		assert.NoError(t, err)
+		result, err = e.EmbedStrings(ctx, []string{"bb"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{2, 2}}, result)
+	})
+
+	t.Run("embedding count mismatch", func(t *testing.T) {
+		me := new(mockEmbedder)
+		cacher, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		e, err := NewEmbedder(me, WithCacher(cacher), WithGenerator(NewSimpleGenerator()))
+		require.NoError(t, err)
+
+		me.On("EmbedStrings", mock.Anything, []string{"a", "b"}, mock.Anything).Return([][]float64{{1}}, nil)
+		_, err = e.EmbedStrings(ctx, []string{"a", "b"})
+		assert.Error(t, err)
+	})
+}
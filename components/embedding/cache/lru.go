@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// lruEntryOverhead approximates the memory of the list element, map entry and headers of an entry,
+// besides its key and vector.
+const lruEntryOverhead = 128
+
+// LRUConfig is the config of LRUCacher.
+type LRUConfig struct {
+	// MaxEntries is the max number of cached vectors, 0 means no limit.
+	MaxEntries int
+	// MaxBytes is the max memory in bytes of cached vectors, counting their keys, their elements and a fixed
+	// overhead per entry. 0 means no limit.
+	MaxBytes int64
+	// Float32 stores vectors as float32, which halves the memory at the cost of precision.
+	Float32 bool
+}
+
+// LRUCacher is an in-process [Cacher] bounded by the number of entries and memory.
+// The least recently used entries are evicted when a bound is exceeded, and expired entries are
+// dropped when they are read or evicted. An expiration of 0 means the entry never expires.
+type LRUCacher struct {
+	mu         sync.Mutex
+	ll         *list.List
+	items      map[string]*list.Element
+	bytes      int64
+	maxEntries int
+	maxBytes   int64
+	float32    bool
+	now        func() time.Time
+}
+
+type lruEntry struct {
+	key       string
+	v64       []float64
+	v32       []float32
+	size      int64
+	expiresAt time.Time
+}
+
+var _ BatchCacher = (*LRUCacher)(nil)
+
+// NewLRUCacher creates a new [LRUCacher].
+func NewLRUCacher(config *LRUConfig) (*LRUCacher, error) {
+	if config == nil {
+		config = &LRUConfig{}
+	}
+	if config.MaxEntries < 0 || config.MaxBytes < 0 {
+		return nil, errors.New("embedding/cache: max entries and max bytes must be non-negative")
+	}
+	return &LRUCacher{
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		maxEntries: config.MaxEntries,
+		maxBytes:   config.MaxBytes,
+		float32:    config.Float32,
+		now:        time.Now,
+	}, nil
+}
+
+func (c *LRUCacher) Set(_ context.Context, key string, value []float64, expire time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, expire)
+	return nil
+}
+
+func (c *LRUCacher) Get(_ context.Context, key string) ([]float64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.get(key)
+	return value, ok, nil
+}
+
+func (c *LRUCacher) MGet(_ context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, key := range keys {
+		values[i], found[i] = c.get(key)
+	}
+	return values, found, nil
+}
+
+func (c *LRUCacher) MSet(_ context.Context, entries []Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range entries {
+		c.set(entry.Key, entry.Value, entry.Expire)
+	}
+	return nil
+}
+
+// Len returns the number of cached entries, including the expired ones not dropped yet.
+func (c *LRUCacher) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Bytes returns the memory accounted for the cached entries.
+func (c *LRUCacher) Bytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bytes
+}
+
+func (c *LRUCacher) get(key string) ([]float64, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+
+	// return a copy, so that callers can not modify the cached vector
+	if entry.v32 != nil {
+		value := make([]float64, len(entry.v32))
+		for i, f := range entry.v32 {
+			value[i] = float64(f)
+		}
+		return value, true
+	}
+	return append([]float64(nil), entry.v64...), true
+}
+
+func (c *LRUCacher) set(key string, value []float64, expire time.Duration) {
+	entry := &lruEntry{key: key}
+	elemSize := int64(8)
+	if c.float32 {
+		entry.v32 = make([]float32, len(value))
+		for i, f := range value {
+			entry.v32[i] = float32(f)
+		}
+		elemSize = 4
+	} else {
+		entry.v64 = append(make([]float64, 0, len(value)), value...)
+	}
+	entry.size = int64(len(key)) + int64(len(value))*elemSize + lruEntryOverhead
+	if expire > 0 {
+		entry.expiresAt = c.now().Add(expire)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+	if c.maxBytes > 0 && entry.size > c.maxBytes {
+		// the entry can never fit
+		return
+	}
+	c.items[key] = c.ll.PushFront(entry)
+	c.bytes += entry.size
+
+	for (c.maxEntries > 0 && c.ll.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.ll.Back())
+	}
+}
+
+func (c *LRUCacher) remove(elem *list.Element) {
+	entry := c.ll.Remove(elem).(*lruEntry)
+	delete(c.items, entry.key)
+	c.bytes -= entry.size
+}
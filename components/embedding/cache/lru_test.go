@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCacher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("max entries", func(t *testing.T) {
+		c, err := NewLRUCacher(&LRUConfig{MaxEntries: 2})
+		require.NoError(t, err)
+
+		assert.NoError(t, c.Set(ctx, "a", []float64{1}, 0))
+		assert.NoError(t, c.Set(ctx, "b", []float64{2}, 0))
+		_, ok, _ := c.Get(ctx, "a")
+		assert.True(t, ok)
+		assert.NoError(t, c.Set(ctx, "c", []float64{3}, 0))
+
+		values, found, err := c.MGet(ctx, []string{"a", "b", "c"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}, nil, {3}}, values)
+		assert.Equal(t, []bool{true, false, true}, found)
+		assert.Equal(t, 2, c.Len())
+	})
+
+	t.Run("max bytes", func(t *testing.T) {
+		c, err := NewLRUCacher(&LRUConfig{MaxBytes: 2 * (lruEntryOverhead + 1 + 4*8)})
+		require.NoError(t, err)
+
+		assert.NoError(t, c.MSet(ctx, []Entry{
+			{Key: "a", Value: []float64{1, 2, 3, 4}},
+			{Key: "b", Value: []float64{1, 2, 3, 4}},
+		}))
+		assert.Equal(t, int64(2*(lruEntryOverhead+1+4*8)), c.Bytes())
+		assert.NoError(t, c.Set(ctx, "c", []float64{1}, 0))
+		assert.Equal(t, 2, c.Len())
+		_, ok, _ := c.Get(ctx, "a")
+		assert.False(t, ok)
+
+		// overwriting releases the memory of the old vector
+		assert.NoError(t, c.Set(ctx, "c", []float64{1, 2}, 0))
+		assert.Equal(t, int64(2*lruEntryOverhead+2+6*8), c.Bytes())
+
+		// an entry larger than the limit is not cached
+		assert.NoError(t, c.Set(ctx, "d", make([]float64, 100), 0))
+		_, ok, _ = c.Get(ctx, "d")
+		assert.False(t, ok)
+		assert.Equal(t, 2, c.Len())
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		c, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		assert.NoError(t, c.Set(ctx, "a", []float64{1}, time.Minute))
+		assert.NoError(t, c.Set(ctx, "b", []float64{2}, 0))
+		now = now.Add(time.Minute)
+
+		_, ok, _ := c.Get(ctx, "a")
+		assert.False(t, ok)
+		_, ok, _ = c.Get(ctx, "b")
+		assert.True(t, ok)
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("float32 and copies", func(t *testing.T) {
+		c, err := NewLRUCacher(&LRUConfig{Float32: true})
+		require.NoError(t, err)
+
+		value := []float64{0.5, 1.1}
+		assert.NoError(t, c.Set(ctx, "a", value, 0))
+		value[0] = 9
+		got, ok, err := c.Get(ctx, "a")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 0.5, got[0])
+		assert.InDelta(t, 1.1, got[1], 1e-6)
+		assert.NotEqual(t, 1.1, got[1])
+		assert.Equal(t, int64(lruEntryOverhead+1+2*4), c.Bytes())
+
+		got[0] = 9
+		got, _, _ = c.Get(ctx, "a")
+		assert.Equal(t, 0.5, got[0])
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewLRUCacher(&LRUConfig{MaxBytes: -1})
+		assert.Error(t, err)
+	})
+}
@@ -29,7 +29,7 @@ import (
 type Cacher struct {
 	rdb    redis.UniversalClient
 	prefix string
-	codec  codec
+	codec  Codec
 }
 
 type Option interface {
@@ -48,7 +48,15 @@ func WithPrefix(prefix string) Option {
 	})
 }
 
-var _ cache.Cacher = (*Cacher)(nil)
+// WithCodec sets the [Codec] of vectors, [NewJSONCodec] by default.
+// e.g. NewBinaryCodec(&BinaryCodecConfig{Float32: true}) takes about a quarter of the space of JSON.
+func WithCodec(codec Codec) Option {
+	return optionFunc(func(c *Cacher) {
+		c.codec = codec
+	})
+}
+
+var _ cache.BatchCacher = (*Cacher)(nil)
 
 func NewCacher(rdb redis.UniversalClient, opts ...Option) *Cacher {
 	cacher := &Cacher{
@@ -85,3 +93,56 @@ func (c *Cacher) Get(ctx context.Context, key string) ([]float64, bool, error) {
 	}
 	return value, true, nil
 }
+
+// MGet retrieves the values of keys with one pipeline of GET commands, which works with cluster clients
+// as well, unlike the MGET command.
+func (c *Cacher) MGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	values := make([][]float64, len(keys))
+	found := make([]bool, len(keys))
+	if len(keys) == 0 {
+		return values, found, nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, c.prefix+key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, nil, err
+	}
+
+	for i, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			return nil, nil, err
+		}
+		var value []float64
+		if err := c.codec.Unmarshal(data, &value); err != nil {
+			return nil, nil, err
+		}
+		values[i], found[i] = value, true
+	}
+	return values, found, nil
+}
+
+// MSet stores the entries with one pipeline of SET commands, each with its own expiration.
+func (c *Cacher) MSet(ctx context.Context, entries []cache.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	for _, entry := range entries {
+		data, err := c.codec.Marshal(entry.Value)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, c.prefix+entry.Key, data, entry.Expire)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
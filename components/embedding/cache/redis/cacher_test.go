@@ -19,10 +19,14 @@ package redis
 import (
 	"context"
 	"errors"
+	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/components/embedding/cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -34,7 +38,7 @@ type mockRedisClient struct {
 }
 
 type mockCodec struct {
-	codec
+	Codec
 	mock.Mock
 }
 
@@ -159,8 +163,127 @@ func TestCacher(t *testing.T) {
 	})
 }
 
+func TestWithCodec(t *testing.T) {
+	ctx := context.Background()
+	f, rdb := newFakeRedis()
+	codec, err := NewBinaryCodec(&BinaryCodecConfig{Float32: true})
+	require.NoError(t, err)
+	c := NewCacher(rdb, WithCodec(codec))
+
+	require.NoError(t, c.Set(ctx, "a", []float64{1, 2}, 0))
+	assert.Len(t, f.data["eino:a"], headerSize+2*4)
+
+	// entries written with the json codec stay readable
+	f.data["eino:b"] = "[3,4]"
+	values, found, err := c.MGet(ctx, []string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{1, 2}, {3, 4}}, values)
+	assert.Equal(t, []bool{true, true}, found)
+}
+
 func TestWithPrefix(t *testing.T) {
 	assert.Equal(t, "eino:", NewCacher(nil).prefix)
 	assert.Equal(t, "custom:", NewCacher(nil, WithPrefix("custom:")).prefix)
 	assert.Equal(t, "custom:", NewCacher(nil, WithPrefix("custom")).prefix)
 }
+
+// fakeRedis serves GET and SET commands from memory in a pipeline hook, so pipelines never reach the network.
+type fakeRedis struct {
+	data      map[string]string
+	ttls      map[string]time.Duration
+	pipelines int
+	err       error
+}
+
+func newFakeRedis() (*fakeRedis, redis.UniversalClient) {
+	f := &fakeRedis{data: map[string]string{}, ttls: map[string]time.Duration{}}
+	rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	rdb.AddHook(f)
+	return f, rdb
+}
+
+func (f *fakeRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("fake redis does not dial")
+	}
+}
+
+func (f *fakeRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		return f.ProcessPipelineHook(nil)(ctx, []redis.Cmder{cmd})
+	}
+}
+
+func (f *fakeRedis) ProcessPipelineHook(_ redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		f.pipelines++
+		for _, cmd := range cmds {
+			if f.err != nil {
+				cmd.SetErr(f.err)
+				continue
+			}
+			args := cmd.Args()
+			key := args[1].(string)
+			switch c := cmd.(type) {
+			case *redis.StringCmd:
+				if v, ok := f.data[key]; ok {
+					c.SetVal(v)
+				} else {
+					c.SetErr(redis.Nil)
+				}
+			case *redis.StatusCmd:
+				f.data[key] = string(args[2].([]byte))
+				if len(args) == 5 && strings.EqualFold(args[3].(string), "ex") {
+					f.ttls[key] = time.Duration(args[4].(int64)) * time.Second
+				}
+				c.SetVal("OK")
+			}
+		}
+		return f.err
+	}
+}
+
+func TestCacherBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("MSet and MGet", func(t *testing.T) {
+		f, rdb := newFakeRedis()
+		c := NewCacher(rdb)
+
+		err := c.MSet(ctx, []cache.Entry{
+			{Key: "a", Value: []float64{1, 2}, Expire: time.Minute},
+			{Key: "b", Value: []float64{3, 4}, Expire: time.Hour},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, f.pipelines)
+		assert.Equal(t, time.Minute, f.ttls["eino:a"])
+		assert.Equal(t, time.Hour, f.ttls["eino:b"])
+
+		values, found, err := c.MGet(ctx, []string{"a", "missing", "b"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, f.pipelines)
+		assert.Equal(t, [][]float64{{1, 2}, nil, {3, 4}}, values)
+		assert.Equal(t, []bool{true, false, true}, found)
+
+		values, found, err = c.MGet(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, values)
+		assert.Empty(t, found)
+		assert.NoError(t, c.MSet(ctx, nil))
+		assert.Equal(t, 2, f.pipelines)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		f, rdb := newFakeRedis()
+		c := NewCacher(rdb)
+		f.data["eino:a"] = "not a vector"
+
+		_, _, err := c.MGet(ctx, []string{"a"})
+		assert.Error(t, err)
+
+		f.err = errors.New("connection refused")
+		_, _, err = c.MGet(ctx, []string{"a"})
+		assert.Equal(t, f.err, err)
+		assert.Equal(t, f.err, c.MSet(ctx, []cache.Entry{{Key: "a", Value: []float64{1}}}))
+	})
+}
@@ -16,15 +16,33 @@
 
 package redis
 
-import "github.com/bytedance/sonic"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
 
-var defaultCodec codec = &sonicCodec{}
+	"github.com/bytedance/sonic"
+	"github.com/klauspost/compress/zstd"
+)
 
-type codec interface {
+var defaultCodec Codec = &sonicCodec{}
+
+// Codec encodes the vectors stored in redis.
+// Unmarshal is called with a *[]float64, and Marshal with a []float64.
+type Codec interface {
 	Marshal(v any) ([]byte, error)
 	Unmarshal(data []byte, v any) error
 }
 
+// NewJSONCodec returns the default [Codec], which encodes vectors as JSON arrays.
+// It also decodes the values written by the binary codec, so that a deployment can roll back
+// from the binary codec.
+func NewJSONCodec() Codec {
+	return &sonicCodec{}
+}
+
 type sonicCodec struct{}
 
 func (*sonicCodec) Marshal(v any) ([]byte, error) {
@@ -32,5 +50,142 @@ func (*sonicCodec) Marshal(v any) ([]byte, error) {
 }
 
 func (*sonicCodec) Unmarshal(data []byte, v any) error {
+	if isBinary(data) {
+		return unmarshalBinary(data, v)
+	}
+	return sonic.Unmarshal(data, v)
+}
+
+// Binary values start with a header of three bytes: binaryMagic, the format version, and the flags.
+// binaryMagic never starts a JSON value, so binary and JSON values can be told apart.
+const (
+	binaryMagic   byte = 0xEB
+	binaryVersion byte = 1
+	headerSize         = 3
+
+	flagFloat32 byte = 1 << 0
+	flagZstd    byte = 1 << 1
+)
+
+// BinaryCodecConfig is the config of the binary codec.
+type BinaryCodecConfig struct {
+	// Float32 stores vectors as float32, which halves the size at the cost of precision.
+	Float32 bool
+	// Zstd compresses the vectors with zstd. Embeddings compress poorly, so it mostly pays off with Float32
+	// disabled, or for vectors with many zero elements.
+	Zstd bool
+	// ZstdLevel is the zstd encoder level, zstd.SpeedDefault by default.
+	ZstdLevel zstd.EncoderLevel
+}
+
+// NewBinaryCodec returns a [Codec] encoding vectors as little-endian float64 or float32 elements after a
+// versioned header. It still decodes the JSON values written by the default codec, so existing entries stay
+// readable while a deployment migrates.
+func NewBinaryCodec(config *BinaryCodecConfig) (Codec, error) {
+	if config == nil {
+		config = &BinaryCodecConfig{}
+	}
+	c := &binaryCodec{float32: config.Float32}
+	if config.Zstd {
+		level := config.ZstdLevel
+		if level == 0 {
+			level = zstd.SpeedDefault
+		}
+		encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(level), zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("create zstd encoder fail: %w", err)
+		}
+		c.encoder = encoder
+	}
+	return c, nil
+}
+
+type binaryCodec struct {
+	float32 bool
+	encoder *zstd.Encoder
+}
+
+func (c *binaryCodec) Marshal(v any) ([]byte, error) {
+	value, ok := v.([]float64)
+	if !ok {
+		return nil, fmt.Errorf("binary codec can only marshal []float64, got %T", v)
+	}
+
+	flags := byte(0)
+	width := 8
+	if c.float32 {
+		flags |= flagFloat32
+		width = 4
+	}
+	payload := make([]byte, 0, len(value)*width)
+	for _, f := range value {
+		if c.float32 {
+			payload = binary.LittleEndian.AppendUint32(payload, math.Float32bits(float32(f)))
+		} else {
+			payload = binary.LittleEndian.AppendUint64(payload, math.Float64bits(f))
+		}
+	}
+
+	header := []byte{binaryMagic, binaryVersion, flags}
+	if c.encoder != nil {
+		header[2] |= flagZstd
+		return c.encoder.EncodeAll(payload, header), nil
+	}
+	return append(header, payload...), nil
+}
+
+func (c *binaryCodec) Unmarshal(data []byte, v any) error {
+	if isBinary(data) {
+		return unmarshalBinary(data, v)
+	}
 	return sonic.Unmarshal(data, v)
 }
+
+func isBinary(data []byte) bool {
+	return len(data) >= headerSize && data[0] == binaryMagic
+}
+
+// zstdDecoder is shared by all codecs, values may be compressed even if the codec decoding them is not
+// configured to compress.
+var zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+})
+
+func unmarshalBinary(data []byte, v any) error {
+	ptr, ok := v.(*[]float64)
+	if !ok {
+		return fmt.Errorf("binary codec can only unmarshal into *[]float64, got %T", v)
+	}
+	if data[1] != binaryVersion {
+		return fmt.Errorf("unsupported binary codec version: %d", data[1])
+	}
+	flags := data[2]
+	payload := data[headerSize:]
+	if flags&flagZstd != 0 {
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return fmt.Errorf("create zstd decoder fail: %w", err)
+		}
+		if payload, err = decoder.DecodeAll(payload, nil); err != nil {
+			return fmt.Errorf("decompress vector fail: %w", err)
+		}
+	}
+
+	width := 8
+	if flags&flagFloat32 != 0 {
+		width = 4
+	}
+	if len(payload)%width != 0 {
+		return errors.New("binary vector is truncated")
+	}
+	value := make([]float64, len(payload)/width)
+	for i := range value {
+		if width == 4 {
+			value[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(payload[i*4:])))
+		} else {
+			value[i] = math.Float64frombits(binary.LittleEndian.Uint64(payload[i*8:]))
+		}
+	}
+	*ptr = value
+	return nil
+}
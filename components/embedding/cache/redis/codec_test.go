@@ -42,3 +42,72 @@ func TestCodec_Sonic(t *testing.T) {
 func TestCodec_Default(t *testing.T) {
 	assert.Equal(t, &sonicCodec{}, defaultCodec)
 }
+
+func TestCodec_Binary(t *testing.T) {
+	v := []float64{0.5, -1.25, 3.1, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	jsonData, err := NewJSONCodec().Marshal(v)
+	require.NoError(t, err)
+
+	t.Run("float64", func(t *testing.T) {
+		c, err := NewBinaryCodec(nil)
+		require.NoError(t, err)
+
+		data, err := c.Marshal(v)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{binaryMagic, binaryVersion, 0}, data[:headerSize])
+		assert.Len(t, data, headerSize+8*len(v))
+
+		var out []float64
+		require.NoError(t, c.Unmarshal(data, &out))
+		assert.Equal(t, v, out)
+
+		// values written by the binary codec stay readable after rolling back to the json codec
+		out = nil
+		require.NoError(t, NewJSONCodec().Unmarshal(data, &out))
+		assert.Equal(t, v, out)
+
+		// and json values written before migrating are still readable
+		out = nil
+		require.NoError(t, c.Unmarshal(jsonData, &out))
+		assert.Equal(t, v, out)
+	})
+
+	t.Run("float32 with zstd", func(t *testing.T) {
+		c, err := NewBinaryCodec(&BinaryCodecConfig{Float32: true, Zstd: true})
+		require.NoError(t, err)
+
+		data, err := c.Marshal(v)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{binaryMagic, binaryVersion, flagFloat32 | flagZstd}, data[:headerSize])
+		assert.Less(t, len(data), headerSize+4*len(v))
+
+		var out []float64
+		require.NoError(t, c.Unmarshal(data, &out))
+		require.Len(t, out, len(v))
+		for i := range v {
+			assert.InDelta(t, v[i], out[i], 1e-6)
+		}
+
+		// compressed values are readable by codecs without compression
+		plain, err := NewBinaryCodec(nil)
+		require.NoError(t, err)
+		out = nil
+		require.NoError(t, plain.Unmarshal(data, &out))
+		assert.Len(t, out, len(v))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c, err := NewBinaryCodec(nil)
+		require.NoError(t, err)
+
+		_, err = c.Marshal("not a vector")
+		assert.Error(t, err)
+
+		var out []float64
+		assert.Error(t, c.Unmarshal([]byte{binaryMagic, 2, 0}, &out))
+		assert.Error(t, c.Unmarshal([]byte{binaryMagic, binaryVersion, 0, 1, 2, 3}, &out))
+		assert.Error(t, c.Unmarshal([]byte{binaryMagic, binaryVersion, flagZstd, 1, 2, 3}, &out))
+		var s string
+		assert.Error(t, c.Unmarshal([]byte{binaryMagic, binaryVersion, 0}, &s))
+	})
+}
@@ -7,6 +7,7 @@ replace github.com/cloudwego/eino-ext/components/embedding/cache => ../
 require (
 	github.com/bytedance/sonic v1.14.1
 	github.com/cloudwego/eino-ext/components/embedding/cache v0.0.0-00010101000000-000000000000
+	github.com/klauspost/compress v1.18.0
 	github.com/redis/go-redis/v9 v9.8.0
 	github.com/stretchr/testify v1.10.0
 )
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TieredConfig is the config of TieredCacher.
+type TieredConfig struct {
+	// Near is the fast cacher checked first, usually an [LRUCacher], required.
+	Near Cacher
+	// Far is the cacher behind Near, e.g. a [DiskCacher] or a redis cacher, required.
+	Far Cacher
+	// NearExpiration is the max expiration of the entries in Near. Entries found in Far are copied into Near
+	// with it, as their remaining expiration in Far is unknown. 10 minutes by default.
+	NearExpiration time.Duration
+}
+
+// TieredCacher layers a near cacher in front of a far one. Get checks Near first and then Far, copying the
+// entries found in Far into Near, and Set stores entries into both.
+type TieredCacher struct {
+	near           Cacher
+	far            Cacher
+	nearExpiration time.Duration
+}
+
+var _ BatchCacher = (*TieredCacher)(nil)
+
+// NewTieredCacher creates a new [TieredCacher].
+func NewTieredCacher(config *TieredConfig) (*TieredCacher, error) {
+	if config == nil || config.Near == nil || config.Far == nil {
+		return nil, errors.New("embedding/cache: near and far cachers are required")
+	}
+	nearExpiration := config.NearExpiration
+	if nearExpiration <= 0 {
+		nearExpiration = 10 * time.Minute
+	}
+	return &TieredCacher{
+		near:           config.Near,
+		far:            config.Far,
+		nearExpiration: nearExpiration,
+	}, nil
+}
+
+func (c *TieredCacher) Set(ctx context.Context, key string, value []float64, expire time.Duration) error {
+	return c.MSet(ctx, []Entry{{Key: key, Value: value, Expire: expire}})
+}
+
+func (c *TieredCacher) Get(ctx context.Context, key string) ([]float64, bool, error) {
+	values, found, err := c.MGet(ctx, []string{key})
+	if err != nil {
+		return nil, false, err
+	}
+	return values[0], found[0], nil
+}
+
+// MGet gets the keys from Near, and the missing ones from Far. Errors of Near are treated as misses.
+func (c *TieredCacher) MGet(ctx context.Context, keys []string) ([][]float64, []bool, error) {
+	values, found, err := getMany(ctx, c.near, keys)
+	if err != nil {
+		values, found = make([][]float64, len(keys)), make([]bool, len(keys))
+	}
+
+	var (
+		missing    []int
+		missedKeys []string
+	)
+	for i, ok := range found {
+		if !ok {
+			missing = append(missing, i)
+			missedKeys = append(missedKeys, keys[i])
+		}
+	}
+	if len(missing) == 0 {
+		return values, found, nil
+	}
+
+	farValues, farFound, err := getMany(ctx, c.far, missedKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	var entries []Entry
+	for j, i := range missing {
+		if !farFound[j] {
+			continue
+		}
+		values[i], found[i] = farValues[j], true
+		entries = append(entries, Entry{Key: keys[i], Value: farValues[j], Expire: c.nearExpiration})
+	}
+	if len(entries) > 0 {
+		// a failed copy only makes the next Get slower
+		_ = setMany(ctx, c.near, entries)
+	}
+	return values, found, nil
+}
+
+// MSet stores the entries into Far and then Near, Near keeps them no longer than NearExpiration.
+func (c *TieredCacher) MSet(ctx context.Context, entries []Entry) error {
+	if err := setMany(ctx, c.far, entries); err != nil {
+		return err
+	}
+	nearEntries := make([]Entry, len(entries))
+	for i, entry := range entries {
+		nearEntries[i] = entry
+		if entry.Expire <= 0 || entry.Expire > c.nearExpiration {
+			nearEntries[i].Expire = c.nearExpiration
+		}
+	}
+	return setMany(ctx, c.near, nearEntries)
+}
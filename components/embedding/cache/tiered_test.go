@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTieredCacher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get through tiers", func(t *testing.T) {
+		near, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		far, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		c, err := NewTieredCacher(&TieredConfig{Near: near, Far: far, NearExpiration: time.Minute})
+		require.NoError(t, err)
+
+		assert.NoError(t, far.Set(ctx, "far", []float64{1}, 0))
+		assert.NoError(t, c.Set(ctx, "both", []float64{2}, time.Hour))
+		assert.Equal(t, 1, near.Len())
+
+		values, found, err := c.MGet(ctx, []string{"far", "both", "missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]float64{{1}, {2}, nil}, values)
+		assert.Equal(t, []bool{true, true, false}, found)
+
+		// the far entry is copied into near, which keeps entries no longer than NearExpiration
+		now := time.Now().Add(59 * time.Second)
+		near.now = func() time.Time { return now }
+		_, ok, _ := near.Get(ctx, "far")
+		assert.True(t, ok)
+		now = now.Add(time.Second)
+		_, ok, _ = near.Get(ctx, "both")
+		assert.False(t, ok)
+		value, ok, err := c.Get(ctx, "both")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []float64{2}, value)
+	})
+
+	t.Run("per-key far cacher", func(t *testing.T) {
+		near, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		far := new(mockCacher)
+		c, err := NewTieredCacher(&TieredConfig{Near: near, Far: far})
+		require.NoError(t, err)
+
+		far.On("Get", mock.Anything, "a").Return([]float64{1}, true, nil).Once()
+		far.On("Set", mock.Anything, "b", []float64{2}, time.Hour).Return(nil)
+
+		assert.NoError(t, c.Set(ctx, "b", []float64{2}, time.Hour))
+		for i := 0; i < 2; i++ {
+			value, ok, err := c.Get(ctx, "a")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, []float64{1}, value)
+		}
+		far.AssertExpectations(t)
+	})
+
+	t.Run("far errors", func(t *testing.T) {
+		near, err := NewLRUCacher(nil)
+		require.NoError(t, err)
+		far := new(mockCacher)
+		c, err := NewTieredCacher(&TieredConfig{Near: near, Far: far})
+		require.NoError(t, err)
+
+		far.On("Get", mock.Anything, "a").Return(nil, false, errors.New("get error"))
+		far.On("Set", mock.Anything, "a", []float64{1}, time.Hour).Return(errors.New("set error"))
+
+		_, _, err = c.Get(ctx, "a")
+		assert.Error(t, err)
+		assert.Error(t, c.Set(ctx, "a", []float64{1}, time.Hour))
+		assert.Equal(t, 0, near.Len())
+
+		_, err = NewTieredCacher(&TieredConfig{Near: near})
+		assert.Error(t, err)
+	})
+}